@@ -47,17 +47,18 @@ func appStatus(app *kcv1alpha1.App) (string, bool) {
 		return "Paused", true
 	}
 	for _, condition := range app.Status.Conditions {
+		isFailing := conditionSeverity(condition.Type) == ConditionSeverityError
 		switch condition.Type {
 		case kcv1alpha1.ReconcileFailed:
-			return "Reconcile failed", true
+			return "Reconcile failed", isFailing
 		case kcv1alpha1.ReconcileSucceeded:
-			return "Reconcile succeeded", false
+			return "Reconcile succeeded", isFailing
 		case kcv1alpha1.DeleteFailed:
-			return "Deletion failed", true
+			return "Deletion failed", isFailing
 		case kcv1alpha1.Reconciling:
-			return "Reconciling", false
+			return "Reconciling", isFailing
 		case kcv1alpha1.Deleting:
-			return "Deleting", false
+			return "Deleting", isFailing
 		}
 	}
 	return app.Status.FriendlyDescription, false
@@ -74,12 +75,17 @@ func HasReconciled(status kcv1alpha1.AppStatus) bool {
 
 func HasFailed(status kcv1alpha1.AppStatus) (bool, string) {
 	for _, condition := range status.Conditions {
-		if condition.Type == kcv1alpha1.ReconcileFailed && condition.Status == corev1.ConditionTrue {
-			return true, color.RedString(fmt.Sprintf("%s: %s", kcv1alpha1.ReconcileFailed, status.UsefulErrorMessage))
+		if condition.Status != corev1.ConditionTrue {
+			continue
 		}
-		if condition.Type == kcv1alpha1.DeleteFailed && condition.Status == corev1.ConditionTrue {
-			return true, color.RedString(fmt.Sprintf("%s: %s", kcv1alpha1.DeleteFailed, status.UsefulErrorMessage))
+		if condition.Type != kcv1alpha1.ReconcileFailed && condition.Type != kcv1alpha1.DeleteFailed {
+			continue
 		}
+		// Downgraded failure conditions do not fail the command
+		if conditionSeverity(condition.Type) != ConditionSeverityError {
+			continue
+		}
+		return true, color.RedString(fmt.Sprintf("%s: %s", condition.Type, status.UsefulErrorMessage))
 	}
 	return false, ""
 }
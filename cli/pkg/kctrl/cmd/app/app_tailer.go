@@ -6,6 +6,8 @@ package app
 import (
 	"context"
 	"fmt"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/cppforlife/go-cli-ui/ui"
@@ -16,6 +18,7 @@ import (
 	kcexternalversions "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/informers/externalversions"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
 	"k8s.io/client-go/tools/cache"
 )
 
@@ -33,6 +36,8 @@ type AppTailer struct {
 
 	lastSeenDeployStdout string
 
+	spinner *spinner
+
 	columns *[]string
 }
 
@@ -40,10 +45,60 @@ type AppTailerOpts struct {
 	IgnoreNotExists   bool
 	PrintMetadata     bool
 	PrintCurrentState bool
+
+	// LabelSelector selects the watched App by label instead of
+	// by metadata.name (useful with generated names). When empty,
+	// selection falls back to the App name.
+	LabelSelector string
+
+	// Verbose prints full stage output even on interactive TTYs
+	// where it would otherwise be collapsed to one-line summaries.
+	Verbose bool
+
+	// Debug prints additional diagnostics, e.g. a short diff of the
+	// App spec when a spec change triggers a reconcile.
+	Debug bool
+
+	// Quiet suppresses intermediate stage output, printing only the
+	// terminal reconciled/failed line and any error output.
+	Quiet bool
+
+	// OnlyFailures suppresses successful stage output like Quiet, but
+	// prints the failed stage's full stdout alongside its error output.
+	OnlyFailures bool
+
+	// MaxLogAge discards stage error output older than this duration
+	// (zero disables the age check).
+	MaxLogAge time.Duration
+
+	// Clock used for timestamped output; defaults to the real clock
+	// when nil (tests can freeze it for deterministic output).
+	Clock cmdcore.Clock
+
+	// ErrorUI receives error lines and blocks instead of the main UI
+	// when set (e.g. so embedding hosts can route errors to a log).
+	ErrorUI ui.UI
+
+	// Stages filters which stages' output is printed (empty prints
+	// all). Terminal detection is unaffected by the filter.
+	Stages StageFilter
+
+	// DeployDurations holds recent historical deploy durations for this
+	// App (from its recorded reconcile history) used to print estimated
+	// remaining time during a long deploy. Empty omits the estimate.
+	DeployDurations []time.Duration
 }
 
 func NewAppTailer(namespace string, name string, ui ui.UI, client kcclient.Interface, opts AppTailerOpts, columns *[]string) *AppTailer {
-	return &AppTailer{Namespace: namespace, Name: name, opts: opts, ui: ui, statusUI: cmdcore.NewStatusLoggingUI(ui), client: client, columns: columns}
+	if opts.Clock == nil {
+		opts.Clock = cmdcore.NewRealClock()
+	}
+	statusUI := cmdcore.NewStatusLoggingUIWithClock(ui, opts.Clock)
+	if opts.ErrorUI != nil {
+		statusUI = cmdcore.NewStatusLoggingUIWithSinks(ui, opts.ErrorUI, opts.Clock)
+	}
+	return &AppTailer{Namespace: namespace, Name: name, opts: opts, ui: ui,
+		statusUI: statusUI, client: client, columns: columns}
 }
 
 func (o *AppTailer) printTillCurrent(status kcv1alpha1.AppStatus) error {
@@ -51,18 +106,57 @@ func (o *AppTailer) printTillCurrent(status kcv1alpha1.AppStatus) error {
 		return nil
 	}
 
-	completed, deployOutput, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, status, o.statusUI, o.lastSeenDeployStdout).PrintUpdate()
+	completed, deployOutput, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, status, o.statusUI, o.statusDiffOpts()).PrintUpdate()
 	o.lastSeenDeployStdout = deployOutput
 	if err != nil {
 		return fmt.Errorf("Reconciling app: %s", err)
 	}
 	if completed {
+		err = o.printApplyResults(status)
+		if err != nil {
+			return err
+		}
 		o.stopWatch()
 	}
 
 	return nil
 }
 
+// printApplyResults renders a table of resource-level apply operations
+// parsed from the last deploy output once a deploy succeeds.
+func (o *AppTailer) printApplyResults(status kcv1alpha1.AppStatus) error {
+	if o.opts.Quiet || o.opts.OnlyFailures || status.Deploy == nil {
+		return nil
+	}
+
+	results := parseApplyResults(status.Deploy.Stdout)
+	if len(results) == 0 {
+		return nil
+	}
+
+	table := uitable.Table{
+		Title: "Apply results",
+
+		Header: []uitable.Header{
+			uitable.NewHeader("Namespace"),
+			uitable.NewHeader("Kind"),
+			uitable.NewHeader("Name"),
+			uitable.NewHeader("Op"),
+		},
+	}
+
+	for _, result := range results {
+		table.Rows = append(table.Rows, []uitable.Value{
+			uitable.NewValueString(result.Namespace),
+			uitable.NewValueString(result.Kind),
+			uitable.NewValueString(result.Name),
+			uitable.NewValueString(result.Op),
+		})
+	}
+
+	return cmdcore.PrintTable(o.ui, table, o.columns)
+}
+
 func (o *AppTailer) printInfo(app kcv1alpha1.App) error {
 	status, isFailing := appStatus(&app)
 	table := uitable.Table{
@@ -74,6 +168,7 @@ func (o *AppTailer) printInfo(app kcv1alpha1.App) error {
 			uitable.NewHeader("Namespace"),
 			uitable.NewHeader("Status"),
 			uitable.NewHeader("Metrics"),
+			uitable.NewHeader("Controller Version"),
 		},
 
 		Rows: [][]uitable.Value{{
@@ -81,9 +176,15 @@ func (o *AppTailer) printInfo(app kcv1alpha1.App) error {
 			uitable.NewValueString(app.Namespace),
 			uitable.ValueFmt{V: uitable.NewValueString(status), Error: isFailing},
 			uitable.NewValueString(o.metricString(app.Status)),
+			uitable.NewValueString(app.Status.ControllerVersion),
 		}},
 	}
 
+	if app.Status.Fetch != nil && app.Status.Fetch.ResolvedGitSHA != "" {
+		table.Header = append(table.Header, uitable.NewHeader("Git Commit"))
+		table.Rows[0] = append(table.Rows[0], uitable.NewValueString(app.Status.Fetch.ResolvedGitSHA))
+	}
+
 	return cmdcore.PrintTable(o.ui, table, o.columns)
 }
 
@@ -124,20 +225,43 @@ func (o *AppTailer) TailAppStatus() error {
 		}
 	}
 
-	informerFactory := kcexternalversions.NewFilteredSharedInformerFactory(o.client, 30*time.Minute, o.Namespace, func(opts *metav1.ListOptions) {
-		opts.FieldSelector = fmt.Sprintf("metadata.name=%s", o.Name)
-	})
+	informerFactory := kcexternalversions.NewFilteredSharedInformerFactory(o.client, 30*time.Minute, o.Namespace, o.setListSelection)
 	informer := informerFactory.Kappctrl().V1alpha1().Apps().Informer()
 	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		UpdateFunc: o.udpateEventHandler,
 		DeleteFunc: o.deleteEventHandler,
 	})
 
+	// A forbidden watch never syncs; stop waiting and surface the
+	// permission error instead of a generic sync timeout
+	var permissionErr error
+	var permissionStop sync.Once
+	err = informer.SetWatchErrorHandler(func(_ *cache.Reflector, err error) {
+		if errors.IsForbidden(err) {
+			permissionStop.Do(func() {
+				permissionErr = err
+				o.stopWatch()
+			})
+		}
+	})
+	if err != nil {
+		return err
+	}
+
 	go informer.Run(o.stopperChan)
 	if !cache.WaitForCacheSync(o.stopperChan, informer.HasSynced) {
+		if permissionErr != nil {
+			return fmt.Errorf("Insufficient RBAC to watch Apps in namespace '%s': %s", o.Namespace, permissionErr)
+		}
 		return fmt.Errorf("Timed out waiting for caches to sync")
 	}
 
+	// Tick a spinner between events on interactive terminals so long
+	// waits do not look frozen (no-op on non-TTY output)
+	o.spinner = newSpinner(o.ui, spinnerInterval)
+	o.spinner.Start()
+	defer o.spinner.Stop()
+
 	<-o.stopperChan
 	if o.watchError != nil {
 		return fmt.Errorf("Reconciling app: %s", o.watchError)
@@ -145,29 +269,76 @@ func (o *AppTailer) TailAppStatus() error {
 	return nil
 }
 
+// setListSelection selects Apps by label when a label selector is
+// configured, falling back to name-based selection otherwise.
+func (o *AppTailer) setListSelection(opts *metav1.ListOptions) {
+	if o.opts.LabelSelector != "" {
+		opts.LabelSelector = o.opts.LabelSelector
+		return
+	}
+	opts.FieldSelector = fmt.Sprintf("metadata.name=%s", o.Name)
+}
+
+// collapseOutput decides whether stage output blocks are collapsed
+// based on TTY detection and the configured verbosity.
+func (o *AppTailer) collapseOutput() bool {
+	return shouldCollapseOutput(o.ui, o.opts.Verbose)
+}
+
+// statusDiffOpts carries the tailer's rendering options into an
+// AppStatusDiff for the next status update.
+func (o *AppTailer) statusDiffOpts() AppStatusDiffOpts {
+	return AppStatusDiffOpts{
+		LastSeenDeployStdout: o.lastSeenDeployStdout,
+		CollapseOutput:       o.collapseOutput(),
+		Quiet:                o.opts.Quiet,
+		OnlyFailures:         o.opts.OnlyFailures,
+		MaxLogAge:            o.opts.MaxLogAge,
+		Stages:               o.opts.Stages,
+		DeployDurations:      o.opts.DeployDurations,
+		Clock:                o.opts.Clock,
+	}
+}
+
 func (o *AppTailer) stopWatch() {
 	close(o.stopperChan)
 }
 
 func (o *AppTailer) udpateEventHandler(oldObj interface{}, newObj interface{}) {
+	o.spinner.Clear()
+
 	newApp, _ := newObj.(*kcv1alpha1.App)
 	oldApp, _ := oldObj.(*kcv1alpha1.App)
 
+	// Printed before the observed-generation gate: the diff explains
+	// the reconcile that is about to be observed
+	if o.opts.Debug && oldApp.Generation != newApp.Generation {
+		if diff := specDiff(oldApp, newApp); diff != "" {
+			o.statusUI.PrintLogLine(fmt.Sprintf("App spec changed (generation %d -> %d)", oldApp.Generation, newApp.Generation), diff, false, o.opts.Clock.Now())
+		}
+	}
+
 	if newApp.Generation != newApp.Status.ObservedGeneration {
-		o.statusUI.PrintLogLine(fmt.Sprintf("Waiting for generation %d to be observed", newApp.Generation), "", false, time.Now())
+		if !o.opts.Quiet && !o.opts.OnlyFailures {
+			o.statusUI.PrintLogLine(fmt.Sprintf("Waiting for generation %d to be observed", newApp.Generation), "", false, o.opts.Clock.Now())
+		}
 		return
 	}
 
-	stopWatch, deployOutput, err := NewAppStatusDiff(oldApp.Status, newApp.Status, o.statusUI, o.lastSeenDeployStdout).PrintUpdate()
+	stopWatch, deployOutput, err := NewAppStatusDiff(oldApp.Status, newApp.Status, o.statusUI, o.statusDiffOpts()).PrintUpdate()
 	o.lastSeenDeployStdout = deployOutput
 	o.watchError = err
 	if stopWatch {
+		if err == nil {
+			o.watchError = o.printApplyResults(newApp.Status)
+		}
 		o.stopWatch()
 	}
 }
 
 func (o *AppTailer) deleteEventHandler(oldObj interface{}) {
-	o.statusUI.PrintLogLine(fmt.Sprintf("App '%s' in namespace '%s' deleted", o.Name, o.Namespace), "", false, time.Now())
+	o.spinner.Clear()
+	o.statusUI.PrintLogLine(fmt.Sprintf("App '%s' in namespace '%s' deleted", o.Name, o.Namespace), "", false, o.opts.Clock.Now())
 	o.stopWatch()
 }
 
@@ -176,82 +347,225 @@ type AppStatusDiff struct {
 	new kcv1alpha1.AppStatus
 
 	statusUI cmdcore.StatusLoggingUI
+	opts     AppStatusDiffOpts
 
 	lastSeenDeployStdout string
 }
 
-func NewAppStatusDiff(old kcv1alpha1.AppStatus, new kcv1alpha1.AppStatus, statusUI cmdcore.StatusLoggingUI, deployOutput string) *AppStatusDiff {
-	return &AppStatusDiff{old: old, new: new, statusUI: statusUI, lastSeenDeployStdout: deployOutput}
+// AppStatusDiffOpts adjusts how an AppStatusDiff renders status changes.
+type AppStatusDiffOpts struct {
+	// LastSeenDeployStdout is the deploy output already printed for
+	// this App so only the delta is rendered.
+	LastSeenDeployStdout string
+
+	CollapseOutput bool
+	Quiet          bool
+	OnlyFailures   bool
+
+	// MaxLogAge discards stage error output older than this duration
+	// (zero disables the age check).
+	MaxLogAge time.Duration
+
+	// Stages filters which stages' output is printed (empty prints all).
+	Stages StageFilter
+
+	// DeployDurations holds recent historical deploy durations used to
+	// print estimated remaining time during a long deploy.
+	DeployDurations []time.Duration
+
+	// Clock used for timestamps not derived from status; defaults to
+	// the real clock when nil.
+	Clock cmdcore.Clock
+}
+
+func NewAppStatusDiff(old kcv1alpha1.AppStatus, new kcv1alpha1.AppStatus, statusUI cmdcore.StatusLoggingUI, opts AppStatusDiffOpts) *AppStatusDiff {
+	if opts.Clock == nil {
+		opts.Clock = cmdcore.NewRealClock()
+	}
+	return &AppStatusDiff{old: old, new: new, statusUI: statusUI, opts: opts, lastSeenDeployStdout: opts.LastSeenDeployStdout}
+}
+
+// suppressProgress reports whether successful and intermediate stage
+// output should be suppressed (both quiet and only-failures modes do).
+func (d *AppStatusDiff) suppressProgress() bool {
+	return d.opts.Quiet || d.opts.OnlyFailures
+}
+
+// staleErrorLog reports whether stage error output from given time is
+// stale: older than the most recent successful stage (a later success
+// means the error was recovered from) or older than the configured
+// maximum log age.
+func (d *AppStatusDiff) staleErrorLog(updatedAt time.Time) bool {
+	if latestSuccess := d.latestSuccessTime(); !latestSuccess.IsZero() && updatedAt.Before(latestSuccess) {
+		return true
+	}
+	if d.opts.MaxLogAge > 0 && d.opts.Clock.Now().Sub(updatedAt) > d.opts.MaxLogAge {
+		return true
+	}
+	return false
+}
+
+func (d *AppStatusDiff) latestSuccessTime() time.Time {
+	var latest time.Time
+	if d.new.Fetch != nil && d.new.Fetch.ExitCode == 0 && d.new.Fetch.UpdatedAt.Time.After(latest) {
+		latest = d.new.Fetch.UpdatedAt.Time
+	}
+	if d.new.Template != nil && d.new.Template.ExitCode == 0 && d.new.Template.UpdatedAt.Time.After(latest) {
+		latest = d.new.Template.UpdatedAt.Time
+	}
+	if d.new.Deploy != nil && d.new.Deploy.ExitCode == 0 && d.new.Deploy.UpdatedAt.Time.After(latest) {
+		latest = d.new.Deploy.UpdatedAt.Time
+	}
+	return latest
 }
 
 func (d *AppStatusDiff) PrintUpdate() (bool, string, error) {
-	if d.new.Fetch != nil {
+	if !d.suppressProgress() && d.old.ObservedGeneration != 0 && d.new.ObservedGeneration != d.old.ObservedGeneration {
+		msg := "App spec updated; reconciling"
+		if summary := d.stageDiffSummary(); summary != "" {
+			msg = fmt.Sprintf("%s (stages changed: %s)", msg, summary)
+		}
+		d.statusUI.PrintLogLine(msg, "", false, d.opts.Clock.Now())
+	}
+	if d.old.ObservedGeneration != 0 && d.new.ConsecutiveReconcileFailures != d.old.ConsecutiveReconcileFailures {
+		increased := d.new.ConsecutiveReconcileFailures > d.old.ConsecutiveReconcileFailures
+		if increased || !d.suppressProgress() {
+			d.statusUI.PrintLogLine(fmt.Sprintf("Consecutive failures: %d -> %d",
+				d.old.ConsecutiveReconcileFailures, d.new.ConsecutiveReconcileFailures), "", increased, d.opts.Clock.Now())
+		}
+	}
+	if d.new.Fetch != nil && d.opts.Stages.Includes(stageFetch) {
 		if d.old.Fetch == nil || (!d.old.Fetch.StartedAt.Equal(&d.new.Fetch.StartedAt) && d.new.Fetch.UpdatedAt.Unix() <= d.new.Fetch.StartedAt.Unix()) {
-			d.statusUI.PrintLogLine("Fetch started", "", false, d.new.Fetch.StartedAt.Time)
+			if !d.suppressProgress() {
+				d.statusUI.PrintLogLine(withFetchSource("Fetch started", d.new.Fetch), "", false, d.new.Fetch.StartedAt.Time)
+			}
 		}
 		if d.old.Fetch == nil || !d.old.Fetch.UpdatedAt.Equal(&d.new.Fetch.UpdatedAt) {
 			if d.new.Fetch.ExitCode != 0 && d.new.Fetch.UpdatedAt.Unix() >= d.new.Fetch.StartedAt.Unix() {
 				msg := "Fetch failed"
 				errLog := d.new.Fetch.Stderr + "\n" + d.new.Fetch.Error
+				if d.opts.OnlyFailures && d.new.Fetch.Stdout != "" {
+					errLog = d.new.Fetch.Stdout + "\n" + errLog
+				}
+				if d.staleErrorLog(d.new.Fetch.UpdatedAt.Time) {
+					errLog = ""
+				}
 				d.statusUI.PrintLogLine(msg, errLog, true, d.new.Fetch.UpdatedAt.Time)
 				return true, d.lastSeenDeployStdout, fmt.Errorf(msg)
 			}
-			d.statusUI.PrintLogLine("Fetching", d.new.Fetch.Stdout, false, d.new.Fetch.UpdatedAt.Time)
-			d.statusUI.PrintLogLine("Fetch succeeded", "", false, d.new.Fetch.UpdatedAt.Time)
+			if !d.suppressProgress() {
+				fetchStdout := d.new.Fetch.Stdout
+				if d.opts.CollapseOutput {
+					fetchStdout = collapseBlock(fetchStdout)
+				}
+				d.statusUI.PrintLogLine("Fetching", fetchStdout, false, d.new.Fetch.UpdatedAt.Time)
+				d.statusUI.PrintLogLine(withStageDuration(withFetchSource("Fetch succeeded", d.new.Fetch), d.new.Fetch.StartedAt, d.new.Fetch.UpdatedAt), "", false, d.new.Fetch.UpdatedAt.Time)
+			}
 		}
 	}
-	if d.new.Template != nil {
+	if d.new.Template != nil && d.opts.Stages.Includes(stageTemplate) {
 		if d.old.Template == nil || !d.old.Template.UpdatedAt.Equal(&d.new.Template.UpdatedAt) {
 			if d.new.Template.ExitCode != 0 {
 				msg := "Template failed"
 				errLog := d.new.Template.Stderr + "\n" + d.new.Template.Error
+				if d.staleErrorLog(d.new.Template.UpdatedAt.Time) {
+					errLog = ""
+				}
 				d.statusUI.PrintLogLine(msg, errLog, true, d.new.Template.UpdatedAt.Time)
 				return true, d.lastSeenDeployStdout, fmt.Errorf(msg)
 			}
-			d.statusUI.PrintLogLine("Template succeeded", "", false, d.new.Template.UpdatedAt.Time)
+			if !d.suppressProgress() {
+				d.statusUI.PrintLogLine("Template succeeded", renderResolvedImages(d.new.Template.ResolvedImages), false, d.new.Template.UpdatedAt.Time)
+				// Data values are only shown with full output (--verbose
+				// on TTYs); sensitive values arrive already redacted
+				if !d.opts.CollapseOutput && d.new.Template.DataValues != "" {
+					d.statusUI.PrintLogLine("Data values used for templating", d.new.Template.DataValues, false, d.new.Template.UpdatedAt.Time)
+				}
+			}
 		}
 	}
-	if d.new.Deploy != nil {
+	if d.new.Deploy != nil && d.opts.Stages.Includes(stageDeploy) {
 		isDeleting := IsDeleting(d.new)
 		ongoingOp := "Deploy"
 		if isDeleting {
 			ongoingOp = "Delete"
 		}
 		if d.old.Deploy == nil || !d.old.Deploy.StartedAt.Equal(&d.new.Deploy.StartedAt) {
-			msg := fmt.Sprintf("%s started", ongoingOp)
-			d.statusUI.PrintLogLine(msg, "", false, d.new.Deploy.StartedAt.Time)
+			if !d.suppressProgress() {
+				msg := fmt.Sprintf("%s started", ongoingOp)
+				d.statusUI.PrintLogLine(msg, "", false, d.new.Deploy.StartedAt.Time)
+			}
 		}
 		if d.old.Deploy == nil || !d.old.Deploy.UpdatedAt.Equal(&d.new.Deploy.UpdatedAt) {
 			if d.new.Deploy.ExitCode != 0 && d.new.Deploy.Finished {
 				msg := fmt.Sprintf("%s failed", ongoingOp)
 				errLog := d.new.Deploy.Stderr + "\n" + d.new.Deploy.Error
+				if d.opts.OnlyFailures && d.new.Deploy.Stdout != "" {
+					errLog = d.new.Deploy.Stdout + "\n" + errLog
+				}
+				if d.staleErrorLog(d.new.Deploy.UpdatedAt.Time) {
+					errLog = ""
+				}
 				d.statusUI.PrintLogLine(msg, errLog, true, d.new.Deploy.UpdatedAt.Time)
+				if failures := parseWaitFailures(d.new.Deploy.Stderr); len(failures) > 0 {
+					d.statusUI.PrintLogLine("Resources kapp failed waiting on", renderWaitFailures(failures), true, d.new.Deploy.UpdatedAt.Time)
+				}
 				return true, d.lastSeenDeployStdout, fmt.Errorf(msg)
 			}
-			d.printDeployStdout(d.new.Deploy.Stdout, d.new.Deploy.UpdatedAt.Time, isDeleting)
+			if !d.suppressProgress() {
+				d.printDeployStdout(d.new.Deploy.Stdout, d.new.Deploy.UpdatedAt.Time, isDeleting)
+				if !d.new.Deploy.Finished && !isDeleting {
+					elapsed := d.new.Deploy.UpdatedAt.Sub(d.new.Deploy.StartedAt.Time)
+					if remaining, ok := estimateDeployRemaining(d.opts.DeployDurations, elapsed); ok {
+						d.statusUI.PrintLogLine(fmt.Sprintf("Estimated time remaining: %s", remaining.Round(time.Second)), "", false, d.new.Deploy.UpdatedAt.Time)
+					}
+				}
+			}
 		}
 	}
 
 	if HasReconciled(d.new) {
-		d.statusUI.PrintLogLine("Deploy succeeded", "", false, d.new.Deploy.UpdatedAt.Time)
+		// Apps without fetch/template (or with no deploy status yet)
+		// may not have all stage timestamps populated
+		updatedAt := d.opts.Clock.Now()
+		msg := "Deploy succeeded"
+		if d.new.Deploy != nil {
+			updatedAt = d.new.Deploy.UpdatedAt.Time
+			msg = withStageDuration(msg, d.new.Deploy.StartedAt, d.new.Deploy.UpdatedAt)
+		}
+		d.statusUI.PrintLogLine(msg, "", false, updatedAt)
 		return true, d.lastSeenDeployStdout, nil
 	}
 	failed, errMsg := HasFailed(d.new)
 	if failed {
-		d.statusUI.PrintLogLine(errMsg, "", true, time.Now())
+		d.statusUI.PrintLogLine(errMsg, "", true, d.opts.Clock.Now())
 		return true, d.lastSeenDeployStdout, fmt.Errorf(errMsg)
 	}
 	return false, d.lastSeenDeployStdout, nil
 }
 
 func (d *AppStatusDiff) printDeployStdout(stdout string, timestamp time.Time, isDeleting bool) {
+	defer d.printResourceProgress(d.lastSeenDeployStdout, stdout, timestamp)
+
 	if d.lastSeenDeployStdout == "" {
 		d.lastSeenDeployStdout = stdout
 		msg := "Deploying"
 		if isDeleting {
 			msg = "Deleting"
 		}
-		d.statusUI.PrintLogLine(msg, stdout, false, timestamp)
+		block := stdout
+		if d.opts.CollapseOutput {
+			block = collapseBlock(stdout)
+		}
+		d.statusUI.PrintLogLine(msg, block, false, timestamp)
+		return
+	}
+
+	if d.opts.CollapseOutput {
+		if stdout != d.lastSeenDeployStdout {
+			d.statusUI.PrintLogLine("Deploy output updated", collapseBlock(stdout), false, timestamp)
+		}
+		d.lastSeenDeployStdout = stdout
 		return
 	}
 
@@ -259,3 +573,54 @@ func (d *AppStatusDiff) printDeployStdout(stdout string, timestamp time.Time, is
 
 	d.lastSeenDeployStdout = stdout
 }
+
+// renderResolvedImages renders kbld's image resolutions from status as
+// an output block (empty when kbld did not run during templating).
+func renderResolvedImages(images []kcv1alpha1.AppResolvedImage) string {
+	var lines []string
+	for _, image := range images {
+		lines = append(lines, fmt.Sprintf("%s -> %s", image.Image, image.Resolved))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// withStageDuration appends how long a stage took to its succeeded
+// message; durations are omitted when either timestamp is missing
+// (template status carries no started-at) or inconsistent.
+func withStageDuration(msg string, startedAt metav1.Time, updatedAt metav1.Time) string {
+	if startedAt.IsZero() || updatedAt.IsZero() || updatedAt.Time.Before(startedAt.Time) {
+		return msg
+	}
+	return fmt.Sprintf("%s (took %s)", msg, duration.ShortHumanDuration(updatedAt.Sub(startedAt.Time)))
+}
+
+// stageDiffSummary returns a short comma separated list of the stages
+// that changed between the old and new status so a retriggered reconcile
+// can be summarized without re-printing every stage's output.
+func (d *AppStatusDiff) stageDiffSummary() string {
+	var changed []string
+	if d.new.Fetch != nil && (d.old.Fetch == nil || !d.old.Fetch.UpdatedAt.Equal(&d.new.Fetch.UpdatedAt)) {
+		changed = append(changed, "fetch")
+	}
+	if d.new.Template != nil && (d.old.Template == nil || !d.old.Template.UpdatedAt.Equal(&d.new.Template.UpdatedAt)) {
+		changed = append(changed, "template")
+	}
+	if d.new.Deploy != nil && (d.old.Deploy == nil || !d.old.Deploy.UpdatedAt.Equal(&d.new.Deploy.UpdatedAt)) {
+		changed = append(changed, "deploy")
+	}
+	return strings.Join(changed, ", ")
+}
+
+// printResourceProgress renders kapp's wait progress (e.g. "5/8 ready")
+// when the latest deploy output reports a different pair than the
+// previously seen output did.
+func (d *AppStatusDiff) printResourceProgress(prevStdout string, stdout string, timestamp time.Time) {
+	ready, total, found := parseResourceProgress(stdout)
+	if !found {
+		return
+	}
+	if prevReady, prevTotal, prevFound := parseResourceProgress(prevStdout); prevFound && prevReady == ready && prevTotal == total {
+		return
+	}
+	d.statusUI.PrintLogLine(fmt.Sprintf("%d/%d ready", ready, total), "", false, timestamp)
+}
@@ -11,6 +11,7 @@ import (
 	internalv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/clientset/versioned/typed/internalpackaging/v1alpha1"
 	kappctrlv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/clientset/versioned/typed/kappctrl/v1alpha1"
 	packagingv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/clientset/versioned/typed/packaging/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	discovery "k8s.io/client-go/discovery"
 )
@@ -116,6 +117,61 @@ func TestAppDeployFail(t *testing.T) {
 	require.Contains(t, err.Error(), "Deploy failed")
 }
 
+func TestAppDeployOnlyStatus(t *testing.T) {
+	fakeVersionedInterface := &FakeVersionedInterface{t}
+
+	deployOnlyStatus := kcv1alpha1.AppStatus{
+		Deploy: &kcv1alpha1.AppStatusDeploy{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			ExitCode:  0,
+			Stdout:    "kapp success",
+		},
+	}
+	appTailer := NewAppTailer("default", "test-app", ui.NewNoopUI(), fakeVersionedInterface, AppTailerOpts{}, nil)
+	appTailer.stopperChan = make(chan struct{})
+	err := appTailer.printTillCurrent(deployOnlyStatus)
+
+	require.NoError(t, err)
+}
+
+func TestAppDeployOnlyStatusFail(t *testing.T) {
+	fakeVersionedInterface := &FakeVersionedInterface{t}
+
+	deployOnlyStatus := kcv1alpha1.AppStatus{
+		Deploy: &kcv1alpha1.AppStatusDeploy{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			Finished:  true,
+			ExitCode:  1,
+			Stderr:    "kapp fail",
+		},
+	}
+	appTailer := NewAppTailer("default", "test-app", ui.NewNoopUI(), fakeVersionedInterface, AppTailerOpts{}, nil)
+	appTailer.stopperChan = make(chan struct{})
+	err := appTailer.printTillCurrent(deployOnlyStatus)
+
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Deploy failed")
+}
+
+func TestAppReconciledWithoutDeployStatus(t *testing.T) {
+	fakeVersionedInterface := &FakeVersionedInterface{t}
+
+	reconciledStatus := kcv1alpha1.AppStatus{
+		GenericStatus: kcv1alpha1.GenericStatus{
+			Conditions: []kcv1alpha1.Condition{
+				{Type: kcv1alpha1.ReconcileSucceeded, Status: corev1.ConditionTrue},
+			},
+		},
+	}
+	appTailer := NewAppTailer("default", "test-app", ui.NewNoopUI(), fakeVersionedInterface, AppTailerOpts{}, nil)
+	appTailer.stopperChan = make(chan struct{})
+	err := appTailer.printTillCurrent(reconciledStatus)
+
+	require.NoError(t, err)
+}
+
 func TestAppPrintInfoFailOnInvalidColumns(t *testing.T) {
 	fakeVersionedInterface := &FakeVersionedInterface{t}
 	app := kcv1alpha1.App{
@@ -130,6 +186,27 @@ func TestAppPrintInfoFailOnInvalidColumns(t *testing.T) {
 	require.ErrorContains(t, err, "invalid column names: namepsace,invalid")
 }
 
+func TestAppTailerSelectsByNameByDefault(t *testing.T) {
+	appTailer := NewAppTailer("default", "test-app", ui.NewNoopUI(), &FakeVersionedInterface{t}, AppTailerOpts{}, nil)
+
+	listOpts := metav1.ListOptions{}
+	appTailer.setListSelection(&listOpts)
+
+	require.Equal(t, "metadata.name=test-app", listOpts.FieldSelector)
+	require.Empty(t, listOpts.LabelSelector)
+}
+
+func TestAppTailerSelectsByLabelWhenConfigured(t *testing.T) {
+	appTailer := NewAppTailer("default", "test-app", ui.NewNoopUI(), &FakeVersionedInterface{t},
+		AppTailerOpts{LabelSelector: "app.kubernetes.io/instance=test-app"}, nil)
+
+	listOpts := metav1.ListOptions{}
+	appTailer.setListSelection(&listOpts)
+
+	require.Equal(t, "app.kubernetes.io/instance=test-app", listOpts.LabelSelector)
+	require.Empty(t, listOpts.FieldSelector)
+}
+
 type FakeVersionedInterface struct {
 	t *testing.T
 }
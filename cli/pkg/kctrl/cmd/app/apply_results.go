@@ -0,0 +1,44 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"regexp"
+	"strings"
+)
+
+// applyResult is one resource-level apply operation parsed from kapp
+// deploy output.
+type applyResult struct {
+	Op        string
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+// applyResultRegexp matches kapp's per-resource apply lines, e.g.
+// "3:56:10PM: create configmap/simple-cm (v1) namespace: default"
+var applyResultRegexp = regexp.MustCompile(
+	`^\d+:\d+:\d+(?:AM|PM): (create|update|delete|noop) ([\w.-]+)/([\w.-]+) \([^)]*\)(?: namespace: ([\w.-]+))?`)
+
+// parseApplyResults extracts resource-level apply operations from kapp
+// deploy stdout. Returns nil when no apply lines are present.
+func parseApplyResults(stdout string) []applyResult {
+	var results []applyResult
+
+	for _, line := range strings.Split(stdout, "\n") {
+		match := applyResultRegexp.FindStringSubmatch(strings.TrimSpace(line))
+		if match == nil {
+			continue
+		}
+		results = append(results, applyResult{
+			Op:        match[1],
+			Kind:      match[2],
+			Name:      match[3],
+			Namespace: match[4],
+		})
+	}
+
+	return results
+}
@@ -0,0 +1,32 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseApplyResults(t *testing.T) {
+	stdout := `3:56:10PM: ---- applying 3 changes [0/3 done] ----
+3:56:10PM: create configmap/simple-cm (v1) namespace: default
+3:56:11PM: update deployment/simple-app (apps/v1) namespace: default
+3:56:11PM: noop clusterrole/simple-role (rbac.authorization.k8s.io/v1) cluster
+3:56:12PM: ---- waiting on 3 changes [0/3 done] ----
+3:56:14PM: ok: reconcile deployment/simple-app (apps/v1) namespace: default
+Succeeded`
+
+	results := parseApplyResults(stdout)
+	require.Equal(t, []applyResult{
+		{Op: "create", Kind: "configmap", Name: "simple-cm", Namespace: "default"},
+		{Op: "update", Kind: "deployment", Name: "simple-app", Namespace: "default"},
+		{Op: "noop", Kind: "clusterrole", Name: "simple-role", Namespace: ""},
+	}, results)
+}
+
+func TestParseApplyResultsNoApplyLines(t *testing.T) {
+	require.Empty(t, parseApplyResults("3:56:12PM: ---- waiting on 1 changes [0/1 done] ----\nSucceeded"))
+	require.Empty(t, parseApplyResults(""))
+}
@@ -0,0 +1,47 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+)
+
+// fixedClock always reports the same time
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time { return c.now }
+
+func TestStatusUIFormatsTimestampsWithFrozenClock(t *testing.T) {
+	var lines []string
+	now := time.Date(2024, 1, 5, 15, 4, 5, 0, time.Local)
+	statusUI := cmdcore.NewStatusLoggingUIWithClock(recordingUI{ui.NewNoopUI(), &lines}, fixedClock{now})
+
+	statusUI.PrintLogLine("Fetch succeeded", "", false, now)
+
+	require.Len(t, lines, 1)
+	require.Equal(t, "3:04:05PM: Fetch succeeded \n", lines[0])
+}
+
+func TestStatusUIRendersMessageAgeWithFrozenClock(t *testing.T) {
+	var lines []string
+	now := time.Date(2024, 1, 5, 15, 4, 5, 0, time.Local)
+	statusUI := cmdcore.NewStatusLoggingUIWithClock(recordingUI{ui.NewNoopUI(), &lines}, fixedClock{now.Add(2 * time.Minute)})
+
+	statusUI.PrintLogLine("Fetch succeeded", "", false, now)
+
+	require.Len(t, lines, 1)
+	require.Equal(t, "3:04:05PM: Fetch succeeded (2m ago)\n", lines[0])
+
+	lines = nil
+	statusUI.PrintMessage("waiting")
+	require.Contains(t, strings.Join(lines, ""), "3:06:05PM: waiting")
+}
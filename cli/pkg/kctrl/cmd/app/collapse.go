@@ -0,0 +1,29 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+)
+
+// shouldCollapseOutput returns true when stage output should be
+// collapsed to a one-line summary. Interactive TTYs get collapsed
+// output unless verbose output is requested; non-TTY output (e.g.
+// redirected to a file or CI log) always stays complete.
+func shouldCollapseOutput(ui ui.UI, verbose bool) bool {
+	return !verbose && ui.IsInteractive()
+}
+
+// collapseBlock summarizes a multi-line output block to a single line.
+func collapseBlock(block string) string {
+	block = strings.TrimSuffix(block, "\n")
+	if block == "" {
+		return ""
+	}
+	numLines := strings.Count(block, "\n") + 1
+	return fmt.Sprintf("(%d lines of output collapsed; re-run with --verbose for full output)", numLines)
+}
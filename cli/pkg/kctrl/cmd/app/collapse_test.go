@@ -0,0 +1,48 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShouldCollapseOutputOnTTY(t *testing.T) {
+	ttyUI := fakeInteractiveUI{ui.NewNoopUI()}
+	require.True(t, shouldCollapseOutput(ttyUI, false))
+}
+
+func TestShouldCollapseOutputVerboseOverridesTTY(t *testing.T) {
+	ttyUI := fakeInteractiveUI{ui.NewNoopUI()}
+	require.False(t, shouldCollapseOutput(ttyUI, true))
+}
+
+func TestShouldCollapseOutputNonTTY(t *testing.T) {
+	nonTTYUI := fakeNonInteractiveUI{ui.NewNoopUI()}
+	require.False(t, shouldCollapseOutput(nonTTYUI, false))
+	require.False(t, shouldCollapseOutput(nonTTYUI, true))
+}
+
+func TestCollapseBlock(t *testing.T) {
+	require.Equal(t, "", collapseBlock(""))
+	require.Equal(t, "(1 lines of output collapsed; re-run with --verbose for full output)", collapseBlock("one line\n"))
+	require.Equal(t, "(3 lines of output collapsed; re-run with --verbose for full output)", collapseBlock("a\nb\nc"))
+}
+
+// fakeInteractiveUI reports itself as an interactive TTY
+// regardless of where test output actually goes.
+type fakeInteractiveUI struct {
+	ui.UI
+}
+
+func (u fakeInteractiveUI) IsInteractive() bool { return true }
+
+// fakeNonInteractiveUI reports output as redirected (non-TTY).
+type fakeNonInteractiveUI struct {
+	ui.UI
+}
+
+func (u fakeNonInteractiveUI) IsInteractive() bool { return false }
@@ -0,0 +1,38 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"strings"
+
+	"github.com/spf13/cobra"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AppNameCompletionFunc returns a cobra completion function listing
+// App names in the effective namespace, for commands taking an app
+// name (usable as a ValidArgsFunction or flag completion function).
+func AppNameCompletionFunc(depsFactory cmdcore.DepsFactory, nsFlags *cmdcore.NamespaceFlags) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return func(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		client, err := depsFactory.KappCtrlClient()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		apps, err := client.KappctrlV1alpha1().Apps(nsFlags.Name).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		var names []string
+		for _, app := range apps.Items {
+			if strings.HasPrefix(app.Name, toComplete) {
+				names = append(names, app.Name)
+			}
+		}
+		return names, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app_test
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+	cmdapp "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/app"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	kcclient "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/clientset/versioned"
+	fakekc "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	pkgclient "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/client/clientset/versioned"
+)
+
+type completionDepsFactory struct {
+	kcClient kcclient.Interface
+}
+
+func (f completionDepsFactory) RESTHost() (string, error) { return "", nil }
+func (f completionDepsFactory) DynamicClient(cmdcore.DynamicClientOpts) (dynamic.Interface, error) {
+	return nil, nil
+}
+func (f completionDepsFactory) CoreClient() (kubernetes.Interface, error) { return nil, nil }
+func (f completionDepsFactory) KappCtrlClient() (kcclient.Interface, error) {
+	return f.kcClient, nil
+}
+func (f completionDepsFactory) PackageClient() (pkgclient.Interface, error) { return nil, nil }
+
+func completionApp(name, ns string) *v1alpha1.App {
+	return &v1alpha1.App{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}}
+}
+
+func TestAppNameCompletionReturnsAppNames(t *testing.T) {
+	depsFactory := completionDepsFactory{fakekc.NewSimpleClientset(
+		completionApp("simple-app", "default"),
+		completionApp("second-app", "default"),
+		completionApp("other-ns-app", "other"),
+	)}
+	nsFlags := cmdcore.NamespaceFlags{Name: "default"}
+
+	completionFunc := cmdapp.AppNameCompletionFunc(depsFactory, &nsFlags)
+
+	names, directive := completionFunc(nil, nil, "")
+	require.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	require.ElementsMatch(t, []string{"simple-app", "second-app"}, names)
+}
+
+func TestAppNameCompletionFiltersByPrefix(t *testing.T) {
+	depsFactory := completionDepsFactory{fakekc.NewSimpleClientset(
+		completionApp("simple-app", "default"),
+		completionApp("second-app", "default"),
+	)}
+	nsFlags := cmdcore.NamespaceFlags{Name: "default"}
+
+	completionFunc := cmdapp.AppNameCompletionFunc(depsFactory, &nsFlags)
+
+	names, directive := completionFunc(nil, nil, "si")
+	require.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	require.Equal(t, []string{"simple-app"}, names)
+}
@@ -0,0 +1,68 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWatcherPrintsCondensedDiffOnSpecChange(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	earlier := metav1.NewTime(time.Now().Add(-time.Minute))
+	oldStatus := kcv1alpha1.AppStatus{
+		GenericStatus: kcv1alpha1.GenericStatus{ObservedGeneration: 1},
+		Fetch: &kcv1alpha1.AppStatusFetch{
+			StartedAt: earlier,
+			UpdatedAt: earlier,
+		},
+	}
+
+	newStatus := kcv1alpha1.AppStatus{
+		GenericStatus: kcv1alpha1.GenericStatus{ObservedGeneration: 2},
+		Fetch: &kcv1alpha1.AppStatusFetch{
+			StartedAt: metav1.Now(),
+			UpdatedAt: metav1.Now(),
+		},
+	}
+
+	_, _, err := NewAppStatusDiff(oldStatus, newStatus, statusUI, AppStatusDiffOpts{}).PrintUpdate()
+	require.NoError(t, err)
+
+	output := strings.Join(lines, "")
+	require.Contains(t, output, "App spec updated; reconciling")
+	require.Contains(t, output, "stages changed: fetch")
+}
+
+func TestWatcherOmitsCondensedDiffWithoutSpecChange(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	earlier := metav1.NewTime(time.Now().Add(-time.Minute))
+	oldStatus := kcv1alpha1.AppStatus{
+		GenericStatus: kcv1alpha1.GenericStatus{ObservedGeneration: 1},
+		Fetch: &kcv1alpha1.AppStatusFetch{
+			StartedAt: earlier,
+			UpdatedAt: earlier,
+		},
+	}
+
+	// Same generation; only the template stage advanced
+	newStatus := *oldStatus.DeepCopy()
+	newStatus.Template = &kcv1alpha1.AppStatusTemplate{UpdatedAt: metav1.Now()}
+
+	_, _, err := NewAppStatusDiff(oldStatus, newStatus, statusUI, AppStatusDiffOpts{}).PrintUpdate()
+	require.NoError(t, err)
+
+	require.NotContains(t, strings.Join(lines, ""), "App spec updated")
+}
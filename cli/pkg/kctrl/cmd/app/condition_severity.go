@@ -0,0 +1,49 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+)
+
+// ConditionSeverity classifies how a status condition is surfaced in
+// printed statuses and exit codes.
+type ConditionSeverity int
+
+const (
+	ConditionSeverityInfo ConditionSeverity = iota
+	ConditionSeverityWarning
+	ConditionSeverityError
+)
+
+var conditionSeverities = defaultConditionSeverities()
+
+func defaultConditionSeverities() map[kcv1alpha1.ConditionType]ConditionSeverity {
+	return map[kcv1alpha1.ConditionType]ConditionSeverity{
+		kcv1alpha1.ReconcileFailed:    ConditionSeverityError,
+		kcv1alpha1.DeleteFailed:       ConditionSeverityError,
+		kcv1alpha1.ReconcileSucceeded: ConditionSeverityInfo,
+		kcv1alpha1.Reconciling:        ConditionSeverityInfo,
+		kcv1alpha1.Deleting:           ConditionSeverityInfo,
+	}
+}
+
+// SetConditionSeverity overrides how the given condition type is
+// classified, e.g. downgrading a transient ReconcileFailed to a warning
+// so it does not fail the command.
+func SetConditionSeverity(condType kcv1alpha1.ConditionType, severity ConditionSeverity) {
+	conditionSeverities[condType] = severity
+}
+
+// ResetConditionSeverities restores the default classification.
+func ResetConditionSeverities() {
+	conditionSeverities = defaultConditionSeverities()
+}
+
+func conditionSeverity(condType kcv1alpha1.ConditionType) ConditionSeverity {
+	if severity, found := conditionSeverities[condType]; found {
+		return severity
+	}
+	return ConditionSeverityInfo
+}
@@ -0,0 +1,66 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func failedApp() *kcv1alpha1.App {
+	return &kcv1alpha1.App{
+		Status: kcv1alpha1.AppStatus{
+			GenericStatus: kcv1alpha1.GenericStatus{
+				Conditions: []kcv1alpha1.Condition{
+					{Type: kcv1alpha1.ReconcileFailed, Status: corev1.ConditionTrue},
+				},
+				UsefulErrorMessage: "ytt: exit status 1",
+			},
+		},
+	}
+}
+
+func TestDefaultSeverityMarksReconcileFailedAsFailure(t *testing.T) {
+	status, isFailing := appStatus(failedApp())
+	require.Equal(t, "Reconcile failed", status)
+	require.True(t, isFailing)
+
+	failed, errMsg := HasFailed(failedApp().Status)
+	require.True(t, failed)
+	require.Contains(t, errMsg, "ytt: exit status 1")
+}
+
+func TestDowngradedSeverityAltersStatusAndExitCode(t *testing.T) {
+	SetConditionSeverity(kcv1alpha1.ReconcileFailed, ConditionSeverityWarning)
+	defer ResetConditionSeverities()
+
+	status, isFailing := appStatus(failedApp())
+	require.Equal(t, "Reconcile failed", status)
+	require.False(t, isFailing)
+
+	failed, _ := HasFailed(failedApp().Status)
+	require.False(t, failed)
+}
+
+func TestUpgradedSeverityMarksConditionAsFailure(t *testing.T) {
+	SetConditionSeverity(kcv1alpha1.Reconciling, ConditionSeverityError)
+	defer ResetConditionSeverities()
+
+	app := &kcv1alpha1.App{
+		Status: kcv1alpha1.AppStatus{
+			GenericStatus: kcv1alpha1.GenericStatus{
+				Conditions: []kcv1alpha1.Condition{
+					{Type: kcv1alpha1.Reconciling, Status: corev1.ConditionTrue},
+				},
+			},
+		},
+	}
+
+	status, isFailing := appStatus(app)
+	require.Equal(t, "Reconciling", status)
+	require.True(t, isFailing)
+}
@@ -0,0 +1,50 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func templatedStatusWithDataValues() kcv1alpha1.AppStatus {
+	return kcv1alpha1.AppStatus{
+		Template: &kcv1alpha1.AppStatusTemplate{
+			UpdatedAt:  metav1.Now(),
+			ExitCode:   0,
+			DataValues: "replicas: 3\napi_token: <redacted>\n",
+		},
+	}
+}
+
+func TestDataValuesPrintedWithFullOutput(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	_, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, templatedStatusWithDataValues(), statusUI, AppStatusDiffOpts{}).PrintUpdate()
+	require.NoError(t, err)
+
+	output := strings.Join(lines, "")
+	require.Contains(t, output, "Data values used for templating")
+	require.Contains(t, output, "replicas: 3")
+	require.Contains(t, output, "api_token: <redacted>")
+}
+
+func TestDataValuesOmittedWhenOutputCollapsed(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	_, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, templatedStatusWithDataValues(), statusUI, AppStatusDiffOpts{CollapseOutput: true}).PrintUpdate()
+	require.NoError(t, err)
+
+	output := strings.Join(lines, "")
+	require.Contains(t, output, "Template succeeded")
+	require.NotContains(t, output, "Data values used for templating")
+}
@@ -52,6 +52,7 @@ func NewDeleteCmd(o *DeleteOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Co
 
 	o.NamespaceFlags.Set(cmd, flagsFactory)
 	cmd.Flags().StringVarP(&o.Name, "app", "a", "", "Set app name (required)")
+	_ = cmd.RegisterFlagCompletionFunc("app", AppNameCompletionFunc(o.depsFactory, &o.NamespaceFlags))
 	cmd.Flags().BoolVar(&o.NoOp, "noop", false, "Ignore resources created by the app and delete the custom resource itself")
 	o.WaitFlags.Set(cmd, flagsFactory, &cmdcore.WaitFlagsOpts{
 		AllowDisableWait: true,
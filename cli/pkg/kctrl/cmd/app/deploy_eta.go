@@ -0,0 +1,92 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// Reconcile history is recorded by kapp-controller (when enabled)
+	// into a per-App ConfigMap with this suffix
+	deployHistoryConfigMapSuffix = "-reconcile-history"
+	deployHistoryDataKey         = "history"
+
+	// maxRecentDeployDurations bounds how many historical durations
+	// feed the estimate so old outliers age out
+	maxRecentDeployDurations = 5
+)
+
+// deployHistoryEntry mirrors the fields of kapp-controller's reconcile
+// history entries that the watcher needs for deploy time estimation.
+type deployHistoryEntry struct {
+	Stage          string          `json:"stage"`
+	DeployDuration metav1.Duration `json:"deployDuration,omitempty"`
+}
+
+// recentDeployDurations fetches the App's reconcile history ConfigMap
+// and returns the durations of its recorded successful deploys. Missing
+// history (feature disabled, no ConfigMap, insufficient RBAC) yields nil
+// so the ETA line is simply omitted.
+func recentDeployDurations(coreClient kubernetes.Interface, namespace string, name string) []time.Duration {
+	if coreClient == nil {
+		return nil
+	}
+
+	cm, err := coreClient.CoreV1().ConfigMaps(namespace).Get(
+		context.Background(), name+deployHistoryConfigMapSuffix, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+
+	return parseDeployDurations(cm.Data[deployHistoryDataKey])
+}
+
+// parseDeployDurations extracts successful deploy durations from the
+// recorded history YAML (corrupted history yields nil).
+func parseDeployDurations(historyYAML string) []time.Duration {
+	var entries []deployHistoryEntry
+	err := yaml.Unmarshal([]byte(historyYAML), &entries)
+	if err != nil {
+		return nil
+	}
+
+	var durations []time.Duration
+	for _, entry := range entries {
+		if entry.Stage == "deploy" && entry.DeployDuration.Duration > 0 {
+			durations = append(durations, entry.DeployDuration.Duration)
+		}
+	}
+	return durations
+}
+
+// estimateDeployRemaining estimates how much longer an in-progress
+// deploy will take as the average of the most recent historical deploy
+// durations minus the time already elapsed. It reports false when no
+// history exists; estimates never go below zero.
+func estimateDeployRemaining(durations []time.Duration, elapsed time.Duration) (time.Duration, bool) {
+	if len(durations) == 0 {
+		return 0, false
+	}
+
+	if len(durations) > maxRecentDeployDurations {
+		durations = durations[len(durations)-maxRecentDeployDurations:]
+	}
+
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+
+	remaining := total/time.Duration(len(durations)) - elapsed
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, true
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateDeployRemainingAveragesRecentHistory(t *testing.T) {
+	durations := []time.Duration{30 * time.Second, 60 * time.Second, 90 * time.Second}
+
+	remaining, ok := estimateDeployRemaining(durations, 20*time.Second)
+	require.True(t, ok)
+	assert.Equal(t, 40*time.Second, remaining)
+}
+
+func TestEstimateDeployRemainingOmittedWithoutHistory(t *testing.T) {
+	_, ok := estimateDeployRemaining(nil, 20*time.Second)
+	assert.False(t, ok)
+}
+
+func TestEstimateDeployRemainingNeverNegative(t *testing.T) {
+	remaining, ok := estimateDeployRemaining([]time.Duration{10 * time.Second}, time.Minute)
+	require.True(t, ok)
+	assert.Equal(t, time.Duration(0), remaining)
+}
+
+func TestEstimateDeployRemainingUsesOnlyRecentDurations(t *testing.T) {
+	// Only the 5 most recent durations count; the old 10m outlier ages out
+	durations := []time.Duration{10 * time.Minute,
+		30 * time.Second, 30 * time.Second, 30 * time.Second, 30 * time.Second, 30 * time.Second}
+
+	remaining, ok := estimateDeployRemaining(durations, 0)
+	require.True(t, ok)
+	assert.Equal(t, 30*time.Second, remaining)
+}
+
+func TestParseDeployDurationsFromRecordedHistory(t *testing.T) {
+	historyYAML := `
+- stage: fetch
+  result: "Reconcile failed"
+- stage: deploy
+  result: "Reconcile succeeded"
+  deployDuration: 30s
+- stage: deploy
+  result: "Reconcile failed"
+- stage: deploy
+  result: "Reconcile succeeded"
+  deployDuration: 1m0s
+`
+
+	durations := parseDeployDurations(historyYAML)
+	assert.Equal(t, []time.Duration{30 * time.Second, 60 * time.Second}, durations)
+}
+
+func TestParseDeployDurationsToleratesCorruptedHistory(t *testing.T) {
+	assert.Nil(t, parseDeployDurations("not: [valid"))
+}
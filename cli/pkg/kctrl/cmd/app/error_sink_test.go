@@ -0,0 +1,44 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+)
+
+func TestStatusUIRoutesErrorBlocksToErrorSink(t *testing.T) {
+	var out, errOut bytes.Buffer
+	outUI := ui.NewWriterUI(&out, &out, nil)
+	errUI := ui.NewWriterUI(&errOut, &errOut, nil)
+
+	statusUI := cmdcore.NewStatusLoggingUIWithSinks(outUI, errUI, nil)
+
+	statusUI.PrintLogLine("Fetch succeeded", "fetch output", false, time.Now())
+	statusUI.PrintLogLine("Deploy failed", "something broke", true, time.Now())
+
+	require.Contains(t, out.String(), "Fetch succeeded")
+	require.Contains(t, out.String(), "fetch output")
+	require.NotContains(t, out.String(), "Deploy failed")
+
+	require.Contains(t, errOut.String(), "Deploy failed")
+	require.Contains(t, errOut.String(), "something broke")
+	require.NotContains(t, errOut.String(), "Fetch succeeded")
+}
+
+func TestStatusUIDefaultsErrorsToMainSink(t *testing.T) {
+	var out bytes.Buffer
+	outUI := ui.NewWriterUI(&out, &out, nil)
+
+	statusUI := cmdcore.NewStatusLoggingUI(outUI)
+	statusUI.PrintLogLine("Deploy failed", "something broke", true, time.Now())
+
+	require.Contains(t, out.String(), "Deploy failed")
+	require.Contains(t, out.String(), "something broke")
+}
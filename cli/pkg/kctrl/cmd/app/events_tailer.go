@@ -0,0 +1,85 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// kappAppLabelKey is the label kapp places on resources belonging to an
+// app; events carrying it are attributed to that app.
+const kappAppLabelKey = "kapp.k14s.io/app"
+
+// eventWatcher is the subset of the typed Events client used by the
+// tailer (narrowed so tests can supply synthetic event streams).
+type eventWatcher interface {
+	Watch(ctx context.Context, opts metav1.ListOptions) (watch.Interface, error)
+}
+
+// EventsTailer follows Events in an App's namespace, printing the ones
+// attributed to the App's kapp app label interleaved with the watcher's
+// stage lines.
+type EventsTailer struct {
+	events        eventWatcher
+	statusUI      cmdcore.StatusLoggingUI
+	appLabelValue string
+
+	stopperChan chan struct{}
+}
+
+// NewEventsTailer returns an EventsTailer printing events labeled with
+// the given kapp app label value until stopperChan is closed.
+func NewEventsTailer(events eventWatcher, statusUI cmdcore.StatusLoggingUI, appLabelValue string, stopperChan chan struct{}) *EventsTailer {
+	return &EventsTailer{events: events, statusUI: statusUI, appLabelValue: appLabelValue, stopperChan: stopperChan}
+}
+
+// TailEvents watches events until the stopper channel is closed or the
+// watch ends. The label filter is applied client side as well since
+// fake clients do not apply selectors on watches.
+func (t *EventsTailer) TailEvents() error {
+	watcher, err := t.events.Watch(context.Background(), metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", kappAppLabelKey, t.appLabelValue),
+	})
+	if err != nil {
+		return fmt.Errorf("Watching events: %s", err)
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-t.stopperChan:
+			return nil
+		case e, ok := <-watcher.ResultChan():
+			if !ok {
+				return nil
+			}
+			event, ok := e.Object.(*corev1.Event)
+			if !ok {
+				continue
+			}
+			if event.Labels[kappAppLabelKey] != t.appLabelValue {
+				continue
+			}
+			t.printEvent(event)
+		}
+	}
+}
+
+func (t *EventsTailer) printEvent(event *corev1.Event) {
+	timestamp := event.LastTimestamp.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	msg := fmt.Sprintf("Event: %s %s/%s: %s",
+		event.Reason, strings.ToLower(event.InvolvedObject.Kind), event.InvolvedObject.Name, event.Message)
+	t.statusUI.PrintLogLine(msg, "", event.Type == corev1.EventTypeWarning, timestamp)
+}
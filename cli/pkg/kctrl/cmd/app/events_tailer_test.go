@@ -0,0 +1,75 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+)
+
+type fakeEventWatcher struct {
+	watcher watch.Interface
+}
+
+func (f fakeEventWatcher) Watch(_ context.Context, _ metav1.ListOptions) (watch.Interface, error) {
+	return f.watcher, nil
+}
+
+func appEvent(name string, labelValue string, reason string, message string) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{kappAppLabelKey: labelValue},
+		},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "simple-app-7d4b"},
+		Reason:         reason,
+		Message:        message,
+		Type:           corev1.EventTypeWarning,
+		LastTimestamp:  metav1.Now(),
+	}
+}
+
+func TestEventsTailerPrintsMatchingEvents(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	fw := watch.NewFake()
+	tailer := NewEventsTailer(fakeEventWatcher{fw}, statusUI, "simple-app-ctrl", make(chan struct{}))
+
+	done := make(chan error)
+	go func() { done <- tailer.TailEvents() }()
+
+	fw.Add(appEvent("evt-1", "simple-app-ctrl", "BackOff", "Back-off restarting failed container"))
+	fw.Add(appEvent("evt-2", "other-app-ctrl", "Killing", "Stopping container"))
+	fw.Stop()
+
+	require.NoError(t, <-done)
+	require.Len(t, lines, 1)
+	require.Contains(t, lines[0], "Event: BackOff pod/simple-app-7d4b: Back-off restarting failed container")
+}
+
+func TestEventsTailerStopsWhenStopperCloses(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	stopper := make(chan struct{})
+	tailer := NewEventsTailer(fakeEventWatcher{watch.NewFake()}, statusUI, "simple-app-ctrl", stopper)
+
+	done := make(chan error)
+	go func() { done <- tailer.TailEvents() }()
+
+	close(stopper)
+
+	require.NoError(t, <-done)
+	require.Empty(t, lines)
+}
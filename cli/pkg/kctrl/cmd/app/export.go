@@ -0,0 +1,120 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/spf13/cobra"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	"github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/logger"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+type ExportOptions struct {
+	ui          ui.UI
+	depsFactory cmdcore.DepsFactory
+	logger      logger.Logger
+
+	NamespaceFlags cmdcore.NamespaceFlags
+	Name           string
+}
+
+func NewExportOptions(ui ui.UI, depsFactory cmdcore.DepsFactory, logger logger.Logger) *ExportOptions {
+	return &ExportOptions{ui: ui, depsFactory: depsFactory, logger: logger}
+}
+
+func NewExportCmd(o *ExportOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export app spec as re-appliable YAML",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				o.Name = args[0]
+			}
+			return o.Run()
+		},
+		Annotations: map[string]string{
+			cmdcore.AppManagementCommandsHelpGroup.Key: cmdcore.AppManagementCommandsHelpGroup.Value,
+		},
+	}
+
+	o.NamespaceFlags.Set(cmd, flagsFactory)
+	cmd.Flags().StringVarP(&o.Name, "app", "a", "", "Set app name (required if not supplied as argument)")
+
+	return cmd
+}
+
+func (o *ExportOptions) Run() error {
+	if len(o.Name) == 0 {
+		return fmt.Errorf("Expected app name to be non empty")
+	}
+
+	client, err := o.depsFactory.KappCtrlClient()
+	if err != nil {
+		return err
+	}
+
+	app, err := client.KappctrlV1alpha1().Apps(o.NamespaceFlags.Name).Get(context.Background(), o.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	bs, err := exportedAppYAML(*app)
+	if err != nil {
+		return err
+	}
+
+	o.ui.PrintBlock(bs)
+
+	return nil
+}
+
+// exportedAppYAML renders an App CR fetched from the cluster as YAML
+// that can be applied elsewhere: status and server-populated metadata
+// (managedFields, resourceVersion, uid, etc.) are stripped.
+func exportedAppYAML(app kcv1alpha1.App) ([]byte, error) {
+	app.TypeMeta = metav1.TypeMeta{
+		APIVersion: "kappctrl.k14s.io/v1alpha1",
+		Kind:       "App",
+	}
+
+	bs, err := yaml.Marshal(app)
+	if err != nil {
+		return nil, fmt.Errorf("Marshaling App CR: %s", err)
+	}
+
+	var obj map[string]interface{}
+	err = yaml.Unmarshal(bs, &obj)
+	if err != nil {
+		return nil, fmt.Errorf("Unmarshaling App CR: %s", err)
+	}
+
+	delete(obj, "status")
+
+	if meta, ok := obj["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"managedFields", "resourceVersion", "uid",
+			"generation", "creationTimestamp", "deletionTimestamp", "selfLink", "ownerReferences", "finalizers"} {
+			delete(meta, field)
+		}
+		if anns, ok := meta["annotations"].(map[string]interface{}); ok {
+			delete(anns, "kubectl.kubernetes.io/last-applied-configuration")
+			if len(anns) == 0 {
+				delete(meta, "annotations")
+			}
+		}
+	}
+
+	result, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("Marshaling App CR: %s", err)
+	}
+
+	return append([]byte("---\n"), result...), nil
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+func TestExportedAppYAMLStripsServerSideFields(t *testing.T) {
+	app := kcv1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "test-app",
+			Namespace:         "default",
+			UID:               uuid.NewUUID(),
+			ResourceVersion:   "12345",
+			Generation:        3,
+			CreationTimestamp: metav1.Now(),
+			Finalizers:        []string{"finalizers.kapp-ctrl.k14s.io/delete"},
+			ManagedFields:     []metav1.ManagedFieldsEntry{{Manager: "kapp-controller"}},
+			Annotations: map[string]string{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				"custom-ann": "custom-value",
+			},
+		},
+		Spec: kcv1alpha1.AppSpec{
+			ServiceAccountName: "default-sa",
+			Fetch: []kcv1alpha1.AppFetch{
+				{Inline: &kcv1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+		},
+		Status: kcv1alpha1.AppStatus{
+			ManagedAppName: "test-app-ctrl",
+			Fetch:          &kcv1alpha1.AppStatusFetch{Stdout: "fetch output"},
+		},
+	}
+
+	bs, err := exportedAppYAML(app)
+	require.NoError(t, err)
+
+	exported := string(bs)
+	require.Contains(t, exported, "apiVersion: kappctrl.k14s.io/v1alpha1")
+	require.Contains(t, exported, "kind: App")
+	require.Contains(t, exported, "name: test-app")
+	require.Contains(t, exported, "serviceAccountName: default-sa")
+	require.Contains(t, exported, "custom-ann: custom-value")
+
+	require.NotContains(t, exported, "status:")
+	require.NotContains(t, exported, "managedFields")
+	require.NotContains(t, exported, "resourceVersion")
+	require.NotContains(t, exported, "uid:")
+	require.NotContains(t, exported, "creationTimestamp")
+	require.NotContains(t, exported, "finalizers")
+	require.NotContains(t, exported, "last-applied-configuration")
+}
+
+func TestExportedAppYAMLDropsEmptyAnnotations(t *testing.T) {
+	app := kcv1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-app",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+		},
+	}
+
+	bs, err := exportedAppYAML(app)
+	require.NoError(t, err)
+
+	require.NotContains(t, string(bs), "annotations")
+}
@@ -0,0 +1,104 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+)
+
+func TestFailureDeltaPrintedOnIncrement(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	oldStatus := kcv1alpha1.AppStatus{
+		GenericStatus:                kcv1alpha1.GenericStatus{ObservedGeneration: 1},
+		ConsecutiveReconcileFailures: 2,
+	}
+	newStatus := kcv1alpha1.AppStatus{
+		GenericStatus:                kcv1alpha1.GenericStatus{ObservedGeneration: 1},
+		ConsecutiveReconcileFailures: 3,
+	}
+
+	_, _, err := NewAppStatusDiff(oldStatus, newStatus, statusUI, AppStatusDiffOpts{}).PrintUpdate()
+	require.NoError(t, err)
+
+	require.Contains(t, strings.Join(lines, "\n"), "Consecutive failures: 2 -> 3")
+}
+
+func TestFailureDeltaPrintedOnReset(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	oldStatus := kcv1alpha1.AppStatus{
+		GenericStatus:                kcv1alpha1.GenericStatus{ObservedGeneration: 1},
+		ConsecutiveReconcileFailures: 3,
+	}
+	newStatus := kcv1alpha1.AppStatus{
+		GenericStatus:                 kcv1alpha1.GenericStatus{ObservedGeneration: 1},
+		ConsecutiveReconcileSuccesses: 1,
+	}
+
+	_, _, err := NewAppStatusDiff(oldStatus, newStatus, statusUI, AppStatusDiffOpts{}).PrintUpdate()
+	require.NoError(t, err)
+
+	require.Contains(t, strings.Join(lines, "\n"), "Consecutive failures: 3 -> 0")
+}
+
+func TestFailureDeltaNotPrintedWhenUnchanged(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	oldStatus := kcv1alpha1.AppStatus{
+		GenericStatus:                kcv1alpha1.GenericStatus{ObservedGeneration: 1},
+		ConsecutiveReconcileFailures: 2,
+	}
+	newStatus := kcv1alpha1.AppStatus{
+		GenericStatus:                kcv1alpha1.GenericStatus{ObservedGeneration: 1},
+		ConsecutiveReconcileFailures: 2,
+	}
+
+	_, _, err := NewAppStatusDiff(oldStatus, newStatus, statusUI, AppStatusDiffOpts{}).PrintUpdate()
+	require.NoError(t, err)
+
+	require.NotContains(t, strings.Join(lines, "\n"), "Consecutive failures")
+}
+
+func TestFailureDeltaNotPrintedForInitialStatus(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	newStatus := kcv1alpha1.AppStatus{
+		GenericStatus:                kcv1alpha1.GenericStatus{ObservedGeneration: 1},
+		ConsecutiveReconcileFailures: 2,
+	}
+
+	_, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, newStatus, statusUI, AppStatusDiffOpts{}).PrintUpdate()
+	require.NoError(t, err)
+
+	require.NotContains(t, strings.Join(lines, "\n"), "Consecutive failures")
+}
+
+func TestFailureDeltaResetSuppressedInQuietMode(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	oldStatus := kcv1alpha1.AppStatus{
+		GenericStatus:                kcv1alpha1.GenericStatus{ObservedGeneration: 1},
+		ConsecutiveReconcileFailures: 3,
+	}
+	newStatus := kcv1alpha1.AppStatus{
+		GenericStatus: kcv1alpha1.GenericStatus{ObservedGeneration: 1},
+	}
+
+	_, _, err := NewAppStatusDiff(oldStatus, newStatus, statusUI, AppStatusDiffOpts{Quiet: true}).PrintUpdate()
+	require.NoError(t, err)
+
+	require.NotContains(t, strings.Join(lines, "\n"), "Consecutive failures")
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+// sourceRefKeys are vendir lock fields that identify where content was
+// fetched from (digested image URLs, git shas, etc)
+var sourceRefKeys = []string{"url", "image", "sha"}
+
+// withFetchSource appends the resolved fetch source (if known) to a
+// fetch stage log line, e.g. "Fetch succeeded (source: repo@sha256:...)"
+func withFetchSource(msg string, fetch *kcv1alpha1.AppStatusFetch) string {
+	if fetch == nil {
+		return msg
+	}
+	if src := fetchSourceRefs(fetch.Stdout); src != "" {
+		return fmt.Sprintf("%s (source: %s)", msg, src)
+	}
+	return msg
+}
+
+// fetchSourceRefs extracts source references from the vendir lock
+// output recorded in the fetch stage stdout. Returns an empty string
+// when the output is not a lock config or has no recognizable sources.
+func fetchSourceRefs(stdout string) string {
+	var lock interface{}
+	err := yaml.Unmarshal([]byte(stdout), &lock)
+	if err != nil {
+		return ""
+	}
+
+	var refs []string
+	collectSourceRefs(lock, &refs)
+	return strings.Join(refs, ", ")
+}
+
+func collectSourceRefs(node interface{}, refs *[]string) {
+	switch typedNode := node.(type) {
+	case map[string]interface{}:
+		for _, key := range sourceRefKeys {
+			if val, ok := typedNode[key].(string); ok && val != "" && !containsRef(*refs, val) {
+				*refs = append(*refs, val)
+			}
+		}
+		// Sorted traversal keeps the rendered source list stable
+		nestedKeys := make([]string, 0, len(typedNode))
+		for key := range typedNode {
+			nestedKeys = append(nestedKeys, key)
+		}
+		sort.Strings(nestedKeys)
+		for _, key := range nestedKeys {
+			collectSourceRefs(typedNode[key], refs)
+		}
+	case []interface{}:
+		for _, item := range typedNode {
+			collectSourceRefs(item, refs)
+		}
+	}
+}
+
+func containsRef(refs []string, ref string) bool {
+	for _, existing := range refs {
+		if existing == ref {
+			return true
+		}
+	}
+	return false
+}
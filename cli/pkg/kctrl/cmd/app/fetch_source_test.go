@@ -0,0 +1,61 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const vendirLockStdout = `apiVersion: vendir.k14s.io/v1alpha1
+directories:
+- contents:
+  - imgpkgBundle:
+      image: registry.example.com/pkg@sha256:abcd1234
+    path: .
+  path: "0"
+kind: LockConfig
+`
+
+func TestFetchSourceRefsFromVendirLock(t *testing.T) {
+	require.Equal(t, "registry.example.com/pkg@sha256:abcd1234", fetchSourceRefs(vendirLockStdout))
+
+	gitLock := "directories:\n- contents:\n  - git:\n      sha: 0123abcd\n    path: .\n  path: \"0\"\n"
+	require.Equal(t, "0123abcd", fetchSourceRefs(gitLock))
+
+	require.Empty(t, fetchSourceRefs("plain output, not yaml: ["))
+	require.Empty(t, fetchSourceRefs(""))
+}
+
+func TestFetchLinesIncludeResolvedSource(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	status := kcv1alpha1.AppStatus{
+		Fetch: &kcv1alpha1.AppStatusFetch{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			ExitCode:  0,
+			Stdout:    vendirLockStdout,
+		},
+	}
+
+	_, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, status, statusUI, AppStatusDiffOpts{}).PrintUpdate()
+	require.NoError(t, err)
+
+	var succeededLine string
+	for _, line := range lines {
+		if strings.Contains(line, "Fetch succeeded") {
+			succeededLine = line
+		}
+	}
+	require.NotEmpty(t, succeededLine, "expected a fetch succeeded line")
+	require.Contains(t, succeededLine, "(source: registry.example.com/pkg@sha256:abcd1234)")
+}
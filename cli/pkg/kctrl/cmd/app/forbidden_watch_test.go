@@ -0,0 +1,43 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	fakekc "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/clientset/versioned/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kctesting "k8s.io/client-go/testing"
+)
+
+func TestTailAppStatusSurfacesForbiddenWatch(t *testing.T) {
+	app := &kcv1alpha1.App{ObjectMeta: metav1.ObjectMeta{Name: "simple-app", Namespace: "default"}}
+	client := fakekc.NewSimpleClientset(app)
+
+	forbidden := apierrors.NewForbidden(
+		schema.GroupResource{Group: "kappctrl.k14s.io", Resource: "apps"}, "", fmt.Errorf("no watch access"))
+	client.PrependReactor("list", "apps", func(kctesting.Action) (bool, runtime.Object, error) {
+		return true, nil, forbidden
+	})
+
+	appTailer := NewAppTailer("default", "simple-app", ui.NewNoopUI(), client, AppTailerOpts{}, nil)
+
+	done := make(chan error)
+	go func() { done <- appTailer.TailAppStatus() }()
+
+	select {
+	case err := <-done:
+		require.ErrorContains(t, err, "Insufficient RBAC to watch Apps in namespace 'default'")
+	case <-time.After(10 * time.Second):
+		t.Fatal("Expected TailAppStatus to return a forbidden watch error")
+	}
+}
@@ -45,6 +45,7 @@ func NewGetCmd(o *GetOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Command
 
 	o.NamespaceFlags.Set(cmd, flagsFactory)
 	cmd.Flags().StringVarP(&o.Name, "app", "a", "", "Set app name (required)")
+	_ = cmd.RegisterFlagCompletionFunc("app", AppNameCompletionFunc(o.depsFactory, &o.NamespaceFlags))
 
 	return cmd
 }
@@ -0,0 +1,57 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+package app
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAppPrintInfoShowsResolvedGitCommit(t *testing.T) {
+	fakeVersionedInterface := &FakeVersionedInterface{t}
+	app := kcv1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-app",
+			Namespace: "default",
+		},
+		Status: kcv1alpha1.AppStatus{
+			Fetch: &kcv1alpha1.AppStatusFetch{
+				ResolvedGitSHA: "4a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b",
+			},
+		},
+	}
+
+	var out bytes.Buffer
+	appTailer := NewAppTailer("default", "test-app", ui.NewWriterUI(&out, &out, nil),
+		fakeVersionedInterface, AppTailerOpts{}, &[]string{})
+
+	err := appTailer.printInfo(app)
+
+	require.NoError(t, err)
+	require.Contains(t, out.String(), "Git Commit")
+	require.Contains(t, out.String(), "4a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b")
+}
+
+func TestAppPrintInfoOmitsGitCommitWhenNotResolved(t *testing.T) {
+	fakeVersionedInterface := &FakeVersionedInterface{t}
+	app := kcv1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-app",
+			Namespace: "default",
+		},
+	}
+
+	var out bytes.Buffer
+	appTailer := NewAppTailer("default", "test-app", ui.NewWriterUI(&out, &out, nil),
+		fakeVersionedInterface, AppTailerOpts{}, &[]string{})
+
+	err := appTailer.printInfo(app)
+
+	require.NoError(t, err)
+	require.NotContains(t, out.String(), "Git Commit")
+}
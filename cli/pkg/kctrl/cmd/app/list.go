@@ -14,6 +14,7 @@ import (
 	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
 	"github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/logger"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
 )
 
 type ListOptions struct {
@@ -23,6 +24,7 @@ type ListOptions struct {
 
 	NamespaceFlags cmdcore.NamespaceFlags
 	AllNamespaces  bool
+	FieldSelector  string
 
 	columns *[]string
 }
@@ -44,6 +46,7 @@ func NewListCmd(o *ListOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Comman
 
 	o.NamespaceFlags.Set(cmd, flagsFactory)
 	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", false, "List apps in all namespaces")
+	cmd.Flags().StringVar(&o.FieldSelector, "field-selector", "", "Filter apps by field selector (e.g. metadata.name=my-app) passed through to the list call")
 
 	return cmd
 }
@@ -64,7 +67,12 @@ func (o *ListOptions) Run() error {
 		return err
 	}
 
-	appList, err := client.KappctrlV1alpha1().Apps(o.NamespaceFlags.Name).List(context.Background(), metav1.ListOptions{})
+	listOpts, err := appListOptions(o.FieldSelector)
+	if err != nil {
+		return err
+	}
+
+	appList, err := client.KappctrlV1alpha1().Apps(o.NamespaceFlags.Name).List(context.Background(), listOpts)
 	if err != nil {
 		return err
 	}
@@ -108,6 +116,21 @@ func (o *ListOptions) Run() error {
 
 }
 
+// appListOptions builds list options for the given field selector,
+// validating the selector format before it is sent to the API server.
+func appListOptions(fieldSelector string) (metav1.ListOptions, error) {
+	if fieldSelector == "" {
+		return metav1.ListOptions{}, nil
+	}
+
+	selector, err := fields.ParseSelector(fieldSelector)
+	if err != nil {
+		return metav1.ListOptions{}, fmt.Errorf("Parsing field selector: %s", err)
+	}
+
+	return metav1.ListOptions{FieldSelector: selector.String()}, nil
+}
+
 func (o *ListOptions) owner(references []metav1.OwnerReference) string {
 	if len(references) > 0 {
 		return fmt.Sprintf("%s/%s", references[0].Kind, references[0].Name)
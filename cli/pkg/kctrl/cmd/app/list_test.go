@@ -0,0 +1,34 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppListOptionsNameSelector(t *testing.T) {
+	listOpts, err := appListOptions("metadata.name=simple-app")
+	require.NoError(t, err)
+	require.Equal(t, "metadata.name=simple-app", listOpts.FieldSelector)
+}
+
+func TestAppListOptionsStatusSelector(t *testing.T) {
+	listOpts, err := appListOptions("status.friendlyDescription!=Reconcile succeeded,metadata.namespace=default")
+	require.NoError(t, err)
+	require.Equal(t, "metadata.namespace=default,status.friendlyDescription!=Reconcile succeeded", listOpts.FieldSelector)
+}
+
+func TestAppListOptionsEmptySelector(t *testing.T) {
+	listOpts, err := appListOptions("")
+	require.NoError(t, err)
+	require.Empty(t, listOpts.FieldSelector)
+}
+
+func TestAppListOptionsInvalidSelector(t *testing.T) {
+	_, err := appListOptions("metadata.name")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Parsing field selector")
+}
@@ -0,0 +1,85 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	uitable "github.com/cppforlife/go-cli-ui/ui/table"
+)
+
+var ansiEscapeRegexp = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// logFileUI tees printed lines to a writer (typically a log file)
+// with ANSI color codes stripped, in addition to the parent UI.
+type logFileUI struct {
+	parent ui.UI
+	w      io.Writer
+}
+
+var _ ui.UI = &logFileUI{}
+
+func newLogFileUI(parent ui.UI, w io.Writer) *logFileUI {
+	return &logFileUI{parent: parent, w: w}
+}
+
+func stripANSI(str string) string {
+	return ansiEscapeRegexp.ReplaceAllString(str, "")
+}
+
+func (u *logFileUI) logLine(line string) {
+	fmt.Fprintf(u.w, "%s\n", stripANSI(strings.TrimSuffix(line, "\n")))
+}
+
+func (u *logFileUI) ErrorLinef(pattern string, args ...interface{}) {
+	u.logLine(fmt.Sprintf(pattern, args...))
+	u.parent.ErrorLinef(pattern, args...)
+}
+
+func (u *logFileUI) PrintLinef(pattern string, args ...interface{}) {
+	u.logLine(fmt.Sprintf(pattern, args...))
+	u.parent.PrintLinef(pattern, args...)
+}
+
+func (u *logFileUI) BeginLinef(pattern string, args ...interface{}) {
+	fmt.Fprintf(u.w, "%s", stripANSI(fmt.Sprintf(pattern, args...)))
+	u.parent.BeginLinef(pattern, args...)
+}
+
+func (u *logFileUI) EndLinef(pattern string, args ...interface{}) {
+	u.logLine(fmt.Sprintf(pattern, args...))
+	u.parent.EndLinef(pattern, args...)
+}
+
+func (u *logFileUI) PrintBlock(block []byte) {
+	fmt.Fprintf(u.w, "%s", stripANSI(string(block)))
+	u.parent.PrintBlock(block)
+}
+
+func (u *logFileUI) PrintErrorBlock(block string) {
+	fmt.Fprintf(u.w, "%s", stripANSI(block))
+	u.parent.PrintErrorBlock(block)
+}
+
+func (u *logFileUI) PrintTable(table uitable.Table) { u.parent.PrintTable(table) }
+
+func (u *logFileUI) AskForText(opts ui.TextOpts) (string, error) { return u.parent.AskForText(opts) }
+
+func (u *logFileUI) AskForChoice(opts ui.ChoiceOpts) (int, error) {
+	return u.parent.AskForChoice(opts)
+}
+
+func (u *logFileUI) AskForPassword(label string) (string, error) {
+	return u.parent.AskForPassword(label)
+}
+
+func (u *logFileUI) AskForConfirmation() error { return u.parent.AskForConfirmation() }
+
+func (u *logFileUI) IsInteractive() bool { return u.parent.IsInteractive() }
+
+func (u *logFileUI) Flush() { u.parent.Flush() }
@@ -0,0 +1,35 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+)
+
+func TestLogFileUIStripsANSI(t *testing.T) {
+	var buf bytes.Buffer
+	logUI := newLogFileUI(ui.NewNoopUI(), &buf)
+
+	logUI.PrintLinef("deploy \x1b[31mfailed\x1b[0m")
+	logUI.ErrorLinef("some error")
+	logUI.PrintBlock([]byte("line1\nline2\n"))
+
+	require.Equal(t, "deploy failed\nsome error\nline1\nline2\n", buf.String())
+}
+
+func TestLogFileUITeesPrintLogLineOutput(t *testing.T) {
+	var buf bytes.Buffer
+	statusUI := cmdcore.NewStatusLoggingUI(newLogFileUI(ui.NewNoopUI(), &buf))
+
+	statusUI.PrintLogLine("Fetch succeeded", "", false, time.Now())
+
+	require.Contains(t, buf.String(), "Fetch succeeded")
+	require.NotContains(t, buf.String(), "\x1b[")
+}
@@ -0,0 +1,106 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/spf13/cobra"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	"github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/logger"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	controllerPodLabelSelector = "app=kapp-controller"
+)
+
+type LogsOptions struct {
+	ui          ui.UI
+	depsFactory cmdcore.DepsFactory
+	logger      logger.Logger
+
+	NamespaceFlags cmdcore.NamespaceFlags
+	Name           string
+
+	Follow              bool
+	ControllerNamespace string
+}
+
+func NewLogsOptions(ui ui.UI, depsFactory cmdcore.DepsFactory, logger logger.Logger) *LogsOptions {
+	return &LogsOptions{ui: ui, depsFactory: depsFactory, logger: logger}
+}
+
+func NewLogsCmd(o *LogsOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Print kapp-controller logs relevant to an app",
+		RunE:  func(_ *cobra.Command, _ []string) error { return o.Run() },
+		Annotations: map[string]string{TTYByDefaultKey: "",
+			cmdcore.AppManagementCommandsHelpGroup.Key: cmdcore.AppManagementCommandsHelpGroup.Value},
+	}
+
+	o.NamespaceFlags.Set(cmd, flagsFactory)
+	cmd.Flags().StringVarP(&o.Name, "app", "a", "", "Set app name (required)")
+	_ = cmd.RegisterFlagCompletionFunc("app", AppNameCompletionFunc(o.depsFactory, &o.NamespaceFlags))
+	cmd.Flags().BoolVarP(&o.Follow, "follow", "f", false, "Stream logs as they are written")
+	cmd.Flags().StringVar(&o.ControllerNamespace, "controller-namespace", "kapp-controller", "Namespace kapp-controller runs in")
+
+	return cmd
+}
+
+func (o *LogsOptions) Run() error {
+	if len(o.Name) == 0 {
+		return fmt.Errorf("Expected app name to be non empty")
+	}
+
+	coreClient, err := o.depsFactory.CoreClient()
+	if err != nil {
+		return err
+	}
+
+	podList, err := coreClient.CoreV1().Pods(o.ControllerNamespace).List(context.Background(),
+		metav1.ListOptions{LabelSelector: controllerPodLabelSelector})
+	if err != nil {
+		return fmt.Errorf("Listing kapp-controller pods: %s", err)
+	}
+	if len(podList.Items) == 0 {
+		return fmt.Errorf("Expected to find kapp-controller pod in namespace '%s'", o.ControllerNamespace)
+	}
+
+	logsReq := coreClient.CoreV1().Pods(o.ControllerNamespace).GetLogs(
+		podList.Items[0].Name, &corev1.PodLogOptions{Follow: o.Follow})
+
+	stream, err := logsReq.Stream(context.Background())
+	if err != nil {
+		return fmt.Errorf("Streaming kapp-controller logs: %s", err)
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if logLineRelevantToApp(line, o.Name, o.NamespaceFlags.Name) {
+			o.ui.PrintBlock([]byte(line + "\n"))
+		}
+	}
+
+	return scanner.Err()
+}
+
+// logLineRelevantToApp returns true when a controller log line references
+// the given app, either via the reconcile request key ("ns/name") or via
+// explicit name and namespace log fields.
+func logLineRelevantToApp(line string, name string, namespace string) bool {
+	if strings.Contains(line, fmt.Sprintf("%q", namespace+"/"+name)) {
+		return true
+	}
+	return strings.Contains(line, fmt.Sprintf(`"name":%q`, name)) &&
+		strings.Contains(line, fmt.Sprintf(`"namespace":%q`, namespace))
+}
@@ -0,0 +1,31 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogLineRelevantToAppMatchesRequestKey(t *testing.T) {
+	line := `{"level":"info","ts":1699012345.6,"logger":"kc.app","msg":"Reconciling","request":"default/simple-app"}`
+
+	assert.True(t, logLineRelevantToApp(line, "simple-app", "default"))
+	assert.False(t, logLineRelevantToApp(line, "other-app", "default"))
+	assert.False(t, logLineRelevantToApp(line, "simple-app", "other-ns"))
+}
+
+func TestLogLineRelevantToAppMatchesNameNamespaceFields(t *testing.T) {
+	line := `{"level":"info","logger":"kc.app","msg":"Updating status","name":"simple-app","namespace":"default"}`
+
+	assert.True(t, logLineRelevantToApp(line, "simple-app", "default"))
+	assert.False(t, logLineRelevantToApp(line, "simple-app", "other-ns"))
+}
+
+func TestLogLineRelevantToAppIgnoresUnrelatedLines(t *testing.T) {
+	assert.False(t, logLineRelevantToApp(`{"level":"info","msg":"starting manager"}`, "simple-app", "default"))
+	// Name appearing only as a substring of another app should not match
+	assert.False(t, logLineRelevantToApp(`{"request":"default/simple-app-2"}`, "simple-app", "default"))
+}
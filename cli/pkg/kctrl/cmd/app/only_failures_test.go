@@ -0,0 +1,107 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOnlyFailuresSuppressesSuccessfulStages(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	failedStatus := kcv1alpha1.AppStatus{
+		Fetch: &kcv1alpha1.AppStatusFetch{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			ExitCode:  0,
+			Stdout:    "vendir success",
+		},
+		Template: &kcv1alpha1.AppStatusTemplate{
+			UpdatedAt: metav1.Now(),
+			ExitCode:  0,
+		},
+		Deploy: &kcv1alpha1.AppStatusDeploy{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			Finished:  true,
+			ExitCode:  1,
+			Stdout:    "kapp partial apply output",
+			Stderr:    "kapp: Error: waiting on reconcile",
+		},
+	}
+
+	stopWatch, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, failedStatus, statusUI, AppStatusDiffOpts{OnlyFailures: true}).PrintUpdate()
+	require.Error(t, err)
+	require.True(t, stopWatch)
+
+	output := strings.Join(lines, "")
+	require.NotContains(t, output, "Fetching")
+	require.NotContains(t, output, "Fetch succeeded")
+	require.NotContains(t, output, "Template succeeded")
+	require.NotContains(t, output, "Deploy started")
+	require.Contains(t, output, "Deploy failed")
+	// Failed stage output includes its full stdout, not just stderr
+	require.Contains(t, output, "kapp partial apply output")
+	require.Contains(t, output, "kapp: Error: waiting on reconcile")
+}
+
+func TestOnlyFailuresPrintsFailedStageOutput(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	failedStatus := kcv1alpha1.AppStatus{
+		Fetch: &kcv1alpha1.AppStatusFetch{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			ExitCode:  1,
+			Stdout:    "vendir partial sync output",
+			Stderr:    "vendir: Error: fetching",
+		},
+	}
+
+	stopWatch, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, failedStatus, statusUI, AppStatusDiffOpts{OnlyFailures: true}).PrintUpdate()
+	require.Error(t, err)
+	require.True(t, stopWatch)
+
+	output := strings.Join(lines, "")
+	require.Contains(t, output, "Fetch failed")
+	require.Contains(t, output, "vendir partial sync output")
+	require.Contains(t, output, "vendir: Error: fetching")
+}
+
+func TestOnlyFailuresPrintsTerminalLineOnSuccess(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	successStatus := kcv1alpha1.AppStatus{
+		Fetch: &kcv1alpha1.AppStatusFetch{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			ExitCode:  0,
+			Stdout:    "vendir success",
+		},
+		Deploy: &kcv1alpha1.AppStatusDeploy{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			ExitCode:  0,
+			Stdout:    "kapp success",
+		},
+		GenericStatus: reconcileSucceededStatus,
+	}
+
+	stopWatch, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, successStatus, statusUI, AppStatusDiffOpts{OnlyFailures: true}).PrintUpdate()
+	require.NoError(t, err)
+	require.True(t, stopWatch)
+
+	require.Len(t, lines, 1)
+	require.Contains(t, lines[0], "Deploy succeeded")
+}
@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 )
 
 type PauseOptions struct {
@@ -25,6 +26,7 @@ type PauseOptions struct {
 
 	NamespaceFlags cmdcore.NamespaceFlags
 	Name           string
+	All            bool
 }
 
 func NewPauseOptions(ui ui.UI, depsFactory cmdcore.DepsFactory, logger logger.Logger) *PauseOptions {
@@ -43,12 +45,18 @@ func NewPauseCmd(o *PauseOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Comm
 
 	o.NamespaceFlags.Set(cmd, flagsFactory)
 	cmd.Flags().StringVarP(&o.Name, "app", "a", "", "Set app name (required)")
+	_ = cmd.RegisterFlagCompletionFunc("app", AppNameCompletionFunc(o.depsFactory, &o.NamespaceFlags))
+	cmd.Flags().BoolVar(&o.All, "all", false, "Pause all apps in the namespace")
 
 	return cmd
 }
 
 func (o *PauseOptions) Run() error {
-	if len(o.Name) == 0 {
+	if o.All && len(o.Name) > 0 {
+		return fmt.Errorf("Expected either app name or --all, but not both")
+	}
+
+	if !o.All && len(o.Name) == 0 {
 		return fmt.Errorf("Expected app name to be non empty")
 	}
 
@@ -57,6 +65,16 @@ func (o *PauseOptions) Run() error {
 		return err
 	}
 
+	if o.All {
+		o.ui.BeginLinef("Pausing reconciliation for all Apps in namespace '%s'", o.NamespaceFlags.Name)
+		err = o.ui.AskForConfirmation()
+		if err != nil {
+			return err
+		}
+
+		return o.pauseAllApps(client)
+	}
+
 	app, err := client.KappctrlV1alpha1().Apps(o.NamespaceFlags.Name).Get(context.Background(), o.Name, metav1.GetOptions{})
 	if err != nil {
 		if errors.IsNotFound(err) {
@@ -89,6 +107,40 @@ func (o *PauseOptions) Run() error {
 }
 
 func (o *PauseOptions) pauseApp(client kcclient.Interface) error {
+	o.ui.PrintLinef("Pausing reconciliation for App '%s' in namespace '%s'", o.Name, o.NamespaceFlags.Name)
+	return o.patchAppPaused(client, o.Name)
+}
+
+// pauseAllApps pauses every App in the namespace, continuing past
+// individual failures and reporting them in aggregate.
+func (o *PauseOptions) pauseAllApps(client kcclient.Interface) error {
+	appList, err := client.KappctrlV1alpha1().Apps(o.NamespaceFlags.Name).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	var pauseErrs []error
+	paused, alreadyPaused := 0, 0
+	for _, app := range appList.Items {
+		if app.Spec.Paused {
+			alreadyPaused++
+			continue
+		}
+		err := o.patchAppPaused(client, app.Name)
+		if err != nil {
+			pauseErrs = append(pauseErrs, fmt.Errorf("app/%s: %s", app.Name, err))
+			continue
+		}
+		paused++
+	}
+
+	o.ui.PrintLinef("Paused %d App(s) in namespace '%s' (%d already paused, %d failed)",
+		paused, o.NamespaceFlags.Name, alreadyPaused, len(pauseErrs))
+
+	return utilerrors.NewAggregate(pauseErrs)
+}
+
+func (o *PauseOptions) patchAppPaused(client kcclient.Interface, name string) error {
 	pausePatch := []map[string]interface{}{
 		{
 			"op":    "add",
@@ -102,9 +154,7 @@ func (o *PauseOptions) pauseApp(client kcclient.Interface) error {
 		return err
 	}
 
-	o.ui.PrintLinef("Pausing reconciliation for App '%s' in namespace '%s'", o.Name, o.NamespaceFlags.Name)
-
-	_, err = client.KappctrlV1alpha1().Apps(o.NamespaceFlags.Name).Patch(context.Background(), o.Name, types.JSONPatchType, patchJSON, metav1.PatchOptions{})
+	_, err = client.KappctrlV1alpha1().Apps(o.NamespaceFlags.Name).Patch(context.Background(), name, types.JSONPatchType, patchJSON, metav1.PatchOptions{})
 	if err != nil {
 		return err
 	}
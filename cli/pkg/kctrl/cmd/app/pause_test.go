@@ -0,0 +1,80 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	fakekc "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func pausableApp(name string, paused bool) *kcv1alpha1.App {
+	return &kcv1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       kcv1alpha1.AppSpec{Paused: paused},
+	}
+}
+
+func pauseOptions() *PauseOptions {
+	opts := NewPauseOptions(ui.NewNoopUI(), nil, nil)
+	opts.NamespaceFlags = cmdcore.NamespaceFlags{Name: "default"}
+	opts.All = true
+	return opts
+}
+
+func TestPauseAllPausesEveryAppInNamespace(t *testing.T) {
+	client := fakekc.NewSimpleClientset(pausableApp("app1", false), pausableApp("app2", false))
+
+	err := pauseOptions().pauseAllApps(client)
+	require.NoError(t, err)
+
+	for _, name := range []string{"app1", "app2"} {
+		app, err := client.KappctrlV1alpha1().Apps("default").Get(context.Background(), name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.True(t, app.Spec.Paused, "Expected app '%s' to be paused", name)
+	}
+}
+
+func TestPauseAllContinuesPastFailures(t *testing.T) {
+	client := fakekc.NewSimpleClientset(
+		pausableApp("app1", false), pausableApp("app2", false), pausableApp("app3", false))
+	client.PrependReactor("patch", "apps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.(k8stesting.PatchAction).GetName() == "app2" {
+			return true, nil, fmt.Errorf("fake patch failure")
+		}
+		return false, nil, nil
+	})
+
+	err := pauseOptions().pauseAllApps(client)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "app/app2")
+	assert.NotContains(t, err.Error(), "app/app1")
+
+	for _, name := range []string{"app1", "app3"} {
+		app, err := client.KappctrlV1alpha1().Apps("default").Get(context.Background(), name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.True(t, app.Spec.Paused, "Expected app '%s' to be paused", name)
+	}
+}
+
+func TestPauseAllSkipsAlreadyPausedApps(t *testing.T) {
+	client := fakekc.NewSimpleClientset(pausableApp("app1", true))
+
+	err := pauseOptions().pauseAllApps(client)
+	require.NoError(t, err)
+
+	for _, action := range client.Actions() {
+		assert.NotEqual(t, "patch", action.GetVerb(), "Expected no patch for already paused app")
+	}
+}
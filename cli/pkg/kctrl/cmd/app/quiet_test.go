@@ -0,0 +1,131 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var reconcileSucceededStatus = kcv1alpha1.GenericStatus{
+	Conditions: []kcv1alpha1.Condition{
+		{Type: kcv1alpha1.ReconcileSucceeded, Status: corev1.ConditionTrue},
+	},
+}
+
+func TestQuietPrintsOnlyTerminalLineOnSuccess(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	successStatus := kcv1alpha1.AppStatus{
+		Fetch: &kcv1alpha1.AppStatusFetch{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			ExitCode:  0,
+			Stdout:    "vendir success",
+		},
+		Template: &kcv1alpha1.AppStatusTemplate{
+			UpdatedAt: metav1.Now(),
+			ExitCode:  0,
+		},
+		Deploy: &kcv1alpha1.AppStatusDeploy{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			ExitCode:  0,
+			Stdout:    "kapp success",
+		},
+		GenericStatus: reconcileSucceededStatus,
+	}
+
+	stopWatch, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, successStatus, statusUI, AppStatusDiffOpts{Quiet: true}).PrintUpdate()
+	require.NoError(t, err)
+	require.True(t, stopWatch)
+
+	require.Len(t, lines, 1)
+	require.Contains(t, lines[0], "Deploy succeeded")
+}
+
+func TestQuietPrintsErrorOutputOnFailure(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	failedStatus := kcv1alpha1.AppStatus{
+		Fetch: &kcv1alpha1.AppStatusFetch{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			ExitCode:  0,
+			Stdout:    "vendir success",
+		},
+		Deploy: &kcv1alpha1.AppStatusDeploy{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			Finished:  true,
+			ExitCode:  1,
+			Stderr:    "kapp fail",
+		},
+	}
+
+	stopWatch, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, failedStatus, statusUI, AppStatusDiffOpts{Quiet: true}).PrintUpdate()
+	require.Error(t, err)
+	require.True(t, stopWatch)
+
+	output := strings.Join(lines, "")
+	require.Contains(t, output, "Deploy failed")
+	require.Contains(t, output, "kapp fail")
+	require.NotContains(t, output, "Fetching")
+	require.NotContains(t, output, "Fetch succeeded")
+}
+
+func TestNonQuietPrintsIntermediateStages(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	successStatus := kcv1alpha1.AppStatus{
+		Fetch: &kcv1alpha1.AppStatusFetch{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			ExitCode:  0,
+			Stdout:    "vendir success",
+		},
+		Deploy: &kcv1alpha1.AppStatusDeploy{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			ExitCode:  0,
+			Stdout:    "kapp success",
+		},
+		GenericStatus: reconcileSucceededStatus,
+	}
+
+	_, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, successStatus, statusUI, AppStatusDiffOpts{}).PrintUpdate()
+	require.NoError(t, err)
+
+	output := strings.Join(lines, "")
+	require.Contains(t, output, "Fetch succeeded")
+	require.Contains(t, output, "Deploying")
+	require.Contains(t, output, "Deploy succeeded")
+}
+
+// recordingUI captures printed lines and blocks for assertions.
+type recordingUI struct {
+	ui.UI
+	lines *[]string
+}
+
+func (u recordingUI) BeginLinef(pattern string, args ...interface{}) {
+	*u.lines = append(*u.lines, fmt.Sprintf(pattern, args...))
+}
+
+func (u recordingUI) PrintBlock(bs []byte) {
+	*u.lines = append(*u.lines, string(bs))
+}
+
+func (u recordingUI) IsInteractive() bool { return false }
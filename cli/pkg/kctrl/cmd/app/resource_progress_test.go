@@ -0,0 +1,68 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseResourceProgress(t *testing.T) {
+	stdout := `3:56:10PM: ---- applying 8 changes [0/8 done] ----
+3:56:15PM: ---- waiting on 3 changes [5/8 done] ----
+3:56:16PM: ongoing: reconcile deployment/simple-app (apps/v1) namespace: default`
+
+	ready, total, found := parseResourceProgress(stdout)
+	require.True(t, found)
+	require.Equal(t, 5, ready)
+	require.Equal(t, 8, total)
+
+	_, _, found = parseResourceProgress("Target cluster 'https://127.0.0.1:6443'")
+	require.False(t, found)
+}
+
+func TestWatcherRendersResourceProgress(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	status := kcv1alpha1.AppStatus{
+		Deploy: &kcv1alpha1.AppStatusDeploy{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			Stdout:    "3:56:15PM: ---- waiting on 3 changes [5/8 done] ----",
+		},
+	}
+
+	_, deployOutput, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, status, statusUI, AppStatusDiffOpts{}).PrintUpdate()
+	require.NoError(t, err)
+
+	output := strings.Join(lines, "")
+	require.Contains(t, output, "5/8 ready")
+
+	// Unchanged progress in subsequent output is not re-rendered
+	lines = nil
+	newStatus := *status.DeepCopy()
+	newStatus.Deploy.UpdatedAt = metav1.Now()
+	newStatus.Deploy.Stdout += "\n3:56:16PM: ongoing: reconcile deployment/simple-app (apps/v1) namespace: default"
+
+	_, _, err = NewAppStatusDiff(status, newStatus, statusUI, AppStatusDiffOpts{LastSeenDeployStdout: deployOutput}).PrintUpdate()
+	require.NoError(t, err)
+	require.NotContains(t, strings.Join(lines, ""), "5/8 ready")
+
+	// Progress advancing is rendered
+	lines = nil
+	advancedStatus := *newStatus.DeepCopy()
+	advancedStatus.Deploy.UpdatedAt = metav1.Now()
+	advancedStatus.Deploy.Stdout += "\n3:56:18PM: ---- waiting on 1 changes [7/8 done] ----"
+
+	_, _, err = NewAppStatusDiff(newStatus, advancedStatus, statusUI, AppStatusDiffOpts{LastSeenDeployStdout: newStatus.Deploy.Stdout}).PrintUpdate()
+	require.NoError(t, err)
+	require.Contains(t, strings.Join(lines, ""), "7/8 ready")
+}
@@ -0,0 +1,138 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/spf13/cobra"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	"github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/logger"
+	kcclient "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type SetIntervalOptions struct {
+	ui          ui.UI
+	depsFactory cmdcore.DepsFactory
+	logger      logger.Logger
+
+	NamespaceFlags cmdcore.NamespaceFlags
+	Name           string
+	Interval       string
+}
+
+func NewSetIntervalOptions(ui ui.UI, depsFactory cmdcore.DepsFactory, logger logger.Logger) *SetIntervalOptions {
+	return &SetIntervalOptions{ui: ui, depsFactory: depsFactory, logger: logger}
+}
+
+func NewSetIntervalCmd(o *SetIntervalOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-interval APP_NAME DURATION",
+		Short: "Set sync period for app",
+		Args:  cobra.MaximumNArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) > 0 {
+				o.Name = args[0]
+			}
+			if len(args) > 1 {
+				o.Interval = args[1]
+			}
+			return o.Run()
+		},
+		Annotations: map[string]string{TTYByDefaultKey: "",
+			cmdcore.AppManagementCommandsHelpGroup.Key: cmdcore.AppManagementCommandsHelpGroup.Value},
+	}
+
+	o.NamespaceFlags.Set(cmd, flagsFactory)
+	cmd.Flags().StringVarP(&o.Name, "app", "a", "", "Set app name (required if not supplied as argument)")
+	_ = cmd.RegisterFlagCompletionFunc("app", AppNameCompletionFunc(o.depsFactory, &o.NamespaceFlags))
+
+	return cmd
+}
+
+func (o *SetIntervalOptions) Run() error {
+	if len(o.Name) == 0 {
+		return fmt.Errorf("Expected app name to be non empty")
+	}
+
+	syncPeriod, err := parseSyncPeriod(o.Interval)
+	if err != nil {
+		return err
+	}
+
+	client, err := o.depsFactory.KappCtrlClient()
+	if err != nil {
+		return err
+	}
+
+	app, err := client.KappctrlV1alpha1().Apps(o.NamespaceFlags.Name).Get(context.Background(), o.Name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Errorf("App '%s' does not exist in namespace '%s'", o.Name, o.NamespaceFlags.Name)
+		}
+		return err
+	}
+
+	if isOwnedByPackageInstall(app) {
+		o.ui.BeginLinef("App '%s' is owned by '%s'\n(The sync period will be reverted when the package installation reconciles)\n", o.Name, fmt.Sprintf("%s/%s", app.OwnerReferences[0].Kind, app.OwnerReferences[0].Name))
+	}
+
+	o.ui.BeginLinef("Setting sync period of App '%s' in namespace '%s' to '%s'", o.Name, o.NamespaceFlags.Name, syncPeriod)
+	err = o.ui.AskForConfirmation()
+	if err != nil {
+		return err
+	}
+
+	return o.setSyncPeriod(client, syncPeriod)
+}
+
+// parseSyncPeriod validates the supplied duration; the controller treats
+// values below its configured minimum (30s by default) as that minimum.
+func parseSyncPeriod(interval string) (time.Duration, error) {
+	if len(interval) == 0 {
+		return 0, fmt.Errorf("Expected sync period duration to be non empty")
+	}
+
+	syncPeriod, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, fmt.Errorf("Parsing sync period '%s': %s", interval, err)
+	}
+
+	if syncPeriod <= 0 {
+		return 0, fmt.Errorf("Expected sync period to be positive, but was '%s'", interval)
+	}
+
+	return syncPeriod, nil
+}
+
+func (o *SetIntervalOptions) setSyncPeriod(client kcclient.Interface, syncPeriod time.Duration) error {
+	syncPeriodPatch := []map[string]interface{}{
+		{
+			"op":    "add",
+			"path":  "/spec/syncPeriod",
+			"value": metav1.Duration{Duration: syncPeriod},
+		},
+	}
+
+	patchJSON, err := json.Marshal(syncPeriodPatch)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.KappctrlV1alpha1().Apps(o.NamespaceFlags.Name).Patch(context.Background(), o.Name, types.JSONPatchType, patchJSON, metav1.PatchOptions{})
+	if err != nil {
+		return err
+	}
+
+	o.ui.PrintLinef("Set sync period of App '%s' in namespace '%s' to '%s'", o.Name, o.NamespaceFlags.Name, syncPeriod)
+
+	return nil
+}
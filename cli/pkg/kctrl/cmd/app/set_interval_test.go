@@ -0,0 +1,75 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	fakekc "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseSyncPeriod(t *testing.T) {
+	syncPeriod, err := parseSyncPeriod("5m")
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, syncPeriod)
+
+	_, err = parseSyncPeriod("")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "to be non empty")
+
+	_, err = parseSyncPeriod("5 parsecs")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Parsing sync period '5 parsecs'")
+
+	for _, interval := range []string{"0s", "-1m"} {
+		_, err = parseSyncPeriod(interval)
+		require.Error(t, err, "interval: %s", interval)
+		assert.Contains(t, err.Error(), "Expected sync period to be positive")
+	}
+}
+
+func TestSetSyncPeriodPatchesApp(t *testing.T) {
+	client := fakekc.NewSimpleClientset(&kcv1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{Name: "app1", Namespace: "default"},
+	})
+
+	opts := NewSetIntervalOptions(ui.NewNoopUI(), nil, nil)
+	opts.NamespaceFlags = cmdcore.NamespaceFlags{Name: "default"}
+	opts.Name = "app1"
+
+	err := opts.setSyncPeriod(client, 5*time.Minute)
+	require.NoError(t, err)
+
+	app, err := client.KappctrlV1alpha1().Apps("default").Get(context.Background(), "app1", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, app.Spec.SyncPeriod)
+	assert.Equal(t, 5*time.Minute, app.Spec.SyncPeriod.Duration)
+}
+
+func TestSetSyncPeriodReplacesExistingValue(t *testing.T) {
+	client := fakekc.NewSimpleClientset(&kcv1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{Name: "app1", Namespace: "default"},
+		Spec:       kcv1alpha1.AppSpec{SyncPeriod: &metav1.Duration{Duration: 30 * time.Second}},
+	})
+
+	opts := NewSetIntervalOptions(ui.NewNoopUI(), nil, nil)
+	opts.NamespaceFlags = cmdcore.NamespaceFlags{Name: "default"}
+	opts.Name = "app1"
+
+	err := opts.setSyncPeriod(client, 2*time.Hour)
+	require.NoError(t, err)
+
+	app, err := client.KappctrlV1alpha1().Apps("default").Get(context.Background(), "app1", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, app.Spec.SyncPeriod)
+	assert.Equal(t, 2*time.Hour, app.Spec.SyncPeriod.Duration)
+}
@@ -0,0 +1,39 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/k14s/difflib"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"sigs.k8s.io/yaml"
+)
+
+// specDiff renders a short line diff between the specs of two revisions
+// of an App ("-" removed, "+" added; unchanged lines are omitted).
+// Returns "" when the specs render identically or cannot be marshaled.
+func specDiff(oldApp *kcv1alpha1.App, newApp *kcv1alpha1.App) string {
+	oldBs, err := yaml.Marshal(oldApp.Spec)
+	if err != nil {
+		return ""
+	}
+	newBs, err := yaml.Marshal(newApp.Spec)
+	if err != nil {
+		return ""
+	}
+
+	var lines []string
+	for _, diffLine := range difflib.Diff(strings.Split(string(oldBs), "\n"), strings.Split(string(newBs), "\n")) {
+		switch diffLine.Delta {
+		case difflib.LeftOnly:
+			lines = append(lines, fmt.Sprintf("- %s", diffLine.Payload))
+		case difflib.RightOnly:
+			lines = append(lines, fmt.Sprintf("+ %s", diffLine.Payload))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
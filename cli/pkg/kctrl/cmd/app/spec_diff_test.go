@@ -0,0 +1,89 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+)
+
+func specDiffTestApp(url string, generation int64) *kcv1alpha1.App {
+	app := &kcv1alpha1.App{
+		Spec: kcv1alpha1.AppSpec{
+			Fetch: []kcv1alpha1.AppFetch{
+				{HTTP: &kcv1alpha1.AppFetchHTTP{URL: url}},
+			},
+			Template: []kcv1alpha1.AppTemplate{
+				{Ytt: &kcv1alpha1.AppTemplateYtt{}},
+			},
+		},
+	}
+	app.Generation = generation
+	return app
+}
+
+func TestSpecDiffShowsOnlyChangedLines(t *testing.T) {
+	oldApp := specDiffTestApp("https://example.com/v1.tgz", 1)
+	newApp := specDiffTestApp("https://example.com/v2.tgz", 2)
+
+	diff := specDiff(oldApp, newApp)
+
+	assert.Contains(t, diff, "-     url: https://example.com/v1.tgz")
+	assert.Contains(t, diff, "+     url: https://example.com/v2.tgz")
+	// Unchanged spec lines are omitted from the short diff
+	assert.NotContains(t, diff, "ytt")
+}
+
+func TestSpecDiffEmptyForIdenticalSpecs(t *testing.T) {
+	oldApp := specDiffTestApp("https://example.com/v1.tgz", 1)
+	newApp := specDiffTestApp("https://example.com/v1.tgz", 2)
+
+	assert.Equal(t, "", specDiff(oldApp, newApp))
+}
+
+func TestDebugPrintsSpecDiffOnGenerationBump(t *testing.T) {
+	var lines []string
+	recUI := recordingUI{ui.NewNoopUI(), &lines}
+
+	tailer := NewAppTailer("default", "test-app", recUI, nil,
+		AppTailerOpts{Debug: true, Clock: fixedClock{now: time.Now()}}, nil)
+	tailer.statusUI = cmdcore.NewStatusLoggingUIWithClock(recUI, fixedClock{now: time.Now()})
+
+	oldApp := specDiffTestApp("https://example.com/v1.tgz", 1)
+	newApp := specDiffTestApp("https://example.com/v2.tgz", 2)
+	// Status has not caught up with the new generation yet; the diff
+	// is still printed to explain the reconcile being triggered
+	newApp.Status.ObservedGeneration = 1
+
+	tailer.udpateEventHandler(oldApp, newApp)
+
+	output := strings.Join(lines, "\n")
+	require.Contains(t, output, "App spec changed (generation 1 -> 2)")
+	assert.Contains(t, output, "v1.tgz")
+	assert.Contains(t, output, "v2.tgz")
+}
+
+func TestNoSpecDiffWithoutDebug(t *testing.T) {
+	var lines []string
+	recUI := recordingUI{ui.NewNoopUI(), &lines}
+
+	tailer := NewAppTailer("default", "test-app", recUI, nil,
+		AppTailerOpts{Clock: fixedClock{now: time.Now()}}, nil)
+	tailer.statusUI = cmdcore.NewStatusLoggingUIWithClock(recUI, fixedClock{now: time.Now()})
+
+	oldApp := specDiffTestApp("https://example.com/v1.tgz", 1)
+	newApp := specDiffTestApp("https://example.com/v2.tgz", 2)
+	newApp.Status.ObservedGeneration = 1
+
+	tailer.udpateEventHandler(oldApp, newApp)
+
+	assert.NotContains(t, strings.Join(lines, "\n"), "App spec changed")
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+)
+
+var spinnerFrames = []string{"|", "/", "-", "\\"}
+
+// spinnerInterval is how often the waiting indicator advances a frame.
+const spinnerInterval = 500 * time.Millisecond
+
+// spinner ticks a lightweight progress indicator while waiting for the
+// next watch event so long interactive waits do not look frozen. It is
+// a no-op on non-TTY output (redirected to a file or CI log), where
+// control characters would garble the log.
+type spinner struct {
+	ui       ui.UI
+	enabled  bool
+	interval time.Duration
+
+	lock     sync.Mutex
+	frame    int
+	visible  bool
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+func newSpinner(ui ui.UI, interval time.Duration) *spinner {
+	return &spinner{ui: ui, enabled: ui.IsInteractive(), interval: interval, stopCh: make(chan struct{})}
+}
+
+// Start begins ticking in the background until Stop is called.
+func (s *spinner) Start() {
+	if s == nil || !s.enabled {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stopCh:
+				return
+			case <-ticker.C:
+				s.tick()
+			}
+		}
+	}()
+}
+
+func (s *spinner) tick() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.ui.PrintBlock([]byte("\r" + spinnerFrames[s.frame%len(spinnerFrames)]))
+	s.frame++
+	s.visible = true
+}
+
+// Clear erases the spinner frame (if any) so new output starts on a
+// clean line.
+func (s *spinner) Clear() {
+	if s == nil || !s.enabled {
+		return
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.visible {
+		s.ui.PrintBlock([]byte("\r \r"))
+		s.visible = false
+	}
+}
+
+// Stop clears the spinner and halts ticking; safe to call repeatedly.
+func (s *spinner) Stop() {
+	if s == nil || !s.enabled {
+		return
+	}
+
+	s.stopOnce.Do(func() { close(s.stopCh) })
+	s.Clear()
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+)
+
+// blockRecordingUI records PrintBlock output and reports a configurable
+// interactivity so the spinner's TTY gate can be exercised.
+type blockRecordingUI struct {
+	ui.UI
+	interactive bool
+	blocks      *[]string
+}
+
+func (u blockRecordingUI) IsInteractive() bool { return u.interactive }
+
+func (u blockRecordingUI) PrintBlock(block []byte) {
+	*u.blocks = append(*u.blocks, string(block))
+}
+
+func TestSpinnerDisabledWhenNotTerminal(t *testing.T) {
+	var blocks []string
+	nonTTYUI := blockRecordingUI{ui.NewNoopUI(), false, &blocks}
+
+	s := newSpinner(nonTTYUI, time.Millisecond)
+	require.False(t, s.enabled, "Expected spinner to be disabled on non-TTY output")
+
+	s.Start()
+	time.Sleep(20 * time.Millisecond)
+	s.Stop()
+
+	require.Empty(t, blocks, "Expected no spinner frames on non-TTY output")
+}
+
+func TestSpinnerTicksAndClearsOnTerminal(t *testing.T) {
+	var blocks []string
+	ttyUI := blockRecordingUI{ui.NewNoopUI(), true, &blocks}
+
+	s := newSpinner(ttyUI, time.Millisecond)
+	require.True(t, s.enabled, "Expected spinner to be enabled on a TTY")
+
+	s.tick()
+	s.tick()
+	s.Clear()
+
+	require.Equal(t, []string{"\r|", "\r/", "\r \r"}, blocks)
+
+	// Clearing again without new frames prints nothing
+	s.Clear()
+	require.Len(t, blocks, 3)
+}
+
+func TestSpinnerNilAndStopAreSafe(t *testing.T) {
+	var s *spinner
+	s.Start()
+	s.Clear()
+	s.Stop()
+
+	var blocks []string
+	ttyUI := blockRecordingUI{ui.NewNoopUI(), true, &blocks}
+	real := newSpinner(ttyUI, time.Millisecond)
+	real.Start()
+	real.Stop()
+	real.Stop()
+}
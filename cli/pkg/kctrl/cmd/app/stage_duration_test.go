@@ -0,0 +1,60 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSucceededLinesIncludeStageDuration(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	started := metav1.Now()
+	status := kcv1alpha1.AppStatus{
+		Fetch: &kcv1alpha1.AppStatusFetch{
+			StartedAt: started,
+			UpdatedAt: metav1.NewTime(started.Add(5 * time.Second)),
+			ExitCode:  0,
+		},
+		Deploy: &kcv1alpha1.AppStatusDeploy{
+			StartedAt: started,
+			UpdatedAt: metav1.NewTime(started.Add(42 * time.Second)),
+			ExitCode:  0,
+		},
+		GenericStatus: reconcileSucceededStatus,
+	}
+
+	_, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, status, statusUI, AppStatusDiffOpts{}).PrintUpdate()
+	require.NoError(t, err)
+
+	require.Contains(t, strings.Join(lines, "\n"), "Fetch succeeded (took 5s)")
+	require.Contains(t, strings.Join(lines, "\n"), "Deploy succeeded (took 42s)")
+}
+
+func TestSucceededLinesOmitDurationWithoutStartedAt(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	status := kcv1alpha1.AppStatus{
+		Template: &kcv1alpha1.AppStatusTemplate{
+			UpdatedAt: metav1.Now(),
+			ExitCode:  0,
+		},
+	}
+
+	_, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, status, statusUI, AppStatusDiffOpts{}).PrintUpdate()
+	require.NoError(t, err)
+
+	require.Contains(t, strings.Join(lines, "\n"), "Template succeeded")
+	require.NotContains(t, strings.Join(lines, "\n"), "took")
+}
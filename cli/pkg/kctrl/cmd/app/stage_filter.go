@@ -0,0 +1,43 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+)
+
+const (
+	stageFetch    = "fetch"
+	stageTemplate = "template"
+	stageDeploy   = "deploy"
+)
+
+// StageFilter selects which stages' output the watcher prints. An
+// empty filter selects all stages. Terminal reconciled/failed lines
+// are printed regardless of the filter.
+type StageFilter map[string]struct{}
+
+// NewStageFilter builds a StageFilter from stage names, rejecting
+// names other than fetch, template and deploy.
+func NewStageFilter(stages []string) (StageFilter, error) {
+	filter := StageFilter{}
+	for _, stage := range stages {
+		switch stage {
+		case stageFetch, stageTemplate, stageDeploy:
+			filter[stage] = struct{}{}
+		default:
+			return nil, fmt.Errorf("Expected stage to be one of 'fetch', 'template', 'deploy', but was '%s'", stage)
+		}
+	}
+	return filter, nil
+}
+
+// Includes reports whether output for the given stage should be printed.
+func (f StageFilter) Includes(stage string) bool {
+	if len(f) == 0 {
+		return true
+	}
+	_, found := f[stage]
+	return found
+}
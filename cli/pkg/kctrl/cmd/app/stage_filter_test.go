@@ -0,0 +1,65 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStageFilterRejectsUnknownStage(t *testing.T) {
+	_, err := NewStageFilter([]string{"fetch", "wait"})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Expected stage to be one of 'fetch', 'template', 'deploy', but was 'wait'")
+}
+
+func TestWatcherPrintsOnlySelectedStages(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	status := kcv1alpha1.AppStatus{
+		Fetch:    &kcv1alpha1.AppStatusFetch{UpdatedAt: metav1.Now(), StartedAt: metav1.Now()},
+		Template: &kcv1alpha1.AppStatusTemplate{UpdatedAt: metav1.Now()},
+		Deploy:   &kcv1alpha1.AppStatusDeploy{UpdatedAt: metav1.Now(), StartedAt: metav1.Now(), Stdout: "deploy-output"},
+	}
+
+	stages, err := NewStageFilter([]string{"deploy"})
+	require.NoError(t, err)
+
+	_, _, err = NewAppStatusDiff(kcv1alpha1.AppStatus{}, status, statusUI, AppStatusDiffOpts{Stages: stages}).PrintUpdate()
+	require.NoError(t, err)
+
+	output := strings.Join(lines, "")
+	require.NotContains(t, output, "Fetch")
+	require.NotContains(t, output, "Template")
+	require.Contains(t, output, "Deploy started")
+}
+
+func TestWatcherTerminalDetectionUnaffectedByStageFilter(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	status := kcv1alpha1.AppStatus{
+		Fetch:  &kcv1alpha1.AppStatusFetch{UpdatedAt: metav1.Now(), StartedAt: metav1.Now()},
+		Deploy: &kcv1alpha1.AppStatusDeploy{UpdatedAt: metav1.Now(), StartedAt: metav1.Now()},
+		GenericStatus: kcv1alpha1.GenericStatus{
+			Conditions: []kcv1alpha1.Condition{{Type: kcv1alpha1.ReconcileSucceeded, Status: corev1.ConditionTrue}},
+		},
+	}
+
+	stages, err := NewStageFilter([]string{"fetch"})
+	require.NoError(t, err)
+
+	stopWatch, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, status, statusUI, AppStatusDiffOpts{Stages: stages}).PrintUpdate()
+	require.NoError(t, err)
+	require.True(t, stopWatch)
+	require.Contains(t, strings.Join(lines, ""), "Deploy succeeded")
+}
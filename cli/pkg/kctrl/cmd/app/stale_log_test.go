@@ -0,0 +1,114 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStaleStderrDroppedAfterNewerSuccess(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	hourAgo := metav1.NewTime(time.Now().Add(-1 * time.Hour))
+	status := kcv1alpha1.AppStatus{
+		Fetch: &kcv1alpha1.AppStatusFetch{
+			UpdatedAt: hourAgo,
+			StartedAt: hourAgo,
+			ExitCode:  1,
+			Stderr:    "vendir fail",
+		},
+		Template: &kcv1alpha1.AppStatusTemplate{
+			UpdatedAt: metav1.Now(),
+			ExitCode:  0,
+		},
+	}
+
+	_, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, status, statusUI, AppStatusDiffOpts{}).PrintUpdate()
+	require.Error(t, err)
+
+	output := strings.Join(lines, "")
+	require.Contains(t, output, "Fetch failed")
+	require.NotContains(t, output, "vendir fail")
+}
+
+func TestStaleStderrDroppedBeyondMaxLogAge(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	hourAgo := metav1.NewTime(time.Now().Add(-1 * time.Hour))
+	status := kcv1alpha1.AppStatus{
+		Fetch: &kcv1alpha1.AppStatusFetch{
+			UpdatedAt: hourAgo,
+			StartedAt: hourAgo,
+			ExitCode:  1,
+			Stderr:    "vendir fail",
+		},
+	}
+
+	_, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, status, statusUI, AppStatusDiffOpts{MaxLogAge: time.Minute}).PrintUpdate()
+	require.Error(t, err)
+
+	output := strings.Join(lines, "")
+	require.Contains(t, output, "Fetch failed")
+	require.NotContains(t, output, "vendir fail")
+}
+
+func TestFreshStderrStillPrinted(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	status := kcv1alpha1.AppStatus{
+		Fetch: &kcv1alpha1.AppStatusFetch{
+			UpdatedAt: metav1.Now(),
+			StartedAt: metav1.Now(),
+			ExitCode:  1,
+			Stderr:    "vendir fail",
+		},
+	}
+
+	_, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, status, statusUI, AppStatusDiffOpts{MaxLogAge: time.Minute}).PrintUpdate()
+	require.Error(t, err)
+
+	output := strings.Join(lines, "")
+	require.Contains(t, output, "Fetch failed")
+	require.Contains(t, output, "vendir fail")
+}
+
+func TestMaxLogAgeUsesInjectedClock(t *testing.T) {
+	var lines []string
+	statusUI := cmdcore.NewStatusLoggingUI(recordingUI{ui.NewNoopUI(), &lines})
+
+	updatedAt := metav1.NewTime(time.Date(2024, 1, 5, 15, 4, 5, 0, time.UTC))
+	status := kcv1alpha1.AppStatus{
+		Fetch: &kcv1alpha1.AppStatusFetch{
+			UpdatedAt: updatedAt,
+			StartedAt: updatedAt,
+			ExitCode:  1,
+			Stderr:    "vendir fail",
+		},
+	}
+
+	// Frozen just after the failure: output is fresh regardless of
+	// how long ago the fixture timestamp is in real time
+	opts := AppStatusDiffOpts{MaxLogAge: time.Minute, Clock: fixedClock{updatedAt.Add(time.Second)}}
+	_, _, err := NewAppStatusDiff(kcv1alpha1.AppStatus{}, status, statusUI, opts).PrintUpdate()
+	require.Error(t, err)
+	require.Contains(t, strings.Join(lines, ""), "vendir fail")
+
+	// Frozen beyond the max log age: output is dropped as stale
+	lines = nil
+	opts.Clock = fixedClock{updatedAt.Add(2 * time.Minute)}
+	_, _, err = NewAppStatusDiff(kcv1alpha1.AppStatus{}, status, statusUI, opts).PrintUpdate()
+	require.Error(t, err)
+	require.NotContains(t, strings.Join(lines, ""), "vendir fail")
+}
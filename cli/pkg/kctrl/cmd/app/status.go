@@ -5,6 +5,8 @@ package app
 
 import (
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/cppforlife/go-cli-ui/ui"
 	"github.com/spf13/cobra"
@@ -21,6 +23,14 @@ type StatusOptions struct {
 	Name           string
 
 	IgnoreNotExists bool
+	Verbose         bool
+	Debug           bool
+	Quiet           bool
+	OnlyFailures    bool
+	LogFile         string
+	MaxLogAge       time.Duration
+	Stages          []string
+	WatchEvents     bool
 
 	columns *[]string
 }
@@ -41,7 +51,16 @@ func NewStatusCmd(o *StatusOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Co
 
 	o.NamespaceFlags.Set(cmd, flagsFactory)
 	cmd.Flags().StringVarP(&o.Name, "app", "a", "", "Set app name (required)")
+	_ = cmd.RegisterFlagCompletionFunc("app", AppNameCompletionFunc(o.depsFactory, &o.NamespaceFlags))
 	cmd.Flags().BoolVar(&o.IgnoreNotExists, "ignore-not-exists", false, "Keep following app if it does not exist")
+	cmd.Flags().BoolVar(&o.Verbose, "verbose", false, "Print full stage output instead of collapsed summaries on TTYs")
+	cmd.Flags().BoolVar(&o.Debug, "debug", false, "Print debug output, e.g. App spec diffs when a spec change triggers a reconcile")
+	cmd.Flags().BoolVar(&o.Quiet, "quiet", false, "Only print the terminal reconciled/failed line and any error output")
+	cmd.Flags().BoolVar(&o.OnlyFailures, "only-failures", false, "Suppress successful stage output; print full output only for a failed stage")
+	cmd.Flags().StringVar(&o.LogFile, "log-file", "", "Also write watch output (without color codes) to this file, truncating it per run")
+	cmd.Flags().DurationVar(&o.MaxLogAge, "max-log-age", 0, "Discard stage error output older than this duration (default 0 disables the age check)")
+	cmd.Flags().StringSliceVar(&o.Stages, "stages", nil, "Only print output for these stages (fetch, template, deploy; default all)")
+	cmd.Flags().BoolVar(&o.WatchEvents, "watch-events", false, "Also print events for resources labeled with the app's kapp app label")
 
 	return cmd
 }
@@ -51,15 +70,74 @@ func (o *StatusOptions) Run() error {
 		return fmt.Errorf("Expected app name to be non empty")
 	}
 
+	if o.Quiet && o.Verbose {
+		return fmt.Errorf("Expected either --quiet or --verbose, but not both")
+	}
+
+	if o.OnlyFailures && (o.Quiet || o.Verbose) {
+		return fmt.Errorf("Expected --only-failures to not be combined with --quiet or --verbose")
+	}
+
+	stages, err := NewStageFilter(o.Stages)
+	if err != nil {
+		return err
+	}
+
 	client, err := o.depsFactory.KappCtrlClient()
 	if err != nil {
 		return err
 	}
 
-	appWatcher := NewAppTailer(o.NamespaceFlags.Name, o.Name, o.ui, client, AppTailerOpts{
+	// Historical deploy durations (recorded by the controller when
+	// history is enabled) drive estimated-time-remaining output;
+	// fetched best effort so missing history just omits the estimate
+	var deployDurations []time.Duration
+	if coreClient, err := o.depsFactory.CoreClient(); err == nil {
+		deployDurations = recentDeployDurations(coreClient, o.NamespaceFlags.Name, o.Name)
+	}
+
+	watcherUI := o.ui
+	if o.LogFile != "" {
+		logFile, err := os.Create(o.LogFile)
+		if err != nil {
+			return fmt.Errorf("Creating log file: %s", err)
+		}
+		defer logFile.Close()
+		watcherUI = newLogFileUI(o.ui, logFile)
+	}
+
+	if o.WatchEvents {
+		coreClient, err := o.depsFactory.CoreClient()
+		if err != nil {
+			return err
+		}
+
+		eventsStopper := make(chan struct{})
+		defer close(eventsStopper)
+
+		// kapp-controller deploys the App via a kapp app named
+		// '<app-name>-ctrl'; its label selects the deployed resources
+		eventsUI := cmdcore.NewStatusLoggingUI(watcherUI)
+		eventsTailer := NewEventsTailer(coreClient.CoreV1().Events(o.NamespaceFlags.Name),
+			eventsUI, fmt.Sprintf("%s-ctrl", o.Name), eventsStopper)
+		go func() {
+			if err := eventsTailer.TailEvents(); err != nil {
+				eventsUI.PrintLogLine(err.Error(), "", true, time.Now())
+			}
+		}()
+	}
+
+	appWatcher := NewAppTailer(o.NamespaceFlags.Name, o.Name, watcherUI, client, AppTailerOpts{
 		IgnoreNotExists:   o.IgnoreNotExists,
-		PrintMetadata:     true,
+		PrintMetadata:     !o.Quiet,
 		PrintCurrentState: true,
+		Verbose:           o.Verbose,
+		Debug:             o.Debug,
+		Quiet:             o.Quiet,
+		OnlyFailures:      o.OnlyFailures,
+		MaxLogAge:         o.MaxLogAge,
+		Stages:            stages,
+		DeployDurations:   deployDurations,
 	}, o.columns)
 
 	err = appWatcher.TailAppStatus()
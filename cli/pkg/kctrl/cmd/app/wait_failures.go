@@ -0,0 +1,46 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Example kapp wait failure output:
+//
+//	kapp: Error: waiting on reconcile deployment/simple-app (apps/v1) namespace: default:
+//	  Finished unsuccessfully (Deployment is not progressing: ProgressDeadlineExceeded)
+var waitFailureRegexp = regexp.MustCompile(
+	`waiting on reconcile (?P<resource>\S+ \([^)]*\)(?: namespace: \S+)?):\s*` +
+		`(?:\n\s*)?(?:Finished unsuccessfully |Errored:? ?)?\(?(?P<reason>[^)\n]+)\)?`)
+
+type waitFailure struct {
+	Resource string
+	Reason   string
+}
+
+// parseWaitFailures extracts (resource, reason) entries from kapp's
+// wait failure stderr so the watcher can render them concisely.
+func parseWaitFailures(stderr string) []waitFailure {
+	var failures []waitFailure
+
+	for _, match := range waitFailureRegexp.FindAllStringSubmatch(stderr, -1) {
+		failures = append(failures, waitFailure{
+			Resource: strings.TrimSuffix(strings.TrimSpace(match[1]), ":"),
+			Reason:   strings.TrimSpace(match[2]),
+		})
+	}
+
+	return failures
+}
+
+func renderWaitFailures(failures []waitFailure) string {
+	var lines []string
+	for _, failure := range failures {
+		lines = append(lines, fmt.Sprintf("%s | %s", failure.Resource, failure.Reason))
+	}
+	return strings.Join(lines, "\n")
+}
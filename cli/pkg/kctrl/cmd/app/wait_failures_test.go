@@ -0,0 +1,47 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseWaitFailures(t *testing.T) {
+	stderr := `kapp: Error: waiting on reconcile deployment/simple-app (apps/v1) namespace: default:
+  Finished unsuccessfully (Deployment is not progressing: ProgressDeadlineExceeded)`
+
+	failures := parseWaitFailures(stderr)
+
+	assert.Equal(t, []waitFailure{{
+		Resource: "deployment/simple-app (apps/v1) namespace: default",
+		Reason:   "Deployment is not progressing: ProgressDeadlineExceeded",
+	}}, failures)
+}
+
+func TestParseWaitFailuresMultipleResources(t *testing.T) {
+	stderr := `kapp: Error: waiting on reconcile deployment/simple-app (apps/v1) namespace: default:
+  Finished unsuccessfully (Deployment is not progressing)
+kapp: Error: waiting on reconcile pod/simple-pod (v1) namespace: default:
+  Errored: Pod is in CrashLoopBackOff`
+
+	failures := parseWaitFailures(stderr)
+
+	assert.Len(t, failures, 2)
+	assert.Equal(t, "pod/simple-pod (v1) namespace: default", failures[1].Resource)
+	assert.Equal(t, "Pod is in CrashLoopBackOff", failures[1].Reason)
+}
+
+func TestRenderWaitFailures(t *testing.T) {
+	rendered := renderWaitFailures([]waitFailure{
+		{Resource: "deployment/simple-app (apps/v1)", Reason: "Deployment is not progressing"},
+	})
+
+	assert.Equal(t, "deployment/simple-app (apps/v1) | Deployment is not progressing", rendered)
+}
+
+func TestParseWaitFailuresNoFailures(t *testing.T) {
+	assert.Nil(t, parseWaitFailures("kapp: Error: Applying create configmap/cm: connection refused"))
+}
@@ -0,0 +1,22 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package core
+
+import (
+	"time"
+)
+
+// Clock provides the current time. Production code uses the real
+// clock; tests can substitute a frozen implementation to make
+// timestamped output deterministic.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+// NewRealClock returns a Clock backed by time.Now.
+func NewRealClock() Clock { return realClock{} }
+
+func (realClock) Now() time.Time { return time.Now() }
@@ -15,30 +15,53 @@ import (
 )
 
 type StatusLoggingUI struct {
-	ui ui.UI
+	ui    ui.UI
+	errUI ui.UI
+	clock Clock
 }
 
 func NewStatusLoggingUI(ui ui.UI) StatusLoggingUI {
-	return StatusLoggingUI{ui}
+	return StatusLoggingUI{ui, ui, NewRealClock()}
+}
+
+// NewStatusLoggingUIWithClock returns a StatusLoggingUI using the given
+// clock for timestamps and message ages (useful in tests).
+func NewStatusLoggingUIWithClock(ui ui.UI, clock Clock) StatusLoggingUI {
+	return StatusLoggingUI{ui, ui, clock}
+}
+
+// NewStatusLoggingUIWithSinks returns a StatusLoggingUI routing error
+// lines and blocks to errUI while normal output goes to outUI (e.g. so
+// embedding hosts can send errors to a log). A nil clock defaults to
+// the real clock.
+func NewStatusLoggingUIWithSinks(outUI ui.UI, errUI ui.UI, clock Clock) StatusLoggingUI {
+	if clock == nil {
+		clock = NewRealClock()
+	}
+	return StatusLoggingUI{outUI, errUI, clock}
 }
 
 func (s StatusLoggingUI) PrintMessage(message string) {
-	s.ui.BeginLinef("%s: %s\n", time.Now().Format("3:04:05PM"), message)
+	s.ui.BeginLinef("%s: %s\n", s.clock.Now().Format("3:04:05PM"), message)
 }
 
 func (s StatusLoggingUI) PrintMessagef(message string, args ...interface{}) {
 	message = fmt.Sprintf(message, args...)
-	s.ui.BeginLinef("%s: %s\n", time.Now().Format("3:04:05PM"), message)
+	s.ui.BeginLinef("%s: %s\n", s.clock.Now().Format("3:04:05PM"), message)
 }
 
 func (s StatusLoggingUI) PrintLogLine(message string, messageBlock string, errorBlock bool, timestamp time.Time) {
 	messageAge := ""
-	if time.Since(timestamp) > 1*time.Second {
-		messageAge = fmt.Sprintf("(%s ago)", duration.ShortHumanDuration(time.Since(timestamp)))
+	if s.clock.Now().Sub(timestamp) > 1*time.Second {
+		messageAge = fmt.Sprintf("(%s ago)", duration.ShortHumanDuration(s.clock.Now().Sub(timestamp)))
+	}
+	sink := s.ui
+	if errorBlock {
+		sink = s.errUI
 	}
-	s.ui.BeginLinef("%s: %s %s\n", timestamp.Local().Format("3:04:05PM"), message, messageAge)
+	sink.BeginLinef("%s: %s %s\n", timestamp.Local().Format("3:04:05PM"), message, messageAge)
 	if len(messageBlock) > 0 {
-		s.ui.PrintBlock([]byte(s.indentMessageBlock(messageBlock, errorBlock)))
+		sink.PrintBlock([]byte(s.indentMessageBlock(messageBlock, errorBlock)))
 	}
 }
 
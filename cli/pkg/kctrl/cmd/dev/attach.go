@@ -0,0 +1,48 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"fmt"
+	"sync"
+
+	fakekc "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/clientset/versioned/fake"
+)
+
+// attachRegistry tracks the fake clientsets of dev deploys running in
+// this process, keyed by namespace/name, so a later invocation can
+// attach a watcher to an in-flight deploy instead of starting another.
+// Dev deploys live entirely in-memory, so attaching is scoped to the
+// same process.
+type attachRegistry struct {
+	lock    sync.Mutex
+	clients map[string]*fakekc.Clientset
+}
+
+var runningDevDeploys = &attachRegistry{clients: map[string]*fakekc.Clientset{}}
+
+func (r *attachRegistry) register(namespace, name string, kcClient *fakekc.Clientset) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.clients[attachKey(namespace, name)] = kcClient
+}
+
+func (r *attachRegistry) deregister(namespace, name string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.clients, attachKey(namespace, name))
+}
+
+// attach returns the clientset backing an in-flight dev deploy of the
+// given app, or false when no such deploy is running in this process.
+func (r *attachRegistry) attach(namespace, name string) (*fakekc.Clientset, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	kcClient, found := r.clients[attachKey(namespace, name)]
+	return kcClient, found
+}
+
+func attachKey(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
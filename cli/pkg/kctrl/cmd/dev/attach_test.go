@@ -0,0 +1,36 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	fakekc "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAttachRegistryHandshake(t *testing.T) {
+	registry := &attachRegistry{clients: map[string]*fakekc.Clientset{}}
+
+	app := &kcv1alpha1.App{ObjectMeta: metav1.ObjectMeta{Name: "simple-app", Namespace: "default"}}
+	kcClient := fakekc.NewSimpleClientset(app)
+
+	_, found := registry.attach("default", "simple-app")
+	require.False(t, found, "expected no deploy to be attachable before registration")
+
+	registry.register("default", "simple-app", kcClient)
+
+	attached, found := registry.attach("default", "simple-app")
+	require.True(t, found)
+	require.Same(t, kcClient, attached, "expected attach to return the registered clientset")
+
+	_, found = registry.attach("other-ns", "simple-app")
+	require.False(t, found, "expected attach to be scoped by namespace")
+
+	registry.deregister("default", "simple-app")
+	_, found = registry.attach("default", "simple-app")
+	require.False(t, found, "expected deploy to no longer be attachable after deregistration")
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/logger"
 	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
 	fakekc "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/clientset/versioned/fake"
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/exec"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/yaml"
 )
@@ -28,11 +29,30 @@ type DevOptions struct {
 
 	NamespaceFlags cmdcore.NamespaceFlags
 
-	Files     []string
-	Local     bool
-	KbldBuild bool
-	Delete    bool
-	Debug     bool
+	Files            []string
+	NamePrefix       string
+	Attach           bool
+	Local            bool
+	KbldBuild        bool
+	Delete           bool
+	Debug            bool
+	Strict           bool
+	InspectOnFailure bool
+	GitSSHAgent      bool
+	Concurrency      int
+	DiffContext      int
+
+	cmdRunner exec.CmdRunner
+
+	FetchCACerts []string
+	DstCACerts   []string
+
+	PrintResolvedImages bool
+	PrintDefaulted      bool
+	SecretValues        []string
+
+	LibraryDataValues     []string
+	LibraryDataValueFiles []string
 }
 
 func NewDevOptions(ui ui.UI, depsFactory cmdcore.DepsFactory, logger logger.Logger) *DevOptions {
@@ -51,11 +71,25 @@ func NewCmd(o *DevOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Command {
 
 	o.NamespaceFlags.Set(cmd, flagsFactory)
 	cmd.Flags().StringSliceVarP(&o.Files, "file", "f", nil, "Set file name (required)")
+	cmd.Flags().StringVar(&o.NamePrefix, "name-prefix", "", "Prefix App and PackageInstall names (avoids collisions in shared namespaces)")
 
+	cmd.Flags().BoolVar(&o.Attach, "attach", false, "Attach the watcher to an in-flight dev deploy of the same app in this process instead of deploying")
 	cmd.Flags().BoolVarP(&o.Local, "local", "l", false, "Use local fetch source")
 	cmd.Flags().BoolVarP(&o.KbldBuild, "kbld-build", "b", false, "Allow kbld build")
 	cmd.Flags().BoolVar(&o.Delete, "delete", false, "Delete deployed app")
 	cmd.Flags().BoolVar(&o.Debug, "debug", false, "Show kapp-controller logs")
+	cmd.Flags().BoolVar(&o.Strict, "strict", false, "Fail on template stage ordering warnings instead of printing them")
+	cmd.Flags().BoolVar(&o.InspectOnFailure, "inspect-on-failure", false, "Run kapp inspect and print deployed resources when a deploy fails")
+	cmd.Flags().BoolVar(&o.GitSSHAgent, "git-ssh-agent", false, "Use local SSH agent (SSH_AUTH_SOCK) and known_hosts for git fetches over SSH")
+	cmd.Flags().IntVar(&o.Concurrency, "concurrency", 1, "Max number of independent Apps reconciled in parallel (ordering between Apps is not guaranteed when >1)")
+	cmd.Flags().IntVar(&o.DiffContext, "diff-context", -1, "Set number of context lines kapp shows around changes in deploy diffs (default uses kapp's own default)")
+	cmd.Flags().StringSliceVar(&o.FetchCACerts, "fetch-ca-cert", nil, "Set CA certificate file (PEM) trusted when fetching over TLS (can be specified multiple times)")
+	cmd.Flags().StringSliceVar(&o.DstCACerts, "dst-ca-cert", nil, "Set additional CA certificate file (PEM) trusted alongside the cluster CA when deploying (can be specified multiple times)")
+	cmd.Flags().BoolVar(&o.PrintResolvedImages, "print-resolved-images", false, "Print resolved image digests (kbld lock output) after templating (requires --kbld-build)")
+	cmd.Flags().BoolVar(&o.PrintDefaulted, "print-defaulted", false, "Print App CRs with implicit defaults applied instead of deploying")
+	cmd.Flags().StringSliceVar(&o.SecretValues, "secret-value", nil, "Set value for a placeholder secret as <secret-name>/<key>=<value> (prefix value with @ to read from a file; can be specified multiple times)")
+	cmd.Flags().StringSliceVar(&o.LibraryDataValues, "library-data-value", nil, "Set ytt library data value as <library>@<key>=<value> (can be specified multiple times)")
+	cmd.Flags().StringSliceVar(&o.LibraryDataValueFiles, "library-data-value-file", nil, "Set ytt library data values file as <library>@<path> (can be specified multiple times)")
 
 	return cmd
 }
@@ -66,9 +100,82 @@ func (o *DevOptions) Run() error {
 		return fmt.Errorf("Reading App CR configuration files: %s", err)
 	}
 
-	configs.ApplyNamespace(o.NamespaceFlags.Name)
+	configs.ApplyNamePrefix(o.NamePrefix)
+
+	overrides := configs.ApplyNamespace(o.NamespaceFlags.Name)
+	if o.Debug {
+		for _, override := range overrides {
+			o.ui.PrintLinef("Namespace defaulted to '%s' for %s", override.Namespace, override.Resource)
+		}
+	}
+
+	if len(o.SecretValues) > 0 {
+		secretValues, err := parseSecretValues(o.SecretValues, os.ReadFile)
+		if err != nil {
+			return err
+		}
+		err = applySecretValues(&configs, secretValues)
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.DiffContext >= 0 {
+		err = applyDiffContext(&configs, o.DiffContext)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = o.validateTemplateStages(configs)
+	if err != nil {
+		return err
+	}
+
+	if o.PrintDefaulted {
+		return o.printDefaultedApps(configs)
+	}
+
+	if o.Attach {
+		return o.attachToRunningDeploys(configs)
+	}
+
+	if len(o.FetchCACerts) > 0 {
+		err := configureFetchCACerts(o.FetchCACerts)
+		if err != nil {
+			return fmt.Errorf("Configuring fetch CA certs: %s", err)
+		}
+	}
+
+	var cmdRunner exec.CmdRunner = cmdlocal.NewDetailedCmdRunner(os.Stdout, o.Debug)
+
+	if o.GitSSHAgent {
+		env, err := gitSSHAgentEnv(os.Getenv)
+		if err != nil {
+			return err
+		}
+		cmdRunner = sshAgentCmdRunner{delegate: cmdRunner, env: env}
+	}
+
+	o.cmdRunner = cmdRunner
+
+	var lockFilePath string
+	if o.PrintResolvedImages {
+		if !o.KbldBuild {
+			return fmt.Errorf("Expected --kbld-build to be set when --print-resolved-images is used")
+		}
+
+		lockFile, err := os.CreateTemp("", "kctrl-kbld-lock")
+		if err != nil {
+			return fmt.Errorf("Creating kbld lock file: %s", err)
+		}
+		lockFile.Close()
+		lockFilePath = lockFile.Name()
+		defer os.Remove(lockFilePath)
+
+		cmdRunner = kbldLockCmdRunner{delegate: cmdRunner, lockFilePath: lockFilePath}
+	}
 
-	cmdRunner := cmdlocal.NewDetailedCmdRunner(os.Stdout, o.Debug)
 	reconciler := cmdlocal.NewReconciler(o.depsFactory, cmdRunner, o.logger)
 
 	reconcileErr := reconciler.Reconcile(configs, cmdlocal.ReconcileOpts{
@@ -77,6 +184,11 @@ func (o *DevOptions) Run() error {
 		Delete:          o.Delete,
 		Debug:           o.Debug,
 		DeployResources: true,
+		Concurrency:     o.Concurrency,
+		DstCACertFiles:  o.DstCACerts,
+
+		LibraryDataValues:     o.LibraryDataValues,
+		LibraryDataValueFiles: o.LibraryDataValueFiles,
 
 		BeforeAppReconcile: o.beforeAppReconcile,
 		AfterAppReconcile:  o.afterAppReconcile,
@@ -85,20 +197,100 @@ func (o *DevOptions) Run() error {
 	// TODO app watcher needs a little time to run; should block ideally
 	time.Sleep(100 * time.Millisecond)
 
+	if o.PrintResolvedImages {
+		lockBs, err := os.ReadFile(lockFilePath)
+		if err == nil && len(lockBs) > 0 {
+			images, err := ResolvedImagesFromKbldLock(lockBs)
+			if err != nil {
+				return fmt.Errorf("Printing resolved images: %s", err)
+			}
+			printResolvedImages(o.ui, images)
+		}
+	}
+
 	return reconcileErr
 }
 
+func (o *DevOptions) printDefaultedApps(configs cmdlocal.Configs) error {
+	for _, app := range configs.Apps {
+		bs, err := yaml.Marshal(defaultedApp(app))
+		if err != nil {
+			return fmt.Errorf("Marshaling defaulted App CR: %s", err)
+		}
+		o.ui.PrintBlock([]byte("---\n"))
+		o.ui.PrintBlock(bs)
+	}
+	return nil
+}
+
+func (o *DevOptions) validateTemplateStages(configs cmdlocal.Configs) error {
+	var warnings []string
+	for _, app := range configs.Apps {
+		for _, w := range validateTemplateStageOrder(app.Spec.Template) {
+			warnings = append(warnings, fmt.Sprintf("app/%s: %s", app.Name, w))
+		}
+	}
+	for _, pkg := range configs.Pkgs {
+		if pkg.Spec.Template.Spec == nil {
+			continue
+		}
+		for _, w := range validateTemplateStageOrder(pkg.Spec.Template.Spec.Template) {
+			warnings = append(warnings, fmt.Sprintf("package/%s: %s", pkg.Name, w))
+		}
+	}
+
+	if len(warnings) == 0 {
+		return nil
+	}
+	for _, w := range warnings {
+		o.ui.ErrorLinef("Warning: %s", w)
+	}
+	if o.Strict {
+		return fmt.Errorf("Expected template stages to be in a sane order (%d warnings; see above)", len(warnings))
+	}
+	return nil
+}
+
+// attachToRunningDeploys tails apps already being deployed by another
+// dev invocation in this process instead of starting a new deploy.
+func (o *DevOptions) attachToRunningDeploys(configs cmdlocal.Configs) error {
+	for _, app := range configs.Apps {
+		kcClient, found := runningDevDeploys.attach(app.Namespace, app.Name)
+		if !found {
+			return fmt.Errorf("Expected to find an in-flight dev deploy for app '%s' in namespace '%s' in this process", app.Name, app.Namespace)
+		}
+
+		o.ui.PrintLinef("Attaching to in-flight app/%s (namespace: %s) ...", app.Name, app.Namespace)
+
+		appWatcher := cmdapp.NewAppTailer(app.Namespace, app.Name,
+			o.ui, kcClient, cmdapp.AppTailerOpts{IgnoreNotExists: true}, nil)
+
+		err := appWatcher.TailAppStatus()
+		if err != nil {
+			return fmt.Errorf("Attaching to app '%s': %s", app.Name, err)
+		}
+	}
+	return nil
+}
+
 func (o *DevOptions) beforeAppReconcile(app kcv1alpha1.App, kcClient *fakekc.Clientset) error {
 	err := o.printRs(app.ObjectMeta, kcClient)
 	if err != nil {
 		return err
 	}
 
+	runningDevDeploys.register(app.Namespace, app.Name, kcClient)
+
 	o.ui.PrintLinef("Reconciling in-memory app/%s (namespace: %s) ...", app.Name, app.Namespace)
 
+	watcherUI := ui.UI(o.ui)
+	if o.Concurrency > 1 {
+		watcherUI = newPrefixedUI(fmt.Sprintf("app/%s | ", app.Name), o.ui)
+	}
+
 	go func() {
 		appWatcher := cmdapp.NewAppTailer(app.Namespace, app.Name,
-			o.ui, kcClient, cmdapp.AppTailerOpts{IgnoreNotExists: true}, nil)
+			watcherUI, kcClient, cmdapp.AppTailerOpts{IgnoreNotExists: true}, nil)
 
 		err := appWatcher.TailAppStatus()
 		if err != nil {
@@ -110,6 +302,18 @@ func (o *DevOptions) beforeAppReconcile(app kcv1alpha1.App, kcClient *fakekc.Cli
 }
 
 func (o *DevOptions) afterAppReconcile(app kcv1alpha1.App, kcClient *fakekc.Clientset) error {
+	runningDevDeploys.deregister(app.Namespace, app.Name)
+
+	if o.InspectOnFailure {
+		reconciledApp, err := kcClient.KappctrlV1alpha1().Apps(app.Namespace).Get(context.Background(), app.Name, metav1.GetOptions{})
+		if err == nil {
+			err := inspectOnFailure(o.cmdRunner, o.ui, o.InspectOnFailure, *reconciledApp)
+			if err != nil {
+				o.ui.ErrorLinef("%s", err)
+			}
+		}
+	}
+
 	if o.Debug {
 		return o.printRs(app.ObjectMeta, kcClient)
 	}
@@ -124,6 +328,11 @@ func (o *DevOptions) printRs(nsName metav1.ObjectMeta, kcClient *fakekc.Clientse
 			return fmt.Errorf("Marshaling App CR: %s", err)
 		}
 
+		bs, err = redactSecretValues(bs)
+		if err != nil {
+			return fmt.Errorf("Redacting App CR: %s", err)
+		}
+
 		o.ui.PrintBlock(bs)
 	}
 
@@ -134,6 +343,11 @@ func (o *DevOptions) printRs(nsName metav1.ObjectMeta, kcClient *fakekc.Clientse
 			return fmt.Errorf("Marshaling PackageInstall CR: %s", err)
 		}
 
+		bs, err = redactSecretValues(bs)
+		if err != nil {
+			return fmt.Errorf("Redacting PackageInstall CR: %s", err)
+		}
+
 		o.ui.PrintBlock(bs)
 	}
 
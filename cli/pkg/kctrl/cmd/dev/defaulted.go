@@ -0,0 +1,36 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"strconv"
+	"time"
+
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// minimumSyncPeriod is the floor applied to spec.syncPeriod by the
+// controller (values below it are raised to it).
+const minimumSyncPeriod = 30 * time.Second
+
+// defaultedApp returns a copy of given App with implicit controller
+// behavior made explicit: the sync period floor is applied and fetch
+// destination paths are filled in with the per-index sub directories
+// contents are placed into.
+func defaultedApp(app kcv1alpha1.App) kcv1alpha1.App {
+	result := *app.DeepCopy()
+
+	if result.Spec.SyncPeriod == nil || result.Spec.SyncPeriod.Duration < minimumSyncPeriod {
+		result.Spec.SyncPeriod = &metav1.Duration{Duration: minimumSyncPeriod}
+	}
+
+	for i, fetch := range result.Spec.Fetch {
+		if fetch.Path == "" {
+			result.Spec.Fetch[i].Path = strconv.Itoa(i)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,64 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDefaultedAppFillsImplicitValues(t *testing.T) {
+	input := kcv1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-app", Namespace: "default"},
+		Spec: kcv1alpha1.AppSpec{
+			Fetch: []kcv1alpha1.AppFetch{
+				{Inline: &kcv1alpha1.AppFetchInline{}},
+				{HTTP: &kcv1alpha1.AppFetchHTTP{URL: "https://example.com"}},
+			},
+		},
+	}
+
+	defaulted := defaultedApp(input)
+
+	require.NotNil(t, defaulted.Spec.SyncPeriod)
+	require.Equal(t, 30*time.Second, defaulted.Spec.SyncPeriod.Duration)
+	require.Equal(t, "0", defaulted.Spec.Fetch[0].Path)
+	require.Equal(t, "1", defaulted.Spec.Fetch[1].Path)
+
+	// input remains untouched
+	require.Nil(t, input.Spec.SyncPeriod)
+	require.Equal(t, "", input.Spec.Fetch[0].Path)
+}
+
+func TestDefaultedAppRaisesSyncPeriodToMinimum(t *testing.T) {
+	input := kcv1alpha1.App{
+		Spec: kcv1alpha1.AppSpec{
+			SyncPeriod: &metav1.Duration{Duration: 10 * time.Second},
+		},
+	}
+
+	defaulted := defaultedApp(input)
+
+	require.Equal(t, 30*time.Second, defaulted.Spec.SyncPeriod.Duration)
+}
+
+func TestDefaultedAppKeepsExplicitValues(t *testing.T) {
+	input := kcv1alpha1.App{
+		Spec: kcv1alpha1.AppSpec{
+			SyncPeriod: &metav1.Duration{Duration: 2 * time.Minute},
+			Fetch: []kcv1alpha1.AppFetch{
+				{Inline: &kcv1alpha1.AppFetchInline{}, Path: "custom-path"},
+			},
+		},
+	}
+
+	defaulted := defaultedApp(input)
+
+	require.Equal(t, 2*time.Minute, defaulted.Spec.SyncPeriod.Duration)
+	require.Equal(t, "custom-path", defaulted.Spec.Fetch[0].Path)
+}
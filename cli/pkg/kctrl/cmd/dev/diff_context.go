@@ -0,0 +1,41 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"fmt"
+
+	cmdlocal "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/local"
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/exec"
+)
+
+// applyDiffContext sets kapp's --diff-context on every kapp deploy
+// section of the provided App CRs so deploy diffs are printed with the
+// requested number of context lines. The option is passed as a raw
+// option and is verified against the restricted deploy flag set the
+// controller enforces (it is expected to always be allowed).
+func applyDiffContext(configs *cmdlocal.Configs, lines int) error {
+	opt := fmt.Sprintf("--diff-context=%d", lines)
+
+	flag, err := exec.NewFlagFromString(opt)
+	if err != nil {
+		return fmt.Errorf("Building diff context option: %s", err)
+	}
+	if !kappAllowedDeployFlagSet.Includes(flag.Name) {
+		return fmt.Errorf("Expected flag '%s' to be allowed as a kapp deploy raw option", flag.Name)
+	}
+
+	for i, app := range configs.Apps {
+		for j, deploy := range app.Spec.Deploy {
+			if deploy.Kapp == nil {
+				continue
+			}
+			// Appended last so it wins over any --diff-context already
+			// present in the file's rawOptions
+			configs.Apps[i].Spec.Deploy[j].Kapp.RawOptions = append(deploy.Kapp.RawOptions, opt)
+		}
+	}
+
+	return nil
+}
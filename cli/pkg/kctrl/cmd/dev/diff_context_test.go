@@ -0,0 +1,82 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	cmdlocal "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/local"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplyDiffContextPassesThroughAsRawOption(t *testing.T) {
+	configs := cmdlocal.Configs{
+		Apps: []kcv1alpha1.App{{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-app"},
+			Spec: kcv1alpha1.AppSpec{
+				Deploy: []kcv1alpha1.AppDeploy{{Kapp: &kcv1alpha1.AppDeployKapp{}}},
+			},
+		}},
+	}
+
+	err := applyDiffContext(&configs, 4)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"--diff-context=4"}, configs.Apps[0].Spec.Deploy[0].Kapp.RawOptions)
+}
+
+func TestApplyDiffContextWinsOverExistingRawOption(t *testing.T) {
+	configs := cmdlocal.Configs{
+		Apps: []kcv1alpha1.App{{
+			Spec: kcv1alpha1.AppSpec{
+				Deploy: []kcv1alpha1.AppDeploy{{Kapp: &kcv1alpha1.AppDeployKapp{
+					RawOptions: []string{"--diff-context=2"},
+				}}},
+			},
+		}},
+	}
+
+	err := applyDiffContext(&configs, 8)
+	require.NoError(t, err)
+
+	// Appended last so kapp's last-flag-wins behavior applies
+	require.Equal(t, []string{"--diff-context=2", "--diff-context=8"}, configs.Apps[0].Spec.Deploy[0].Kapp.RawOptions)
+}
+
+func TestApplyDiffContextSkipsNonKappDeploys(t *testing.T) {
+	configs := cmdlocal.Configs{
+		Apps: []kcv1alpha1.App{{
+			Spec: kcv1alpha1.AppSpec{Deploy: []kcv1alpha1.AppDeploy{{}}},
+		}},
+	}
+
+	err := applyDiffContext(&configs, 4)
+	require.NoError(t, err)
+
+	require.Nil(t, configs.Apps[0].Spec.Deploy[0].Kapp)
+}
+
+func TestDiffContextAllowedByRestrictedDeployFlagSet(t *testing.T) {
+	// The option must survive kapp-controller's raw option allow list
+	// (pkg/deploy/kapp_restrict.go) that lint mirrors
+	require.True(t, kappAllowedDeployFlagSet.Includes("--diff-context"))
+
+	findings := lintAppFile("app.yml", []byte(`
+apiVersion: kappctrl.k14s.io/v1alpha1
+kind: App
+metadata:
+  name: test-app
+spec:
+  fetch:
+  - inline: {}
+  template:
+  - ytt: {}
+  deploy:
+  - kapp:
+      rawOptions: ["--diff-context=4"]
+`))
+	require.Empty(t, findings)
+}
@@ -0,0 +1,58 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+// configureFetchCACerts adds the CA certificates from the given PEM files
+// to the trust store used by in-process HTTP fetches (e.g. behind
+// corporate proxies with private CAs).
+func configureFetchCACerts(paths []string) error {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+
+	err = appendCACertsFromFiles(pool, paths)
+	if err != nil {
+		return err
+	}
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("Expected default HTTP transport to be configurable")
+	}
+
+	transport = transport.Clone()
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{}
+	}
+	transport.TLSClientConfig.RootCAs = pool
+	http.DefaultTransport = transport
+
+	return nil
+}
+
+// appendCACertsFromFiles appends all PEM certificates found in the given
+// files to the pool, erroring when a file has no parseable certificates.
+func appendCACertsFromFiles(pool *x509.CertPool, paths []string) error {
+	for _, path := range paths {
+		pemBs, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("Reading CA cert file '%s': %s", path, err)
+		}
+
+		if !pool.AppendCertsFromPEM(pemBs) {
+			return fmt.Errorf("Expected CA cert file '%s' to contain at least one PEM certificate", path)
+		}
+	}
+
+	return nil
+}
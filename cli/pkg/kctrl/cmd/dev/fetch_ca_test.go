@@ -0,0 +1,65 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func selfSignedCAPEM(t *testing.T, commonName string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	derBs, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBs})
+}
+
+func TestAppendCACertsFromFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	paths := []string{filepath.Join(dir, "ca1.pem"), filepath.Join(dir, "ca2.pem")}
+	require.NoError(t, os.WriteFile(paths[0], selfSignedCAPEM(t, "test-ca-1"), 0600))
+	require.NoError(t, os.WriteFile(paths[1], selfSignedCAPEM(t, "test-ca-2"), 0600))
+
+	pool := x509.NewCertPool()
+	require.NoError(t, appendCACertsFromFiles(pool, paths))
+
+	assert.Len(t, pool.Subjects(), 2)
+}
+
+func TestAppendCACertsFromFilesRejectsNonPEMFile(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "not-a-cert.pem")
+	require.NoError(t, os.WriteFile(path, []byte("junk"), 0600))
+
+	err := appendCACertsFromFiles(x509.NewCertPool(), []string{path})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one PEM certificate")
+}
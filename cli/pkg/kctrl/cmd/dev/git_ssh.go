@@ -0,0 +1,54 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"fmt"
+	goexec "os/exec"
+	"path/filepath"
+
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/exec"
+)
+
+// gitSSHAgentEnv selects the environment for git-over-SSH fetches
+// when the local SSH agent is used instead of inline keys. getenv is
+// injected so transport selection can be tested without a live agent.
+func gitSSHAgentEnv(getenv func(string) string) ([]string, error) {
+	sock := getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		return nil, fmt.Errorf("Expected SSH_AUTH_SOCK to be set when --git-ssh-agent is used (is an ssh-agent running?)")
+	}
+
+	env := []string{"SSH_AUTH_SOCK=" + sock}
+
+	knownHosts := getenv("SSH_KNOWN_HOSTS")
+	if knownHosts == "" && getenv("HOME") != "" {
+		knownHosts = filepath.Join(getenv("HOME"), ".ssh", "known_hosts")
+	}
+	if knownHosts != "" {
+		env = append(env, fmt.Sprintf("GIT_SSH_COMMAND=ssh -o UserKnownHostsFile=%s", knownHosts))
+	}
+
+	return env, nil
+}
+
+// sshAgentCmdRunner forwards SSH agent environment to fetch
+// subprocesses (vendir clears inherited environment for some
+// invocations, so the agent socket must be added explicitly).
+type sshAgentCmdRunner struct {
+	delegate exec.CmdRunner
+	env      []string
+}
+
+var _ exec.CmdRunner = sshAgentCmdRunner{}
+
+func (r sshAgentCmdRunner) Run(cmd *goexec.Cmd) error {
+	cmd.Env = append(cmd.Env, r.env...)
+	return r.delegate.Run(cmd)
+}
+
+func (r sshAgentCmdRunner) RunWithCancel(cmd *goexec.Cmd, cancelCh chan struct{}) error {
+	cmd.Env = append(cmd.Env, r.env...)
+	return r.delegate.RunWithCancel(cmd, cancelCh)
+}
@@ -0,0 +1,57 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	goexec "os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func fakeGetenv(env map[string]string) func(string) string {
+	return func(key string) string { return env[key] }
+}
+
+func TestGitSSHAgentEnvSelectsAgentTransport(t *testing.T) {
+	env, err := gitSSHAgentEnv(fakeGetenv(map[string]string{
+		"SSH_AUTH_SOCK": "/tmp/agent.sock",
+		"HOME":          "/home/dev",
+	}))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"SSH_AUTH_SOCK=/tmp/agent.sock",
+		"GIT_SSH_COMMAND=ssh -o UserKnownHostsFile=/home/dev/.ssh/known_hosts",
+	}, env)
+}
+
+func TestGitSSHAgentEnvPrefersExplicitKnownHosts(t *testing.T) {
+	env, err := gitSSHAgentEnv(fakeGetenv(map[string]string{
+		"SSH_AUTH_SOCK":   "/tmp/agent.sock",
+		"SSH_KNOWN_HOSTS": "/etc/ssh/known_hosts",
+		"HOME":            "/home/dev",
+	}))
+	require.NoError(t, err)
+	require.Contains(t, env, "GIT_SSH_COMMAND=ssh -o UserKnownHostsFile=/etc/ssh/known_hosts")
+}
+
+func TestGitSSHAgentEnvRequiresAgentSocket(t *testing.T) {
+	_, err := gitSSHAgentEnv(fakeGetenv(map[string]string{"HOME": "/home/dev"}))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "SSH_AUTH_SOCK")
+}
+
+func TestSSHAgentCmdRunnerForwardsEnv(t *testing.T) {
+	var runs [][]string
+	delegate := recordingCmdRunner{runs: &runs}
+	runner := sshAgentCmdRunner{delegate: delegate, env: []string{"SSH_AUTH_SOCK=/tmp/agent.sock"}}
+
+	cmd := goexec.Command("vendir", "sync")
+	require.NoError(t, runner.Run(cmd))
+	require.Contains(t, cmd.Env, "SSH_AUTH_SOCK=/tmp/agent.sock")
+
+	cmd = goexec.Command("vendir", "sync")
+	require.NoError(t, runner.RunWithCancel(cmd, nil))
+	require.Contains(t, cmd.Env, "SSH_AUTH_SOCK=/tmp/agent.sock")
+}
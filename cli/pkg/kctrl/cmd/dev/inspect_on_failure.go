@@ -0,0 +1,48 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"bytes"
+	"fmt"
+	goexec "os/exec"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/exec"
+)
+
+// inspectOnFailure runs `kapp inspect --raw` for the app and prints
+// the resources it deployed when the app's last deploy failed. Only
+// flags from kapp-controller's allowed inspect flag set are used.
+// It is gated behind --inspect-on-failure and is best effort; the
+// deploy failure itself is still surfaced by the watcher.
+func inspectOnFailure(runner exec.CmdRunner, ui ui.UI, enabled bool, app kcv1alpha1.App) error {
+	if !shouldInspectOnFailure(enabled, app.Status) {
+		return nil
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := goexec.Command("kapp", "inspect", "-a", app.Name+".app", "-n", app.Namespace, "--raw")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := runner.Run(cmd)
+	if err != nil {
+		return fmt.Errorf("Inspecting app after deploy failure: %s (stderr: %s)", err, stderr.String())
+	}
+
+	ui.PrintLinef("Resources deployed by failed app/%s (namespace: %s):", app.Name, app.Namespace)
+	ui.PrintBlock(stdout.Bytes())
+
+	return nil
+}
+
+func shouldInspectOnFailure(enabled bool, status kcv1alpha1.AppStatus) bool {
+	if !enabled || status.Deploy == nil {
+		return false
+	}
+	return status.Deploy.Error != "" || status.Deploy.ExitCode != 0
+}
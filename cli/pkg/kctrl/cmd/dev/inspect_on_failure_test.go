@@ -0,0 +1,58 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	goexec "os/exec"
+	"testing"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type recordingCmdRunner struct {
+	runs *[][]string
+}
+
+func (r recordingCmdRunner) Run(cmd *goexec.Cmd) error {
+	*r.runs = append(*r.runs, cmd.Args)
+	return nil
+}
+
+func (r recordingCmdRunner) RunWithCancel(cmd *goexec.Cmd, _ chan struct{}) error {
+	return r.Run(cmd)
+}
+
+func TestInspectOnFailureRunsOnlyOnFailureWhenEnabled(t *testing.T) {
+	failedApp := kcv1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{Name: "simple-app", Namespace: "default"},
+		Status:     kcv1alpha1.AppStatus{Deploy: &kcv1alpha1.AppStatusDeploy{Error: "Deploying: exit status 1", ExitCode: 1}},
+	}
+	succeededApp := kcv1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{Name: "simple-app", Namespace: "default"},
+		Status:     kcv1alpha1.AppStatus{Deploy: &kcv1alpha1.AppStatusDeploy{ExitCode: 0}},
+	}
+
+	var runs [][]string
+	runner := recordingCmdRunner{runs: &runs}
+
+	require.NoError(t, inspectOnFailure(runner, ui.NewNoopUI(), true, succeededApp))
+	require.Empty(t, runs, "expected no inspect for successful deploy")
+
+	require.NoError(t, inspectOnFailure(runner, ui.NewNoopUI(), false, failedApp))
+	require.Empty(t, runs, "expected no inspect when not enabled")
+
+	require.NoError(t, inspectOnFailure(runner, ui.NewNoopUI(), true, failedApp))
+	require.Len(t, runs, 1)
+	require.Equal(t, []string{"kapp", "inspect", "-a", "simple-app.app", "-n", "default", "--raw"}, runs[0])
+}
+
+func TestShouldInspectOnFailureRequiresDeployStatus(t *testing.T) {
+	require.False(t, shouldInspectOnFailure(true, kcv1alpha1.AppStatus{}))
+	require.True(t, shouldInspectOnFailure(true, kcv1alpha1.AppStatus{
+		Deploy: &kcv1alpha1.AppStatusDeploy{Error: "Deploying: exit status 1"},
+	}))
+}
@@ -0,0 +1,236 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	uitable "github.com/cppforlife/go-cli-ui/ui/table"
+	"github.com/spf13/cobra"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	"github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/logger"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/exec"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+type LintOptions struct {
+	ui          ui.UI
+	depsFactory cmdcore.DepsFactory
+	logger      logger.Logger
+
+	Files  []string
+	Output string
+
+	columns *[]string
+}
+
+func NewLintOptions(ui ui.UI, depsFactory cmdcore.DepsFactory, logger logger.Logger, columns *[]string) *LintOptions {
+	return &LintOptions{ui: ui, depsFactory: depsFactory, logger: logger, columns: columns}
+}
+
+func NewLintCmd(o *LintOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint",
+		Short: "Validate App CRs without deploying them",
+		RunE:  func(_ *cobra.Command, _ []string) error { return o.Run() },
+		Annotations: map[string]string{
+			cmdcore.DevHelpGroup.Key: cmdcore.DevHelpGroup.Value,
+		},
+	}
+
+	cmd.Flags().StringSliceVarP(&o.Files, "file", "f", nil, "Set file name (required)")
+	cmd.Flags().StringVarP(&o.Output, "output", "o", "", "Output format (json)")
+
+	return cmd
+}
+
+func (o *LintOptions) Run() error {
+	if len(o.Files) == 0 {
+		return fmt.Errorf("Expected App CR files to be specified with -f")
+	}
+	if o.Output != "" && o.Output != "json" {
+		return fmt.Errorf("Expected --output to be 'json', but was '%s'", o.Output)
+	}
+
+	findings := []lintFinding{}
+	for _, file := range o.Files {
+		bs, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("Reading App CR file: %s", err)
+		}
+		findings = append(findings, lintAppFile(file, bs)...)
+	}
+
+	numErrors := 0
+	for _, finding := range findings {
+		if finding.Severity == lintSeverityError {
+			numErrors++
+		}
+	}
+
+	if o.Output == "json" {
+		bs, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return fmt.Errorf("Marshaling findings: %s", err)
+		}
+		o.ui.PrintBlock(append(bs, '\n'))
+	} else {
+		table := uitable.Table{
+			Title: "Findings",
+
+			Header: []uitable.Header{
+				uitable.NewHeader("Severity"),
+				uitable.NewHeader("File"),
+				uitable.NewHeader("Line"),
+				uitable.NewHeader("Message"),
+			},
+		}
+
+		for _, finding := range findings {
+			table.Rows = append(table.Rows, []uitable.Value{
+				uitable.NewValueString(finding.Severity),
+				uitable.NewValueString(finding.File),
+				uitable.NewValueInt(finding.Line),
+				uitable.NewValueString(finding.Message),
+			})
+		}
+
+		err := cmdcore.PrintTable(o.ui, table, o.columns)
+		if err != nil {
+			return err
+		}
+	}
+
+	if numErrors > 0 {
+		return fmt.Errorf("Expected App CRs to lint cleanly (%d errors)", numErrors)
+	}
+	return nil
+}
+
+const (
+	lintSeverityError   = "error"
+	lintSeverityWarning = "warning"
+)
+
+type lintFinding struct {
+	Severity string `json:"severity"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Message  string `json:"message"`
+}
+
+// kappAllowedDeployOpts mirrors kapp-controller's allowed deploy flag
+// set (pkg/deploy/kapp_restrict.go); raw options outside this list are
+// rejected by the controller at deploy time
+var kappAllowedDeployOpts = []string{
+	// Globals
+	"--column", "--debug", "--json", "--tty",
+	"--dangerous-ignore-failing-api-services",
+	"--dangerous-scope-to-fallback-allowed-namespaces",
+	// Filtering
+	"--filter", "--filter-age", "--filter-kind", "--filter-kind-name",
+	"--filter-kind-ns", "--filter-kind-ns-name", "--filter-name", "--filter-ns",
+	"--kube-api-qps", "--kube-api-burst",
+	// Diffing
+	"--diff-changes", "--diff-against-last-applied", "--diff-context",
+	"--diff-line-numbers", "--diff-mask", "--diff-run", "--diff-summary", "--diff-anchored",
+	// Applying
+	"--apply-check-interval", "--apply-concurrency", "--apply-default-update-strategy",
+	"--apply-ignored", "--apply-timeout", "--exit-early-on-apply-error",
+	// Waiting
+	"--wait", "--wait-check-interval", "--wait-concurrency",
+	"--wait-ignored", "--wait-timeout", "--exit-early-on-wait-error",
+
+	"--dangerous-allow-empty-list-of-resources",
+	"--existing-non-labeled-resources-check",
+	"--existing-non-labeled-resources-check-concurrency",
+	"--dangerous-override-ownership-of-existing-resources",
+	"--into-ns", "--map-ns",
+	"--logs", "--logs-all",
+	"--app-changes-max-to-keep",
+	"--labels", "--patch",
+}
+
+var kappAllowedDeployFlagSet = exec.NewFlagSet(kappAllowedDeployOpts)
+
+var yamlDocSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// lintAppFile validates all App CR documents in a YAML file and
+// returns findings; non-App documents are skipped. Findings carry the
+// starting line of the document they refer to.
+func lintAppFile(file string, bs []byte) []lintFinding {
+	var findings []lintFinding
+
+	line := 1
+	for _, doc := range yamlDocSeparator.Split(string(bs), -1) {
+		docLine := line
+		// +1 accounts for the separator line consumed by the split
+		line += strings.Count(doc, "\n") + 1
+
+		var typeMeta metav1.TypeMeta
+		err := yaml.Unmarshal([]byte(doc), &typeMeta)
+		if err != nil || typeMeta.Kind != "App" {
+			continue
+		}
+
+		var app kcv1alpha1.App
+		err = yaml.UnmarshalStrict([]byte(doc), &app)
+		if err != nil {
+			findings = append(findings, lintFinding{lintSeverityError, file, docLine, fmt.Sprintf("Unknown or invalid fields: %s", err)})
+			continue
+		}
+
+		findings = append(findings, lintApp(file, docLine, app)...)
+	}
+
+	return findings
+}
+
+func lintApp(file string, line int, app kcv1alpha1.App) []lintFinding {
+	var findings []lintFinding
+
+	if len(app.Spec.Fetch) == 0 {
+		findings = append(findings, lintFinding{lintSeverityError, file, line, "Expected at least one fetch section"})
+	}
+	if len(app.Spec.Template) == 0 {
+		findings = append(findings, lintFinding{lintSeverityError, file, line, "Expected at least one template section"})
+	}
+	if len(app.Spec.Deploy) == 0 {
+		findings = append(findings, lintFinding{lintSeverityError, file, line, "Expected at least one deploy section"})
+	}
+
+	for _, deploy := range app.Spec.Deploy {
+		if deploy.Kapp == nil {
+			continue
+		}
+		for _, opt := range deploy.Kapp.RawOptions {
+			flag, err := exec.NewFlagFromString(opt)
+			if err != nil {
+				findings = append(findings, lintFinding{lintSeverityError, file, line, err.Error()})
+				continue
+			}
+			if !kappAllowedDeployFlagSet.Includes(flag.Name) {
+				findings = append(findings, lintFinding{lintSeverityError, file, line,
+					fmt.Sprintf("Flag '%s' is not allowed as a kapp raw option (not in the deploy allowed set)", flag.Name)})
+			}
+			if flag.Name == "--into-ns" && deploy.Kapp.IntoNs != "" && flag.Value != deploy.Kapp.IntoNs {
+				findings = append(findings, lintFinding{lintSeverityWarning, file, line,
+					fmt.Sprintf("Conflicting namespace mappings: intoNs '%s' vs raw option '%s'", deploy.Kapp.IntoNs, opt)})
+			}
+		}
+		if deploy.Kapp.IntoNs != "" && len(deploy.Kapp.MapNs) > 0 {
+			findings = append(findings, lintFinding{lintSeverityWarning, file, line,
+				fmt.Sprintf("Conflicting namespace mappings: both intoNs '%s' and mapNs are set", deploy.Kapp.IntoNs)})
+		}
+	}
+
+	return findings
+}
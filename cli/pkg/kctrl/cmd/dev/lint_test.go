@@ -0,0 +1,162 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const lintValidApp = `
+apiVersion: kappctrl.k14s.io/v1alpha1
+kind: App
+metadata:
+  name: simple-app
+spec:
+  fetch:
+  - inline:
+      paths:
+        file.yml: "key: value"
+  template:
+  - ytt: {}
+  deploy:
+  - kapp: {}
+`
+
+func TestLintAppFileValidApp(t *testing.T) {
+	require.Empty(t, lintAppFile("app.yml", []byte(lintValidApp)))
+}
+
+func TestLintAppFileUnknownFields(t *testing.T) {
+	appYAML := `
+apiVersion: kappctrl.k14s.io/v1alpha1
+kind: App
+metadata:
+  name: simple-app
+spec:
+  fetchh:
+  - inline: {}
+  template:
+  - ytt: {}
+  deploy:
+  - kapp: {}
+`
+	findings := lintAppFile("app.yml", []byte(appYAML))
+	require.Len(t, findings, 1)
+	require.Equal(t, lintSeverityError, findings[0].Severity)
+	require.Contains(t, findings[0].Message, "Unknown or invalid fields")
+}
+
+func TestLintAppFileMissingSections(t *testing.T) {
+	appYAML := `
+apiVersion: kappctrl.k14s.io/v1alpha1
+kind: App
+metadata:
+  name: simple-app
+spec: {}
+`
+	findings := lintAppFile("app.yml", []byte(appYAML))
+	require.Len(t, findings, 3)
+	for _, finding := range findings {
+		require.Equal(t, lintSeverityError, finding.Severity)
+	}
+}
+
+func TestLintAppFileDisallowedRawOptions(t *testing.T) {
+	appYAML := `
+apiVersion: kappctrl.k14s.io/v1alpha1
+kind: App
+metadata:
+  name: simple-app
+spec:
+  fetch:
+  - inline: {}
+  template:
+  - ytt: {}
+  deploy:
+  - kapp:
+      rawOptions: ["--kubeconfig=/tmp/config", "--wait-timeout=5m"]
+`
+	findings := lintAppFile("app.yml", []byte(appYAML))
+	require.Len(t, findings, 1)
+	require.Equal(t, lintSeverityError, findings[0].Severity)
+	require.Contains(t, findings[0].Message, "--kubeconfig")
+}
+
+func TestLintAppFileConflictingNamespaceMappings(t *testing.T) {
+	appYAML := `
+apiVersion: kappctrl.k14s.io/v1alpha1
+kind: App
+metadata:
+  name: simple-app
+spec:
+  fetch:
+  - inline: {}
+  template:
+  - ytt: {}
+  deploy:
+  - kapp:
+      intoNs: ns1
+      rawOptions: ["--into-ns=ns2"]
+`
+	findings := lintAppFile("app.yml", []byte(appYAML))
+	require.Len(t, findings, 1)
+	require.Equal(t, lintSeverityWarning, findings[0].Severity)
+	require.Contains(t, findings[0].Message, "Conflicting namespace mappings")
+}
+
+func TestLintAppFileSkipsNonAppDocs(t *testing.T) {
+	configYAML := `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+` + lintValidApp
+	require.Empty(t, lintAppFile("app.yml", []byte(configYAML)))
+}
+
+func TestLintAppFileRecordsDocumentLine(t *testing.T) {
+	configYAML := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+---
+apiVersion: kappctrl.k14s.io/v1alpha1
+kind: App
+metadata:
+  name: simple-app
+spec: {}
+`
+	findings := lintAppFile("app.yml", []byte(configYAML))
+	require.Len(t, findings, 3)
+	for _, finding := range findings {
+		// The App document starts right after the separator on line 5
+		require.Equal(t, 6, finding.Line)
+	}
+}
+
+func TestLintFindingsSerializeToJSON(t *testing.T) {
+	appYAML := `apiVersion: kappctrl.k14s.io/v1alpha1
+kind: App
+metadata:
+  name: simple-app
+spec:
+  fetch:
+  - inline: {}
+  template:
+  - ytt: {}
+`
+	findings := lintAppFile("app.yml", []byte(appYAML))
+	require.Len(t, findings, 1)
+
+	bs, err := json.Marshal(findings)
+	require.NoError(t, err)
+	require.Contains(t, string(bs), `"severity":"error"`)
+	require.Contains(t, string(bs), `"file":"app.yml"`)
+	require.Contains(t, string(bs), `"line":1`)
+	require.Contains(t, string(bs), `"message":"Expected at least one deploy section"`)
+}
@@ -0,0 +1,75 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	uitable "github.com/cppforlife/go-cli-ui/ui/table"
+)
+
+// prefixedUI prefixes every printed line so that output from multiple
+// apps being watched in parallel stays attributable to its app.
+type prefixedUI struct {
+	prefix string
+	parent ui.UI
+}
+
+var _ ui.UI = &prefixedUI{}
+
+func newPrefixedUI(prefix string, parent ui.UI) *prefixedUI {
+	return &prefixedUI{prefix: prefix, parent: parent}
+}
+
+func (u *prefixedUI) ErrorLinef(pattern string, args ...interface{}) {
+	u.parent.ErrorLinef("%s%s", u.prefix, fmt.Sprintf(pattern, args...))
+}
+
+func (u *prefixedUI) PrintLinef(pattern string, args ...interface{}) {
+	u.parent.PrintLinef("%s%s", u.prefix, fmt.Sprintf(pattern, args...))
+}
+
+func (u *prefixedUI) BeginLinef(pattern string, args ...interface{}) {
+	u.parent.BeginLinef("%s%s", u.prefix, fmt.Sprintf(pattern, args...))
+}
+
+func (u *prefixedUI) EndLinef(pattern string, args ...interface{}) {
+	u.parent.EndLinef(pattern, args...)
+}
+
+func (u *prefixedUI) PrintBlock(block []byte) {
+	u.parent.PrintBlock([]byte(u.prefixLines(string(block))))
+}
+
+func (u *prefixedUI) PrintErrorBlock(block string) {
+	u.parent.PrintErrorBlock(u.prefixLines(block))
+}
+
+func (u *prefixedUI) prefixLines(block string) string {
+	lines := strings.Split(strings.TrimSuffix(block, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = u.prefix + line
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func (u *prefixedUI) PrintTable(table uitable.Table) { u.parent.PrintTable(table) }
+
+func (u *prefixedUI) AskForText(opts ui.TextOpts) (string, error) { return u.parent.AskForText(opts) }
+
+func (u *prefixedUI) AskForChoice(opts ui.ChoiceOpts) (int, error) {
+	return u.parent.AskForChoice(opts)
+}
+
+func (u *prefixedUI) AskForPassword(label string) (string, error) {
+	return u.parent.AskForPassword(label)
+}
+
+func (u *prefixedUI) AskForConfirmation() error { return u.parent.AskForConfirmation() }
+
+func (u *prefixedUI) IsInteractive() bool { return u.parent.IsInteractive() }
+
+func (u *prefixedUI) Flush() { u.parent.Flush() }
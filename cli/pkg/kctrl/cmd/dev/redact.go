@@ -0,0 +1,105 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const redactedValue = "<redacted>"
+
+// secretFieldMarkers are substrings of field names whose string values
+// are considered secret-bearing and are masked before debug printing.
+var secretFieldMarkers = []string{
+	"password",
+	"token",
+	"apikey",
+	"api_key",
+	"accesskey",
+	"access_key",
+	"privatekey",
+	"private_key",
+	"clientsecret",
+	"client_secret",
+}
+
+// redactSecretValues masks values of known secret-bearing fields in a
+// marshaled YAML document, including fields inside inline file contents
+// (e.g. values files under spec.fetch[].inline.paths).
+func redactSecretValues(bs []byte) ([]byte, error) {
+	var doc map[string]interface{}
+	err := yaml.Unmarshal(bs, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	if !maskSecretFields(doc) {
+		return bs, nil
+	}
+
+	return yaml.Marshal(doc)
+}
+
+func maskSecretFields(node interface{}) bool {
+	masked := false
+	switch typedNode := node.(type) {
+	case map[string]interface{}:
+		for key, val := range typedNode {
+			if strVal, ok := val.(string); ok {
+				if isSecretFieldKey(key) {
+					typedNode[key] = redactedValue
+					masked = true
+					continue
+				}
+				if newVal, ok := maskInlineContent(strVal); ok {
+					typedNode[key] = newVal
+					masked = true
+				}
+				continue
+			}
+			if maskSecretFields(val) {
+				masked = true
+			}
+		}
+	case []interface{}:
+		for _, item := range typedNode {
+			if maskSecretFields(item) {
+				masked = true
+			}
+		}
+	}
+	return masked
+}
+
+// maskInlineContent masks secret-bearing fields within a string value
+// that itself parses as a YAML document (e.g. an inline values file).
+func maskInlineContent(content string) (string, bool) {
+	var doc map[string]interface{}
+	err := yaml.Unmarshal([]byte(content), &doc)
+	if err != nil || doc == nil {
+		return content, false
+	}
+
+	if !maskSecretFields(doc) {
+		return content, false
+	}
+
+	bs, err := yaml.Marshal(doc)
+	if err != nil {
+		return content, false
+	}
+	return string(bs), true
+}
+
+func isSecretFieldKey(key string) bool {
+	lowered := strings.ToLower(key)
+	for _, marker := range secretFieldMarkers {
+		if strings.Contains(lowered, marker) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactSecretValuesMasksSecretFields(t *testing.T) {
+	in := `
+spec:
+  fetch:
+  - imgpkgBundle:
+      image: registry.example.com/my-pkg:v1
+  registryPassword: hunter2
+  registryToken: tok-123
+`
+
+	out, err := redactSecretValues([]byte(in))
+	require.NoError(t, err)
+
+	require.Contains(t, string(out), "registryPassword: <redacted>")
+	require.Contains(t, string(out), "registryToken: <redacted>")
+	require.NotContains(t, string(out), "hunter2")
+	require.NotContains(t, string(out), "tok-123")
+	require.Contains(t, string(out), "registry.example.com/my-pkg:v1")
+}
+
+func TestRedactSecretValuesMasksInlineFileContents(t *testing.T) {
+	in := `
+spec:
+  fetch:
+  - inline:
+      paths:
+        values.yml: |
+          username: admin
+          password: hunter2
+`
+
+	out, err := redactSecretValues([]byte(in))
+	require.NoError(t, err)
+
+	require.Contains(t, string(out), "password: <redacted>")
+	require.NotContains(t, string(out), "hunter2")
+	require.Contains(t, string(out), "username: admin")
+}
+
+func TestRedactSecretValuesLeavesNonSecretDocsUntouched(t *testing.T) {
+	in := `metadata:
+  name: simple-app
+spec:
+  serviceAccountName: default-ns-sa
+`
+
+	out, err := redactSecretValues([]byte(in))
+	require.NoError(t, err)
+	require.Equal(t, in, string(out))
+}
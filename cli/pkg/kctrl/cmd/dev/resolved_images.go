@@ -0,0 +1,86 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"fmt"
+	goexec "os/exec"
+	"path/filepath"
+	"sort"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/exec"
+	"sigs.k8s.io/yaml"
+)
+
+const kbldIDAnnotation = "kbld.k14s.io/id"
+
+// kbldImagesLock mirrors the subset of kbld's imgpkg lock
+// output needed to map source images to resolved digests.
+type kbldImagesLock struct {
+	Images []struct {
+		Image       string            `json:"image"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"images"`
+}
+
+// ResolvedImagesFromKbldLock parses kbld's imgpkg lock output and
+// returns a map of source image references to resolved digest references.
+func ResolvedImagesFromKbldLock(bs []byte) (map[string]string, error) {
+	var lock kbldImagesLock
+	err := yaml.Unmarshal(bs, &lock)
+	if err != nil {
+		return nil, fmt.Errorf("Parsing kbld lock output: %s", err)
+	}
+
+	images := map[string]string{}
+	for _, img := range lock.Images {
+		source := img.Annotations[kbldIDAnnotation]
+		if source == "" {
+			source = img.Image
+		}
+		images[source] = img.Image
+	}
+
+	return images, nil
+}
+
+func printResolvedImages(ui ui.UI, images map[string]string) {
+	ui.PrintLinef("Resolved images:")
+
+	var sources []string
+	for source := range images {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	for _, source := range sources {
+		ui.PrintLinef("- %s -> %s", source, images[source])
+	}
+}
+
+// kbldLockCmdRunner wraps another CmdRunner and asks kbld to write
+// its imgpkg lock output so resolved images can be shown afterwards.
+type kbldLockCmdRunner struct {
+	delegate     exec.CmdRunner
+	lockFilePath string
+}
+
+var _ exec.CmdRunner = kbldLockCmdRunner{}
+
+func (r kbldLockCmdRunner) Run(cmd *goexec.Cmd) error {
+	r.addLockOutputArg(cmd)
+	return r.delegate.Run(cmd)
+}
+
+func (r kbldLockCmdRunner) RunWithCancel(cmd *goexec.Cmd, cancelCh chan struct{}) error {
+	r.addLockOutputArg(cmd)
+	return r.delegate.RunWithCancel(cmd, cancelCh)
+}
+
+func (r kbldLockCmdRunner) addLockOutputArg(cmd *goexec.Cmd) {
+	if filepath.Base(cmd.Path) == "kbld" {
+		cmd.Args = append(cmd.Args, fmt.Sprintf("--imgpkg-lock-output=%s", r.lockFilePath))
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+)
+
+const stubbedKbldLock = `
+apiVersion: imgpkg.carvel.dev/v1alpha1
+kind: ImagesLock
+images:
+- image: index.docker.io/library/nginx@sha256:aaa
+  annotations:
+    kbld.k14s.io/id: nginx:1.21
+- image: registry.example.com/app@sha256:bbb
+`
+
+func TestResolvedImagesFromKbldLock(t *testing.T) {
+	images, err := ResolvedImagesFromKbldLock([]byte(stubbedKbldLock))
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]string{
+		"nginx:1.21":                          "index.docker.io/library/nginx@sha256:aaa",
+		"registry.example.com/app@sha256:bbb": "registry.example.com/app@sha256:bbb",
+	}, images)
+}
+
+func TestPrintResolvedImages(t *testing.T) {
+	images, err := ResolvedImagesFromKbldLock([]byte(stubbedKbldLock))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	printResolvedImages(ui.NewWriterUI(&out, &out, nil), images)
+
+	require.Contains(t, out.String(), "Resolved images:")
+	require.Contains(t, out.String(), "- nginx:1.21 -> index.docker.io/library/nginx@sha256:aaa")
+}
@@ -0,0 +1,72 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"fmt"
+	"strings"
+
+	cmdlocal "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/local"
+)
+
+// parseSecretValues parses --secret-value entries of the form
+// "<secret-name>/<key>=<value>". Values prefixed with @ are read from
+// the named file instead. Result maps secret name to key-value pairs.
+func parseSecretValues(entries []string, readFile func(string) ([]byte, error)) (map[string]map[string]string, error) {
+	result := map[string]map[string]string{}
+
+	for _, entry := range entries {
+		ref, value, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("Expected secret value '%s' to be in '<secret-name>/<key>=<value>' format", entry)
+		}
+
+		secretName, key, found := strings.Cut(ref, "/")
+		if !found || secretName == "" || key == "" {
+			return nil, fmt.Errorf("Expected secret value '%s' to be in '<secret-name>/<key>=<value>' format", entry)
+		}
+
+		if strings.HasPrefix(value, "@") {
+			bs, err := readFile(strings.TrimPrefix(value, "@"))
+			if err != nil {
+				return nil, fmt.Errorf("Reading secret value file for '%s': %s", ref, err)
+			}
+			value = string(bs)
+		}
+
+		if result[secretName] == nil {
+			result[secretName] = map[string]string{}
+		}
+		result[secretName][key] = value
+	}
+
+	return result, nil
+}
+
+// applySecretValues substitutes parsed secret values into the secrets
+// declared in the provided configuration files. Referencing a secret
+// that is not declared is an error since the value would silently be
+// dropped otherwise.
+func applySecretValues(configs *cmdlocal.Configs, values map[string]map[string]string) error {
+	for secretName, keyValues := range values {
+		found := false
+		for i, secret := range configs.Secrets {
+			if secret.Name != secretName {
+				continue
+			}
+			found = true
+			if configs.Secrets[i].StringData == nil {
+				configs.Secrets[i].StringData = map[string]string{}
+			}
+			for key, value := range keyValues {
+				configs.Secrets[i].StringData[key] = value
+				delete(configs.Secrets[i].Data, key)
+			}
+		}
+		if !found {
+			return fmt.Errorf("Expected secret '%s' to be defined in provided files", secretName)
+		}
+	}
+	return nil
+}
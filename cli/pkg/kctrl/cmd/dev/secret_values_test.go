@@ -0,0 +1,82 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	cmdlocal "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/local"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseSecretValues(t *testing.T) {
+	values, err := parseSecretValues([]string{
+		"creds/username=admin",
+		"creds/password=@pass-file",
+		"other/token=t0ken",
+	}, func(path string) ([]byte, error) {
+		require.Equal(t, "pass-file", path)
+		return []byte("s3cret"), nil
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]map[string]string{
+		"creds": {"username": "admin", "password": "s3cret"},
+		"other": {"token": "t0ken"},
+	}, values)
+}
+
+func TestParseSecretValuesRejectsMalformedEntries(t *testing.T) {
+	_, err := parseSecretValues([]string{"creds-username"}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'<secret-name>/<key>=<value>' format")
+
+	_, err = parseSecretValues([]string{"username=admin"}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'<secret-name>/<key>=<value>' format")
+}
+
+func TestParseSecretValuesFileReadError(t *testing.T) {
+	_, err := parseSecretValues([]string{"creds/password=@missing"}, func(string) ([]byte, error) {
+		return nil, fmt.Errorf("no such file")
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Reading secret value file for 'creds/password'")
+}
+
+func TestApplySecretValuesSubstitutesPlaceholders(t *testing.T) {
+	configs := cmdlocal.Configs{
+		Secrets: []corev1.Secret{{
+			ObjectMeta: metav1.ObjectMeta{Name: "creds"},
+			Data:       map[string][]byte{"password": []byte("placeholder")},
+			StringData: map[string]string{"username": "placeholder"},
+		}},
+	}
+
+	err := applySecretValues(&configs, map[string]map[string]string{
+		"creds": {"username": "admin", "password": "s3cret"},
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "admin", configs.Secrets[0].StringData["username"])
+	require.Equal(t, "s3cret", configs.Secrets[0].StringData["password"])
+	require.NotContains(t, configs.Secrets[0].Data, "password")
+}
+
+func TestApplySecretValuesErrsOnMissingSecret(t *testing.T) {
+	configs := cmdlocal.Configs{
+		Secrets: []corev1.Secret{{
+			ObjectMeta: metav1.ObjectMeta{Name: "creds"},
+		}},
+	}
+
+	err := applySecretValues(&configs, map[string]map[string]string{
+		"unknown": {"token": "t0ken"},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Expected secret 'unknown' to be defined in provided files")
+}
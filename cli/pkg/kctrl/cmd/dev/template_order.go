@@ -0,0 +1,71 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"fmt"
+
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+)
+
+// validateTemplateStageOrder inspects the order of template stages
+// and returns advisory warnings for combinations that commonly
+// produce confusing errors. It does not reject any ordering outright
+// since unusual but valid pipelines exist.
+func validateTemplateStageOrder(templates []kcv1alpha1.AppTemplate) []string {
+	var warnings []string
+
+	names := make([]string, 0, len(templates))
+	for _, tpl := range templates {
+		names = append(names, templateStageName(tpl))
+	}
+
+	seenRenderer := false
+	for i, name := range names {
+		switch name {
+		case "kbld":
+			if !seenRenderer && i == 0 && len(names) > 1 {
+				warnings = append(warnings, fmt.Sprintf(
+					"Template stage %d (kbld) runs before any templating stage; kbld resolves images in rendered manifests and is usually last", i+1))
+			}
+			if i < len(names)-1 && names[i+1] != "kbld" && names[i+1] != "sops" {
+				warnings = append(warnings, fmt.Sprintf(
+					"Template stage %d (kbld) is followed by %s; stages after kbld may produce image references that are never resolved to digests", i+1, names[i+1]))
+			}
+		case "helmTemplate":
+			if seenRenderer {
+				warnings = append(warnings, fmt.Sprintf(
+					"Template stage %d (helmTemplate) runs after another templating stage; helm template expects a chart directory, not rendered manifests", i+1))
+			}
+		}
+
+		switch name {
+		case "ytt", "helmTemplate", "kustomize", "jsonnet", "cue":
+			seenRenderer = true
+		}
+	}
+
+	return warnings
+}
+
+func templateStageName(tpl kcv1alpha1.AppTemplate) string {
+	switch {
+	case tpl.Ytt != nil:
+		return "ytt"
+	case tpl.Kbld != nil:
+		return "kbld"
+	case tpl.HelmTemplate != nil:
+		return "helmTemplate"
+	case tpl.Kustomize != nil:
+		return "kustomize"
+	case tpl.Jsonnet != nil:
+		return "jsonnet"
+	case tpl.Sops != nil:
+		return "sops"
+	case tpl.Cue != nil:
+		return "cue"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package dev
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+)
+
+func TestValidateTemplateStageOrderCommonPipelines(t *testing.T) {
+	require.Empty(t, validateTemplateStageOrder([]kcv1alpha1.AppTemplate{
+		{Ytt: &kcv1alpha1.AppTemplateYtt{}},
+		{Kbld: &kcv1alpha1.AppTemplateKbld{}},
+	}))
+
+	require.Empty(t, validateTemplateStageOrder([]kcv1alpha1.AppTemplate{
+		{HelmTemplate: &kcv1alpha1.AppTemplateHelmTemplate{}},
+		{Ytt: &kcv1alpha1.AppTemplateYtt{}},
+		{Kbld: &kcv1alpha1.AppTemplateKbld{}},
+	}))
+
+	require.Empty(t, validateTemplateStageOrder([]kcv1alpha1.AppTemplate{
+		{Kbld: &kcv1alpha1.AppTemplateKbld{}},
+	}))
+}
+
+func TestValidateTemplateStageOrderKbldFirst(t *testing.T) {
+	warnings := validateTemplateStageOrder([]kcv1alpha1.AppTemplate{
+		{Kbld: &kcv1alpha1.AppTemplateKbld{}},
+		{Ytt: &kcv1alpha1.AppTemplateYtt{}},
+	})
+
+	require.Len(t, warnings, 2)
+	require.Contains(t, warnings[0], "kbld")
+	require.Contains(t, warnings[0], "usually last")
+	require.Contains(t, warnings[1], "never resolved to digests")
+}
+
+func TestValidateTemplateStageOrderHelmAfterYtt(t *testing.T) {
+	warnings := validateTemplateStageOrder([]kcv1alpha1.AppTemplate{
+		{Ytt: &kcv1alpha1.AppTemplateYtt{}},
+		{HelmTemplate: &kcv1alpha1.AppTemplateHelmTemplate{}},
+	})
+
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0], "helm template expects a chart directory")
+}
+
+func TestValidateTemplateStageOrderKbldSandwich(t *testing.T) {
+	warnings := validateTemplateStageOrder([]kcv1alpha1.AppTemplate{
+		{Ytt: &kcv1alpha1.AppTemplateYtt{}},
+		{Kbld: &kcv1alpha1.AppTemplateKbld{}},
+		{Ytt: &kcv1alpha1.AppTemplateYtt{}},
+	})
+
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0], "followed by ytt")
+}
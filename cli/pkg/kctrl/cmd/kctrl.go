@@ -105,11 +105,16 @@ func NewKctrlCmd(o *KctrlOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Comm
 	appCmd.AddCommand(app.NewStatusCmd(app.NewStatusOptions(o.ui, o.depsFactory, o.logger, &o.UIFlags.Columns), flagsFactory))
 	appCmd.AddCommand(app.NewPauseCmd(app.NewPauseOptions(o.ui, o.depsFactory, o.logger), flagsFactory))
 	appCmd.AddCommand(app.NewKickCmd(app.NewKickOptions(o.ui, o.depsFactory, o.logger), flagsFactory))
+	appCmd.AddCommand(app.NewSetIntervalCmd(app.NewSetIntervalOptions(o.ui, o.depsFactory, o.logger), flagsFactory))
+	appCmd.AddCommand(app.NewLogsCmd(app.NewLogsOptions(o.ui, o.depsFactory, o.logger), flagsFactory))
 	appCmd.AddCommand(app.NewDeleteCmd(app.NewDeleteOptions(o.ui, o.depsFactory, o.logger), flagsFactory))
+	appCmd.AddCommand(app.NewExportCmd(app.NewExportOptions(o.ui, o.depsFactory, o.logger), flagsFactory))
 	appCmd.AddCommand(app.NewInitCmd(app.NewInitOptions(o.ui, o.depsFactory, o.logger)))
 	cmd.AddCommand(appCmd)
 
-	cmd.AddCommand(dev.NewCmd(dev.NewDevOptions(o.ui, o.depsFactory, o.logger), flagsFactory))
+	devCmd := dev.NewCmd(dev.NewDevOptions(o.ui, o.depsFactory, o.logger), flagsFactory)
+	devCmd.AddCommand(dev.NewLintCmd(dev.NewLintOptions(o.ui, o.depsFactory, o.logger, &o.UIFlags.Columns), flagsFactory))
+	cmd.AddCommand(devCmd)
 
 	configureGlobalFlags(o, cmd, flagsFactory, pkgOpts.PositionalArgs)
 
@@ -187,6 +192,7 @@ func AddPackageCommands(o *KctrlOptions, cmd *cobra.Command, flagsFactory cmdcor
 	pkgrepoCmd.AddCommand(pkgrepo.NewAddCmd(pkgrepo.NewAddOrUpdateOptions(o.ui, o.depsFactory, o.logger, opts), flagsFactory))
 	pkgrepoCmd.AddCommand(pkgrepo.NewUpdateCmd(pkgrepo.NewAddOrUpdateOptions(o.ui, o.depsFactory, o.logger, opts), flagsFactory))
 	pkgrepoCmd.AddCommand(pkgrepo.NewKickCmd(pkgrepo.NewKickOptions(o.ui, o.depsFactory, o.logger, opts), flagsFactory))
+	pkgrepoCmd.AddCommand(pkgrepo.NewDiffCmd(pkgrepo.NewDiffOptions(o.ui, o.depsFactory, o.logger, opts, &o.UIFlags.Columns), flagsFactory))
 	pkgrepoCmd.AddCommand(pkgreporel.NewReleaseCmd(pkgreporel.NewReleaseOptions(o.ui, o.depsFactory, o.logger)))
 
 	pkgiCmd := pkginst.NewCmd()
@@ -200,6 +206,7 @@ func AddPackageCommands(o *KctrlOptions, cmd *cobra.Command, flagsFactory cmdcor
 	pkgiCmd.AddCommand(pkginst.NewDeleteCmd(pkginst.NewDeleteOptions(o.ui, o.depsFactory, o.logger, opts), flagsFactory))
 	pkgiCmd.AddCommand(pkginst.NewPauseCmd(pkginst.NewPauseOrKickOptions(o.ui, o.depsFactory, o.logger, opts), flagsFactory))
 	pkgiCmd.AddCommand(pkginst.NewKickCmd(pkginst.NewPauseOrKickOptions(o.ui, o.depsFactory, o.logger, opts), flagsFactory))
+	pkgiCmd.AddCommand(pkginst.NewResumeCmd(pkginst.NewPauseOrKickOptions(o.ui, o.depsFactory, o.logger, opts), flagsFactory))
 	pkgiCmd.AddCommand(pkginst.NewStatusCmd(pkginst.NewStatusOptions(o.ui, o.depsFactory, o.logger, opts, &o.UIFlags.Columns), flagsFactory))
 
 	pkgaCmd := pkgavail.NewCmd()
@@ -107,6 +107,39 @@ func NewKickCmd(o *PauseOrKickOptions, flagsFactory cmdcore.FlagsFactory) *cobra
 	return cmd
 }
 
+func NewResumeCmd(o *PauseOrKickOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume",
+		Short: "Resume reconciliation of package install",
+		Args:  cobra.ExactArgs(1),
+		RunE:  func(_ *cobra.Command, args []string) error { return o.Resume(args) },
+		Example: cmdcore.Examples{
+			cmdcore.Example{"Resume reconciliation of package install",
+				[]string{"package", "installed", "resume", "-i", "sample-pkg-install"},
+			},
+		}.Description("-i", o.pkgCmdTreeOpts),
+		SilenceUsage: true,
+		Annotations: map[string]string{cmdapp.TTYByDefaultKey: "",
+			cmdcore.PackageManagementCommandsHelpGroup.Key: cmdcore.PackageManagementCommandsHelpGroup.Value},
+	}
+
+	o.NamespaceFlags.Set(cmd, flagsFactory)
+
+	if !o.pkgCmdTreeOpts.PositionalArgs {
+		cmd.Flags().StringVarP(&o.Name, "package-install", "i", "", "Set installed package name (required)")
+	} else {
+		cmd.Use = "resume INSTALLED_PACKAGE_NAME"
+	}
+
+	o.WaitFlags.Set(cmd, flagsFactory, &cmdcore.WaitFlagsOpts{
+		AllowDisableWait: true,
+		DefaultInterval:  2 * time.Second,
+		DefaultTimeout:   5 * time.Minute,
+	})
+
+	return cmd
+}
+
 func (o *PauseOrKickOptions) Pause(args []string) error {
 	if o.pkgCmdTreeOpts.PositionalArgs {
 		if len(args) > 0 {
@@ -190,6 +223,51 @@ func (o *PauseOrKickOptions) Kick(args []string) error {
 	return nil
 }
 
+func (o *PauseOrKickOptions) Resume(args []string) error {
+	if o.pkgCmdTreeOpts.PositionalArgs {
+		if len(args) > 0 {
+			o.Name = args[0]
+		}
+	}
+
+	if len(o.Name) == 0 {
+		return fmt.Errorf("Expected package install name to be non empty")
+	}
+
+	client, err := o.depsFactory.KappCtrlClient()
+	if err != nil {
+		return err
+	}
+
+	pkgi, err := client.PackagingV1alpha1().PackageInstalls(
+		o.NamespaceFlags.Name).Get(context.Background(), o.Name, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+
+	if !pkgi.Spec.Paused {
+		o.ui.PrintLinef("Package install '%s' in namespace '%s' is not paused", o.Name, o.NamespaceFlags.Name)
+		return nil
+	}
+
+	o.ui.PrintLinef("Resuming reconciliation for package install '%s' in namespace '%s'", o.Name, o.NamespaceFlags.Name)
+	err = o.ui.AskForConfirmation()
+	if err != nil {
+		return err
+	}
+
+	err = o.unpause(client)
+	if err != nil {
+		return err
+	}
+
+	if o.WaitFlags.Enabled {
+		return o.waitForPackageInstallReconciliation(client)
+	}
+
+	return nil
+}
+
 func (o *PauseOrKickOptions) pause(client kcclient.Interface) error {
 	o.statusUI.PrintMessagef("Pausing reconciliation for package installation '%s' in namespace '%s'", o.Name, o.NamespaceFlags.Name)
 	pausePatch := []map[string]interface{}{
@@ -0,0 +1,67 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package installed
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	"github.com/stretchr/testify/require"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	kcpkgv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
+	fakekc "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func pauseOrKickOptions(name string) *PauseOrKickOptions {
+	opts := NewPauseOrKickOptions(ui.NewNoopUI(), nil, nil, cmdcore.PackageCommandTreeOpts{})
+	opts.Name = name
+	opts.NamespaceFlags = cmdcore.NamespaceFlags{Name: "default"}
+	return opts
+}
+
+func testPackageInstall(paused bool) *kcpkgv1alpha1.PackageInstall {
+	return &kcpkgv1alpha1.PackageInstall{
+		ObjectMeta: metav1.ObjectMeta{Name: "sample-pkg-install", Namespace: "default"},
+		Spec:       kcpkgv1alpha1.PackageInstallSpec{Paused: paused},
+	}
+}
+
+func TestPausePatchesPackageInstallSpec(t *testing.T) {
+	client := fakekc.NewSimpleClientset(testPackageInstall(false))
+
+	err := pauseOrKickOptions("sample-pkg-install").pause(client)
+	require.NoError(t, err)
+
+	pkgi, err := client.PackagingV1alpha1().PackageInstalls("default").Get(context.Background(), "sample-pkg-install", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, pkgi.Spec.Paused)
+}
+
+func TestUnpausePatchesPackageInstallSpec(t *testing.T) {
+	client := fakekc.NewSimpleClientset(testPackageInstall(true))
+
+	err := pauseOrKickOptions("sample-pkg-install").unpause(client)
+	require.NoError(t, err)
+
+	pkgi, err := client.PackagingV1alpha1().PackageInstalls("default").Get(context.Background(), "sample-pkg-install", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.False(t, pkgi.Spec.Paused)
+}
+
+func TestKickTogglesPauseAndBack(t *testing.T) {
+	client := fakekc.NewSimpleClientset(testPackageInstall(false))
+	opts := pauseOrKickOptions("sample-pkg-install")
+
+	require.NoError(t, opts.pause(client))
+	pkgi, err := client.PackagingV1alpha1().PackageInstalls("default").Get(context.Background(), "sample-pkg-install", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, pkgi.Spec.Paused)
+
+	require.NoError(t, opts.unpause(client))
+	pkgi, err = client.PackagingV1alpha1().PackageInstalls("default").Get(context.Background(), "sample-pkg-install", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.False(t, pkgi.Spec.Paused)
+}
@@ -0,0 +1,169 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/cppforlife/go-cli-ui/ui"
+	uitable "github.com/cppforlife/go-cli-ui/ui/table"
+	"github.com/spf13/cobra"
+	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
+	cmdlocal "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/local"
+	"github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/logger"
+	dpv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type DiffOptions struct {
+	ui          ui.UI
+	depsFactory cmdcore.DepsFactory
+	logger      logger.Logger
+
+	NamespaceFlags cmdcore.NamespaceFlags
+	Files          []string
+
+	pkgCmdTreeOpts cmdcore.PackageCommandTreeOpts
+
+	columns *[]string
+}
+
+func NewDiffOptions(ui ui.UI, depsFactory cmdcore.DepsFactory, logger logger.Logger, pkgCmdTreeOpts cmdcore.PackageCommandTreeOpts, columns *[]string) *DiffOptions {
+	return &DiffOptions{ui: ui, depsFactory: depsFactory, logger: logger, pkgCmdTreeOpts: pkgCmdTreeOpts, columns: columns}
+}
+
+func NewDiffCmd(o *DiffOptions, flagsFactory cmdcore.FlagsFactory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Diff new repository contents against packages currently available in a namespace",
+		RunE:  func(_ *cobra.Command, _ []string) error { return o.Run() },
+		Example: cmdcore.Examples{
+			cmdcore.Example{"Diff new repository contents against the cluster",
+				[]string{"package", "repository", "diff", "-f", "packages/"},
+			},
+		}.Description("", o.pkgCmdTreeOpts),
+		SilenceUsage: true,
+		Annotations: map[string]string{"table": "",
+			cmdcore.PackageManagementCommandsHelpGroup.Key: cmdcore.PackageManagementCommandsHelpGroup.Value},
+	}
+	o.NamespaceFlags.SetWithPackageCommandTreeOpts(cmd, flagsFactory, o.pkgCmdTreeOpts)
+	cmd.Flags().StringSliceVarP(&o.Files, "file", "f", nil, "Set file or directory containing new repository Package and PackageMetadata CRs (required)")
+	return cmd
+}
+
+func (o *DiffOptions) Run() error {
+	if len(o.Files) == 0 {
+		return fmt.Errorf("Expected repository content files to be specified with -f")
+	}
+
+	configs, err := cmdlocal.NewConfigFromFiles(o.Files)
+	if err != nil {
+		return fmt.Errorf("Reading repository content files: %s", err)
+	}
+
+	client, err := o.depsFactory.PackageClient()
+	if err != nil {
+		return err
+	}
+
+	pkgList, err := client.DataV1alpha1().Packages(
+		o.NamespaceFlags.Name).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	pkgmList, err := client.DataV1alpha1().PackageMetadatas(
+		o.NamespaceFlags.Name).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	entries := diffRepoContents(pkgList.Items, configs.Pkgs, pkgmList.Items, configs.PkgMetadatas)
+
+	table := uitable.Table{
+		Title: fmt.Sprintf("Changes against packages in namespace '%s'", o.NamespaceFlags.Name),
+
+		Header: []uitable.Header{
+			uitable.NewHeader("Kind"),
+			uitable.NewHeader("Name"),
+			uitable.NewHeader("Change"),
+		},
+
+		SortBy: []uitable.ColumnSort{
+			{Column: 0, Asc: true},
+			{Column: 1, Asc: true},
+		},
+	}
+
+	for _, entry := range entries {
+		table.Rows = append(table.Rows, []uitable.Value{
+			uitable.NewValueString(entry.Kind),
+			uitable.NewValueString(entry.Name),
+			uitable.NewValueString(entry.Change),
+		})
+	}
+
+	return cmdcore.PrintTable(o.ui, table, o.columns)
+}
+
+type repoDiffEntry struct {
+	Kind   string
+	Name   string
+	Change string
+}
+
+// diffRepoContents compares the Package and PackageMetadata set of a
+// new repository version against the currently available set, keyed
+// by resource name (which encodes refName and version for Packages).
+func diffRepoContents(existingPkgs []dpv1alpha1.Package, newPkgs []dpv1alpha1.Package,
+	existingMetas []dpv1alpha1.PackageMetadata, newMetas []dpv1alpha1.PackageMetadata) []repoDiffEntry {
+
+	var entries []repoDiffEntry
+
+	existingPkgSpecs := map[string]dpv1alpha1.PackageSpec{}
+	for _, pkg := range existingPkgs {
+		existingPkgSpecs[pkg.Name] = pkg.Spec
+	}
+	newPkgNames := map[string]struct{}{}
+	for _, pkg := range newPkgs {
+		newPkgNames[pkg.Name] = struct{}{}
+		existingSpec, found := existingPkgSpecs[pkg.Name]
+		switch {
+		case !found:
+			entries = append(entries, repoDiffEntry{Kind: "Package", Name: pkg.Name, Change: "added"})
+		case !reflect.DeepEqual(existingSpec, pkg.Spec):
+			entries = append(entries, repoDiffEntry{Kind: "Package", Name: pkg.Name, Change: "updated"})
+		}
+	}
+	for _, pkg := range existingPkgs {
+		if _, found := newPkgNames[pkg.Name]; !found {
+			entries = append(entries, repoDiffEntry{Kind: "Package", Name: pkg.Name, Change: "removed"})
+		}
+	}
+
+	existingMetaSpecs := map[string]dpv1alpha1.PackageMetadataSpec{}
+	for _, meta := range existingMetas {
+		existingMetaSpecs[meta.Name] = meta.Spec
+	}
+	newMetaNames := map[string]struct{}{}
+	for _, meta := range newMetas {
+		newMetaNames[meta.Name] = struct{}{}
+		existingSpec, found := existingMetaSpecs[meta.Name]
+		switch {
+		case !found:
+			entries = append(entries, repoDiffEntry{Kind: "PackageMetadata", Name: meta.Name, Change: "added"})
+		case !reflect.DeepEqual(existingSpec, meta.Spec):
+			entries = append(entries, repoDiffEntry{Kind: "PackageMetadata", Name: meta.Name, Change: "updated"})
+		}
+	}
+	for _, meta := range existingMetas {
+		if _, found := newMetaNames[meta.Name]; !found {
+			entries = append(entries, repoDiffEntry{Kind: "PackageMetadata", Name: meta.Name, Change: "removed"})
+		}
+	}
+
+	return entries
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dpv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func repoPkg(name string, version string) dpv1alpha1.Package {
+	return dpv1alpha1.Package{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "." + version},
+		Spec:       dpv1alpha1.PackageSpec{RefName: name, Version: version},
+	}
+}
+
+func repoPkgMeta(name string, displayName string) dpv1alpha1.PackageMetadata {
+	return dpv1alpha1.PackageMetadata{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       dpv1alpha1.PackageMetadataSpec{DisplayName: displayName},
+	}
+}
+
+func TestDiffRepoContentsAddedRemovedUpdated(t *testing.T) {
+	existingPkgs := []dpv1alpha1.Package{
+		repoPkg("pkg.test.carvel.dev", "1.0.0"),
+		repoPkg("pkg.test.carvel.dev", "1.1.0"),
+	}
+	updatedPkg := repoPkg("pkg.test.carvel.dev", "1.1.0")
+	updatedPkg.Spec.ReleasedAt = metav1.Now()
+	newPkgs := []dpv1alpha1.Package{
+		updatedPkg,
+		repoPkg("pkg.test.carvel.dev", "1.2.0"),
+	}
+
+	existingMetas := []dpv1alpha1.PackageMetadata{
+		repoPkgMeta("pkg.test.carvel.dev", "Test Package"),
+		repoPkgMeta("legacy.test.carvel.dev", "Legacy Package"),
+	}
+	newMetas := []dpv1alpha1.PackageMetadata{
+		repoPkgMeta("pkg.test.carvel.dev", "Test Package"),
+	}
+
+	entries := diffRepoContents(existingPkgs, newPkgs, existingMetas, newMetas)
+
+	require.Equal(t, []repoDiffEntry{
+		{Kind: "Package", Name: "pkg.test.carvel.dev.1.1.0", Change: "updated"},
+		{Kind: "Package", Name: "pkg.test.carvel.dev.1.2.0", Change: "added"},
+		{Kind: "Package", Name: "pkg.test.carvel.dev.1.0.0", Change: "removed"},
+		{Kind: "PackageMetadata", Name: "legacy.test.carvel.dev", Change: "removed"},
+	}, entries)
+}
+
+func TestDiffRepoContentsNoChanges(t *testing.T) {
+	pkgs := []dpv1alpha1.Package{repoPkg("pkg.test.carvel.dev", "1.0.0")}
+	metas := []dpv1alpha1.PackageMetadata{repoPkgMeta("pkg.test.carvel.dev", "Test Package")}
+
+	require.Empty(t, diffRepoContents(pkgs, pkgs, metas, metas))
+}
@@ -94,7 +94,7 @@ func (o *RepoTailer) printTillCurrent(status kcv1alpha1.AppStatus) error {
 		return nil
 	}
 
-	completed, deployOutput, err := cmdapp.NewAppStatusDiff(kcv1alpha1.AppStatus{}, status, o.statusUI, o.lastSeenDeployStdout).PrintUpdate()
+	completed, deployOutput, err := cmdapp.NewAppStatusDiff(kcv1alpha1.AppStatus{}, status, o.statusUI, cmdapp.AppStatusDiffOpts{LastSeenDeployStdout: o.lastSeenDeployStdout}).PrintUpdate()
 	o.lastSeenDeployStdout = deployOutput
 	if err != nil {
 		return fmt.Errorf("Reconciling package repository: %s", err)
@@ -119,7 +119,7 @@ func (o *RepoTailer) udpateEventHandler(oldObj interface{}, newObj interface{})
 	mappedNewStatus := o.appStatusFromPkgrStatus(newRepo.Status)
 
 	// o.printUpdate(oldApp.Status, newApp.Status)
-	stopWatch, deployOutput, err := cmdapp.NewAppStatusDiff(mappedOldStatus, mappedNewStatus, o.statusUI, o.lastSeenDeployStdout).PrintUpdate()
+	stopWatch, deployOutput, err := cmdapp.NewAppStatusDiff(mappedOldStatus, mappedNewStatus, o.statusUI, cmdapp.AppStatusDiffOpts{LastSeenDeployStdout: o.lastSeenDeployStdout}).PrintUpdate()
 	o.lastSeenDeployStdout = deployOutput
 	o.watchError = err
 	if stopWatch {
@@ -0,0 +1,34 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package local
+
+import (
+	"crypto/x509"
+	"fmt"
+	"strings"
+)
+
+// appendCACerts concatenates additional PEM CA bundles after the cluster
+// CA so kapp trusts both the cluster CA and any custom CAs (e.g. a
+// corporate CA in front of the API server). The cluster CA stays first
+// so its trust is unaffected by extra bundles.
+func appendCACerts(clusterCA string, additionalCAs []string, readFile func(string) ([]byte, error)) (string, error) {
+	bundle := clusterCA
+	for _, path := range additionalCAs {
+		pemBs, err := readFile(path)
+		if err != nil {
+			return "", fmt.Errorf("Reading CA cert file '%s': %s", path, err)
+		}
+
+		if !x509.NewCertPool().AppendCertsFromPEM(pemBs) {
+			return "", fmt.Errorf("Expected CA cert file '%s' to contain at least one PEM certificate", path)
+		}
+
+		if bundle != "" && !strings.HasSuffix(bundle, "\n") {
+			bundle += "\n"
+		}
+		bundle += string(pemBs)
+	}
+	return bundle, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package local
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func selfSignedCAPEM(t *testing.T, commonName string) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	derBs, err := x509.CreateCertificate(rand.Reader, &tmpl, &tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: derBs})
+}
+
+func TestAppendCACertsConcatenatesAfterClusterCA(t *testing.T) {
+	clusterCA := string(selfSignedCAPEM(t, "cluster-ca"))
+	corporateCA := string(selfSignedCAPEM(t, "corporate-ca"))
+
+	readFile := func(path string) ([]byte, error) {
+		require.Equal(t, "corporate-ca.pem", path)
+		return []byte(corporateCA), nil
+	}
+
+	bundle, err := appendCACerts(clusterCA, []string{"corporate-ca.pem"}, readFile)
+	require.NoError(t, err)
+
+	// Cluster CA stays first so its trust is unaffected
+	require.True(t, strings.HasPrefix(bundle, clusterCA))
+	require.Contains(t, bundle, corporateCA)
+	assert.Less(t, strings.Index(bundle, clusterCA), strings.Index(bundle, corporateCA))
+
+	// The resulting bundle stays a valid PEM bundle with both certs
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM([]byte(bundle)))
+	assert.Len(t, pool.Subjects(), 2)
+}
+
+func TestAppendCACertsWithoutAdditionalCAsIsUnchanged(t *testing.T) {
+	clusterCA := string(selfSignedCAPEM(t, "cluster-ca"))
+
+	bundle, err := appendCACerts(clusterCA, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, clusterCA, bundle)
+}
+
+func TestAppendCACertsRejectsNonPEMFile(t *testing.T) {
+	readFile := func(string) ([]byte, error) { return []byte("junk"), nil }
+
+	_, err := appendCACerts("", []string{"not-a-cert.pem"}, readFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "at least one PEM certificate")
+}
+
+func TestAppendCACertsSurfacesReadErrors(t *testing.T) {
+	readFile := func(path string) ([]byte, error) { return nil, fmt.Errorf("no such file") }
+
+	_, err := appendCACerts("", []string{"missing.pem"}, readFile)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Reading CA cert file 'missing.pem'")
+}
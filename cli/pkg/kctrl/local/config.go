@@ -15,6 +15,7 @@ import (
 	pkgv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/packaging/v1alpha1"
 	dpv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apiserver/apis/datapackaging/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	kyaml "k8s.io/apimachinery/pkg/util/yaml"
 	"sigs.k8s.io/yaml"
@@ -29,36 +30,94 @@ type Configs struct {
 	ConfigMaps   []corev1.ConfigMap
 }
 
-func (c *Configs) ApplyNamespace(ns string) {
+// NamespaceOverride records a resource whose namespace was rewritten
+// while applying the effective namespace.
+type NamespaceOverride struct {
+	Resource  string
+	Namespace string
+}
+
+// ApplyNamespace defaults the namespace of resources that do not
+// specify one, returning which resources were rewritten and to what.
+func (c *Configs) ApplyNamespace(ns string) []NamespaceOverride {
+	var overrides []NamespaceOverride
+
 	// Prefer namespace specified in the configuration
 	for i, res := range c.Apps {
 		if len(res.Namespace) == 0 {
 			res.Namespace = ns
 			c.Apps[i] = res
+			overrides = append(overrides, NamespaceOverride{fmt.Sprintf("App/%s", res.Name), ns})
 		}
 	}
 	for i, res := range c.PkgInstalls {
 		if len(res.Namespace) == 0 {
 			res.Namespace = ns
 			c.PkgInstalls[i] = res
+			overrides = append(overrides, NamespaceOverride{fmt.Sprintf("PackageInstall/%s", res.Name), ns})
 		}
 	}
 	for i, res := range c.Pkgs {
 		if len(res.Namespace) == 0 {
 			res.Namespace = ns
 			c.Pkgs[i] = res
+			overrides = append(overrides, NamespaceOverride{fmt.Sprintf("Package/%s", res.Name), ns})
 		}
 	}
 	for i, res := range c.Secrets {
 		if len(res.Namespace) == 0 {
 			res.Namespace = ns
 			c.Secrets[i] = res
+			overrides = append(overrides, NamespaceOverride{fmt.Sprintf("Secret/%s", res.Name), ns})
 		}
 	}
 	for i, res := range c.ConfigMaps {
 		if len(res.Namespace) == 0 {
 			res.Namespace = ns
 			c.ConfigMaps[i] = res
+			overrides = append(overrides, NamespaceOverride{fmt.Sprintf("ConfigMap/%s", res.Name), ns})
+		}
+	}
+
+	return overrides
+}
+
+// ApplyNamePrefix prefixes App and PackageInstall names (e.g. to avoid
+// collisions when multiple developers share a namespace), updating
+// owner references on accompanying Secrets and ConfigMaps so they keep
+// pointing at the renamed resources.
+func (c *Configs) ApplyNamePrefix(prefix string) {
+	if prefix == "" {
+		return
+	}
+
+	renamed := map[string]struct{}{}
+
+	for i, res := range c.Apps {
+		renamed[fmt.Sprintf("App/%s", res.Name)] = struct{}{}
+		res.Name = prefix + res.Name
+		c.Apps[i] = res
+	}
+	for i, res := range c.PkgInstalls {
+		renamed[fmt.Sprintf("PackageInstall/%s", res.Name)] = struct{}{}
+		res.Name = prefix + res.Name
+		c.PkgInstalls[i] = res
+	}
+
+	for i := range c.Secrets {
+		prefixOwnerRefs(c.Secrets[i].OwnerReferences, prefix, renamed)
+	}
+	for i := range c.ConfigMaps {
+		prefixOwnerRefs(c.ConfigMaps[i].OwnerReferences, prefix, renamed)
+	}
+}
+
+// prefixOwnerRefs renames owner references that point at resources
+// renamed by ApplyNamePrefix.
+func prefixOwnerRefs(refs []metav1.OwnerReference, prefix string, renamed map[string]struct{}) {
+	for i, ref := range refs {
+		if _, found := renamed[fmt.Sprintf("%s/%s", ref.Kind, ref.Name)]; found {
+			refs[i].Name = prefix + ref.Name
 		}
 	}
 }
@@ -145,8 +204,14 @@ func NewConfigFromFiles(paths []string) (Configs, error) {
 	if len(configs.Apps) == 0 && len(configs.PkgInstalls) == 0 {
 		return configs, fmt.Errorf("Expected to find at least one App or PackageInstall, but found none")
 	}
-	if len(configs.Apps) > 1 || len(configs.PkgInstalls) > 1 {
-		return configs, fmt.Errorf("Expected to find exactly one App or PackageInstall, but found multiple")
+	if len(configs.PkgInstalls) > 1 {
+		return configs, fmt.Errorf("Expected to find exactly one PackageInstall, but found multiple")
+	}
+	if len(configs.Apps) > 1 && len(configs.PkgInstalls) > 0 {
+		return configs, fmt.Errorf("Expected to find either Apps or a PackageInstall, but found both")
+	}
+	if len(configs.Apps) > 1 && len(configs.Pkgs) > 0 {
+		return configs, fmt.Errorf("Expected to find exactly one App when Packages are provided, but found multiple")
 	}
 
 	return configs, nil
@@ -0,0 +1,88 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package local
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestApplyNamespaceReportsOverriddenResources(t *testing.T) {
+	configs := Configs{
+		Apps: []kcv1alpha1.App{
+			{ObjectMeta: metav1.ObjectMeta{Name: "app-without-ns"}},
+			{ObjectMeta: metav1.ObjectMeta{Name: "app-with-ns", Namespace: "existing-ns"}},
+		},
+		Secrets: []corev1.Secret{
+			{ObjectMeta: metav1.ObjectMeta{Name: "secret-without-ns"}},
+		},
+	}
+
+	overrides := configs.ApplyNamespace("default")
+
+	require.Equal(t, []NamespaceOverride{
+		{Resource: "App/app-without-ns", Namespace: "default"},
+		{Resource: "Secret/secret-without-ns", Namespace: "default"},
+	}, overrides)
+
+	require.Equal(t, "default", configs.Apps[0].Namespace)
+	require.Equal(t, "existing-ns", configs.Apps[1].Namespace,
+		"Expected namespace specified in configuration to be preferred")
+}
+
+func TestApplyNamePrefixUpdatesNamesAndOwnerRefs(t *testing.T) {
+	configs := Configs{
+		Apps: []kcv1alpha1.App{
+			{ObjectMeta: metav1.ObjectMeta{Name: "simple-app"}},
+		},
+		Secrets: []corev1.Secret{
+			{ObjectMeta: metav1.ObjectMeta{Name: "simple-app-values", OwnerReferences: []metav1.OwnerReference{
+				{Kind: "App", Name: "simple-app"},
+				{Kind: "App", Name: "unrelated-app"},
+			}}},
+		},
+		ConfigMaps: []corev1.ConfigMap{
+			{ObjectMeta: metav1.ObjectMeta{Name: "simple-app-config", OwnerReferences: []metav1.OwnerReference{
+				{Kind: "App", Name: "simple-app"},
+			}}},
+		},
+	}
+
+	configs.ApplyNamePrefix("dev-alice-")
+
+	require.Equal(t, "dev-alice-simple-app", configs.Apps[0].Name)
+	require.Equal(t, "dev-alice-simple-app", configs.Secrets[0].OwnerReferences[0].Name,
+		"Expected owner ref pointing at renamed App to be renamed too")
+	require.Equal(t, "unrelated-app", configs.Secrets[0].OwnerReferences[1].Name,
+		"Expected owner refs to other resources to be untouched")
+	require.Equal(t, "dev-alice-simple-app", configs.ConfigMaps[0].OwnerReferences[0].Name)
+	require.Equal(t, "simple-app-values", configs.Secrets[0].Name,
+		"Expected secret names themselves to be untouched")
+}
+
+func TestApplyNamePrefixEmptyPrefixIsNoop(t *testing.T) {
+	configs := Configs{
+		Apps: []kcv1alpha1.App{
+			{ObjectMeta: metav1.ObjectMeta{Name: "simple-app"}},
+		},
+	}
+
+	configs.ApplyNamePrefix("")
+
+	require.Equal(t, "simple-app", configs.Apps[0].Name)
+}
+
+func TestApplyNamespaceNoOverridesWhenAllSet(t *testing.T) {
+	configs := Configs{
+		Apps: []kcv1alpha1.App{
+			{ObjectMeta: metav1.ObjectMeta{Name: "app-with-ns", Namespace: "existing-ns"}},
+		},
+	}
+
+	require.Empty(t, configs.ApplyNamespace("default"))
+}
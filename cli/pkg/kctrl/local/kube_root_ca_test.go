@@ -0,0 +1,48 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package local
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+func TestFetchKubeRootCARetriesTransientErrors(t *testing.T) {
+	attempts := 0
+	getConfigMap := func(context.Context) (*corev1.ConfigMap, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, fmt.Errorf("connection refused")
+		}
+		return &corev1.ConfigMap{Data: map[string]string{"ca.crt": "ca-pem-data"}}, nil
+	}
+
+	caData, err := fetchKubeRootCA(getConfigMap, testBackoff())
+	require.NoError(t, err)
+	require.Equal(t, "ca-pem-data", caData)
+	require.Equal(t, 2, attempts)
+}
+
+func TestFetchKubeRootCAReturnsLastErrorWhenExhausted(t *testing.T) {
+	attempts := 0
+	getConfigMap := func(context.Context) (*corev1.ConfigMap, error) {
+		attempts++
+		return nil, fmt.Errorf("connection refused")
+	}
+
+	_, err := fetchKubeRootCA(getConfigMap, testBackoff())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Fetching kube-root-ca.crt: connection refused")
+	require.Equal(t, 3, attempts)
+}
+
+func testBackoff() wait.Backoff {
+	return wait.Backoff{Duration: time.Millisecond, Factor: 2, Steps: 3}
+}
@@ -0,0 +1,77 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package local
+
+import (
+	"fmt"
+	"strings"
+
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+)
+
+// libraryValuesInlinePaths renders library data values given as
+// "<library>@<key>=<value>" entries and "<library>@<path>" file entries
+// into ytt data values files annotated with the library ref, keyed by a
+// generated file name.
+func libraryValuesInlinePaths(values []string, valueFiles []string, readFile func(string) ([]byte, error)) (map[string]string, error) {
+	paths := map[string]string{}
+
+	for i, entry := range values {
+		library, keyValue, found := strings.Cut(entry, "@")
+		if !found || library == "" {
+			return nil, fmt.Errorf("Expected library data value '%s' to be in '<library>@<key>=<value>' format", entry)
+		}
+		key, value, found := strings.Cut(keyValue, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("Expected library data value '%s' to be in '<library>@<key>=<value>' format", entry)
+		}
+
+		name := fmt.Sprintf("kctrl-library-values-%d.yml", i)
+		paths[name] = libraryValuesFile(library, fmt.Sprintf("%s: %s\n", key, value))
+	}
+
+	for i, entry := range valueFiles {
+		library, filePath, found := strings.Cut(entry, "@")
+		if !found || library == "" || filePath == "" {
+			return nil, fmt.Errorf("Expected library data value file '%s' to be in '<library>@<path>' format", entry)
+		}
+
+		bs, err := readFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("Reading library data value file '%s': %s", filePath, err)
+		}
+
+		name := fmt.Sprintf("kctrl-library-values-file-%d.yml", i)
+		paths[name] = libraryValuesFile(library, strings.TrimPrefix(string(bs), "---\n"))
+	}
+
+	return paths, nil
+}
+
+func libraryValuesFile(library string, values string) string {
+	return fmt.Sprintf("#@library/ref \"@%s\"\n#@data/values\n---\n%s", library, values)
+}
+
+// applyLibraryValues adds the given data values files to every ytt
+// template stage of the app so they are forwarded to ytt invocations.
+func applyLibraryValues(app *kcv1alpha1.App, paths map[string]string) {
+	for i, template := range app.Spec.Template {
+		if template.Ytt == nil {
+			continue
+		}
+		if app.Spec.Template[i].Ytt.Inline == nil {
+			app.Spec.Template[i].Ytt.Inline = &kcv1alpha1.AppFetchInline{}
+		}
+		if app.Spec.Template[i].Ytt.Inline.Paths == nil {
+			app.Spec.Template[i].Ytt.Inline.Paths = map[string]string{}
+		}
+		for name, content := range paths {
+			app.Spec.Template[i].Ytt.Inline.Paths[name] = content
+			// Explicit path lists need the generated files added to be seen
+			if len(app.Spec.Template[i].Ytt.Paths) > 0 {
+				app.Spec.Template[i].Ytt.Paths = append(app.Spec.Template[i].Ytt.Paths, name)
+			}
+		}
+	}
+}
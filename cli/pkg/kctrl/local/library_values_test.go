@@ -0,0 +1,65 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package local
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+)
+
+func TestLibraryValuesInlinePaths(t *testing.T) {
+	paths, err := libraryValuesInlinePaths(
+		[]string{"helpers@replicas=3"},
+		[]string{"helpers@values.yml"},
+		func(path string) ([]byte, error) {
+			require.Equal(t, "values.yml", path)
+			return []byte("---\nname: injected\n"), nil
+		})
+	require.NoError(t, err)
+	require.Len(t, paths, 2)
+
+	require.Equal(t, "#@library/ref \"@helpers\"\n#@data/values\n---\nreplicas: 3\n",
+		paths["kctrl-library-values-0.yml"])
+	require.Equal(t, "#@library/ref \"@helpers\"\n#@data/values\n---\nname: injected\n",
+		paths["kctrl-library-values-file-0.yml"])
+}
+
+func TestLibraryValuesInlinePathsRejectsMalformedEntries(t *testing.T) {
+	_, err := libraryValuesInlinePaths([]string{"replicas=3"}, nil, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'<library>@<key>=<value>' format")
+
+	_, err = libraryValuesInlinePaths(nil, []string{"values.yml"}, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "'<library>@<path>' format")
+}
+
+func TestLibraryValuesInlinePathsFileReadError(t *testing.T) {
+	_, err := libraryValuesInlinePaths(nil, []string{"helpers@missing.yml"}, func(string) ([]byte, error) {
+		return nil, fmt.Errorf("no such file")
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Reading library data value file 'missing.yml'")
+}
+
+func TestApplyLibraryValuesForwardsToYttStages(t *testing.T) {
+	app := devApp("app1")
+	app.Spec.Template = append(app.Spec.Template, kcv1alpha1.AppTemplate{
+		Ytt: &kcv1alpha1.AppTemplateYtt{Paths: []string{"config"}},
+	})
+
+	applyLibraryValues(&app, map[string]string{"kctrl-library-values-0.yml": "values"})
+
+	for _, template := range app.Spec.Template {
+		require.NotNil(t, template.Ytt.Inline)
+		require.Equal(t, "values", template.Ytt.Inline.Paths["kctrl-library-values-0.yml"])
+	}
+
+	// Explicit path lists gain the generated file, implicit ones stay implicit
+	require.Empty(t, app.Spec.Template[0].Ytt.Paths)
+	require.Equal(t, []string{"config", "kctrl-library-values-0.yml"}, app.Spec.Template[1].Ytt.Paths)
+}
@@ -325,6 +325,10 @@ func (*MinCoreV1Client) ResourceQuotas(namespace string) corev1.ResourceQuotaInt
 	return nil
 }
 func (c *MinCoreV1Client) Secrets(namespace string) corev1.SecretInterface {
+	if c.client == nil {
+		// To handle scenarios where only locally provided secrets exist
+		return &Secrets{namespace, nil, c.localSecrets}
+	}
 	return &Secrets{namespace, c.client.Secrets(namespace), c.localSecrets}
 }
 func (*MinCoreV1Client) Services(namespace string) corev1.ServiceInterface {
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	gourl "net/url"
 	"os"
+	"sync"
 	"time"
 
 	cmdcore "github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/cmd/core"
@@ -25,9 +26,12 @@ import (
 	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/packageinstall"
 	"github.com/vmware-tanzu/carvel-kapp-controller/pkg/reftracker"
 	vendirconf "github.com/vmware-tanzu/carvel-vendir/pkg/vendir/config"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -53,22 +57,51 @@ type ReconcileOpts struct {
 	Debug           bool
 	DeployResources bool
 
+	// Concurrency bounds how many independent Apps are reconciled in
+	// parallel. Apps are reconciled in file order when it is 0 or 1;
+	// ordering between Apps is not guaranteed when it is higher.
+	Concurrency int
+
+	// Library data values ("<library>@<key>=<value>" entries and
+	// "<library>@<path>" file entries) forwarded to every ytt template stage
+	LibraryDataValues     []string
+	LibraryDataValueFiles []string
+
+	// DstCACertFiles lists additional CA bundle files (PEM) trusted
+	// alongside the cluster CA when kapp connects to the cluster
+	DstCACertFiles []string
+
 	BeforeAppReconcile func(kcv1alpha1.App, *fakekc.Clientset) error
 	AfterAppReconcile  func(kcv1alpha1.App, *fakekc.Clientset) error
 }
 
 func (o *Reconciler) Reconcile(configs Configs, opts ReconcileOpts) error {
 	var objs []runtime.Object
-	var appRes kcv1alpha1.App
+	var appResources []kcv1alpha1.App
 	var primaryAnns map[string]string
 
+	libraryPaths, err := libraryValuesInlinePaths(opts.LibraryDataValues, opts.LibraryDataValueFiles, os.ReadFile)
+	if err != nil {
+		return fmt.Errorf("Building library data values: %s", err)
+	}
+
+	// Local vendir adjustments are driven by the primary (first) app's
+	// annotations, regardless of how many apps are reconciled
 	if len(configs.Apps) > 0 {
-		appRes = configs.Apps[0]
-		primaryAnns = appRes.Annotations
+		primaryAnns = configs.Apps[0].Annotations
+	}
+
+	for _, appRes := range configs.Apps {
 		if opts.Delete {
 			appRes.DeletionTimestamp = &metav1.Time{time.Now()}
 		}
-		objs = append(objs, &appRes)
+		if len(libraryPaths) > 0 {
+			applyLibraryValues(&appRes, libraryPaths)
+		}
+		appResources = append(appResources, appRes)
+	}
+	for i := range appResources {
+		objs = append(objs, &appResources[i])
 	}
 
 	if len(configs.PkgInstalls) > 0 {
@@ -81,8 +114,8 @@ func (o *Reconciler) Reconcile(configs Configs, opts ReconcileOpts) error {
 		objs = append(objs, &pkgiRes)
 
 		// Specifies underlying app resource
-		appRes.Name = pkgiRes.Name
-		appRes.Namespace = pkgiRes.Namespace
+		appResources = []kcv1alpha1.App{{ObjectMeta: metav1.ObjectMeta{
+			Name: pkgiRes.Name, Namespace: pkgiRes.Namespace}}}
 	}
 
 	var coreClient kubernetes.Interface
@@ -93,7 +126,7 @@ func (o *Reconciler) Reconcile(configs Configs, opts ReconcileOpts) error {
 			return err
 		}
 		coreClient = client
-		err = o.hackyConfigureKubernetesDst(coreClient)
+		err = o.hackyConfigureKubernetesDst(coreClient, opts.DstCACertFiles)
 		if err != nil {
 			return err
 		}
@@ -122,9 +155,11 @@ func (o *Reconciler) Reconcile(configs Configs, opts ReconcileOpts) error {
 		minCoreClient, kcClient, dpkgClient, vendirConfigHook, opts)
 
 	if opts.BeforeAppReconcile != nil {
-		err := opts.BeforeAppReconcile(appRes, kcClient)
-		if err != nil {
-			return err
+		for _, appRes := range appResources {
+			err := opts.BeforeAppReconcile(appRes, kcClient)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -141,12 +176,7 @@ func (o *Reconciler) Reconcile(configs Configs, opts ReconcileOpts) error {
 
 	// TODO is there a better way to deal with service accounts?
 	// TODO do anything with reconcile result?
-	_, reconcileErr = appReconciler.Reconcile(context.TODO(), reconcile.Request{
-		NamespacedName: types.NamespacedName{
-			Name:      appRes.Name,
-			Namespace: appRes.Namespace,
-		},
-	})
+	reconcileErr = o.reconcileApps(appReconciler, kcClient, appResources, opts)
 
 	// One more time to get successful or failed status
 	if len(configs.PkgInstalls) > 0 {
@@ -159,20 +189,88 @@ func (o *Reconciler) Reconcile(configs Configs, opts ReconcileOpts) error {
 	}
 
 	if opts.AfterAppReconcile != nil {
-		err := opts.AfterAppReconcile(appRes, kcClient)
-		if err != nil {
-			return err
+		for _, appRes := range appResources {
+			err := opts.AfterAppReconcile(appRes, kcClient)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
 	return reconcileErr
 }
 
+// reconcileApps reconciles the given apps, running up to opts.Concurrency
+// of them in parallel. Returns an aggregated error listing each app that
+// failed to reconcile (if any).
+func (o *Reconciler) reconcileApps(appReconciler *app.Reconciler, kcClient *fakekc.Clientset, appResources []kcv1alpha1.App, opts ReconcileOpts) error {
+	concurrency := opts.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	var errLock sync.Mutex
+	var reconcileErrs []error
+
+	sem := make(chan struct{}, concurrency)
+
+	for _, appRes := range appResources {
+		appRes := appRes
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			_, err := appReconciler.Reconcile(context.TODO(), reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      appRes.Name,
+					Namespace: appRes.Namespace,
+				},
+			})
+			if err == nil {
+				// App failures are recorded in status rather than
+				// returned from the reconciler
+				existingApp, getErr := kcClient.KappctrlV1alpha1().Apps(appRes.Namespace).Get(
+					context.TODO(), appRes.Name, metav1.GetOptions{})
+				if getErr == nil {
+					err = appFailureError(existingApp.Status)
+				}
+			}
+			if err != nil {
+				errLock.Lock()
+				reconcileErrs = append(reconcileErrs, fmt.Errorf("app/%s: %s", appRes.Name, err))
+				errLock.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return utilerrors.NewAggregate(reconcileErrs)
+}
+
+// appFailureError returns an error describing the app's failed reconcile
+// or delete (nil when neither condition is marked).
+func appFailureError(status kcv1alpha1.AppStatus) error {
+	for _, cond := range status.Conditions {
+		if (cond.Type == kcv1alpha1.ReconcileFailed || cond.Type == kcv1alpha1.DeleteFailed) && cond.Status == corev1.ConditionTrue {
+			if status.UsefulErrorMessage != "" {
+				return fmt.Errorf("%s", status.UsefulErrorMessage)
+			}
+			return fmt.Errorf("%s", cond.Message)
+		}
+	}
+	return nil
+}
+
 // hackyConfigureKubernetesDst configures environment variables for kapp.
 // This would not be necessary if kapp was using default kubeconfig; however,
 // right now kapp will use configuration based on configured serviceAccount within
 // PackageInstall or App CR. However, we still need to configure it to know where to connect.
-func (o *Reconciler) hackyConfigureKubernetesDst(coreClient kubernetes.Interface) error {
+func (o *Reconciler) hackyConfigureKubernetesDst(coreClient kubernetes.Interface, dstCACertFiles []string) error {
 	host, err := o.depsFactory.RESTHost()
 	if err != nil {
 		return fmt.Errorf("Getting host: %s", err)
@@ -188,16 +286,55 @@ func (o *Reconciler) hackyConfigureKubernetesDst(coreClient kubernetes.Interface
 		os.Setenv("KUBERNETES_SERVICE_PORT", hostURL.Port())
 	}
 
-	cm, err := coreClient.CoreV1().ConfigMaps("kube-public").Get(context.TODO(), "kube-root-ca.crt", metav1.GetOptions{})
+	caData, err := fetchKubeRootCA(func(ctx context.Context) (*corev1.ConfigMap, error) {
+		return coreClient.CoreV1().ConfigMaps("kube-public").Get(ctx, "kube-root-ca.crt", metav1.GetOptions{})
+	}, kubeRootCABackoff)
+	if err != nil {
+		return err
+	}
+	caData, err = appendCACerts(caData, dstCACertFiles, os.ReadFile)
 	if err != nil {
-		return fmt.Errorf("Fetching kube-root-ca.crt: %s", err)
+		return err
 	}
+
 	// Used during fetching of service accounts in kapp-controller
-	os.Setenv("KAPPCTRL_KUBERNETES_CA_DATA", cm.Data["ca.crt"])
+	os.Setenv("KAPPCTRL_KUBERNETES_CA_DATA", caData)
 
 	return nil
 }
 
+// kubeRootCABackoff retries for roughly 15s total since the kube-root-ca.crt
+// ConfigMap read is the first API call made against the cluster and transient
+// connectivity errors here would otherwise fail the whole dev run.
+var kubeRootCABackoff = wait.Backoff{Duration: 500 * time.Millisecond, Factor: 2, Steps: 5}
+
+// fetchKubeRootCA reads the cluster CA bundle from the kube-root-ca.crt
+// ConfigMap in kube-public, retrying transient errors with backoff. Each
+// attempt is bounded by a timeout so a hanging API server does not stall
+// the backoff loop indefinitely.
+func fetchKubeRootCA(getConfigMap func(context.Context) (*corev1.ConfigMap, error), backoff wait.Backoff) (string, error) {
+	var caData string
+	var lastErr error
+
+	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		cm, err := getConfigMap(ctx)
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+		caData = cm.Data["ca.crt"]
+		return true, nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("Fetching kube-root-ca.crt: %s", lastErr)
+	}
+
+	return caData, nil
+}
+
 func (o *Reconciler) newReconcilers(
 	coreClient kubernetes.Interface, kcClient *fakekc.Clientset, pkgClient *fakedpkg.Clientset,
 	vendirConfigHook func(vendirconf.Config) vendirconf.Config, opts ReconcileOpts) (*app.Reconciler, *packageinstall.Reconciler) {
@@ -0,0 +1,180 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package local
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	goexec "os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vmware-tanzu/carvel-kapp-controller/cli/pkg/kctrl/logger"
+	kcv1alpha1 "github.com/vmware-tanzu/carvel-kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	fakekc "github.com/vmware-tanzu/carvel-kapp-controller/pkg/client/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// rendezvousCmdRunner blocks every command until the expected number of
+// commands are running at the same time, proving parallel reconciliation.
+type rendezvousCmdRunner struct {
+	arrived chan struct{}
+	release chan struct{}
+}
+
+func (r rendezvousCmdRunner) meet() error {
+	r.arrived <- struct{}{}
+	select {
+	case <-r.release:
+		return nil
+	case <-time.After(10 * time.Second):
+		return fmt.Errorf("Timed out waiting for concurrent reconcile")
+	}
+}
+
+func (r rendezvousCmdRunner) Run(*goexec.Cmd) error { return r.meet() }
+
+func (r rendezvousCmdRunner) RunWithCancel(*goexec.Cmd, chan struct{}) error { return r.meet() }
+
+func devApp(name string) kcv1alpha1.App {
+	return kcv1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       v1alpha1AppSpecWithInlineFetch(),
+	}
+}
+
+func v1alpha1AppSpecWithInlineFetch() kcv1alpha1.AppSpec {
+	return kcv1alpha1.AppSpec{
+		Fetch: []kcv1alpha1.AppFetch{
+			{Inline: &kcv1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+		},
+		Template: []kcv1alpha1.AppTemplate{
+			{Ytt: &kcv1alpha1.AppTemplateYtt{}},
+		},
+		Cluster: &kcv1alpha1.AppCluster{
+			KubeconfigSecretRef: &kcv1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+		},
+		Deploy: []kcv1alpha1.AppDeploy{
+			{Kapp: &kcv1alpha1.AppDeployKapp{}},
+		},
+	}
+}
+
+func devKubeconfigSecret() corev1.Secret {
+	return corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-kubeconfig", Namespace: "default"},
+		Data: map[string][]byte{"value": []byte(`
+apiVersion: v1
+kind: Config
+clusters:
+- cluster: {server: https://cluster.example.com}
+  name: dst-cluster
+users:
+- user: {token: token}
+  name: dst-user
+contexts:
+- context: {cluster: dst-cluster, user: dst-user}
+  name: dst-ctx
+current-context: dst-ctx
+`)},
+	}
+}
+
+func Test_Reconcile_IndependentAppsInParallel(t *testing.T) {
+	runner := rendezvousCmdRunner{
+		arrived: make(chan struct{}, 16),
+		release: make(chan struct{}),
+	}
+
+	// Release all commands once two are running concurrently
+	go func() {
+		<-runner.arrived
+		<-runner.arrived
+		close(runner.release)
+	}()
+
+	configs := Configs{Apps: []kcv1alpha1.App{devApp("app1"), devApp("app2")}, Secrets: []corev1.Secret{devKubeconfigSecret()}}
+
+	var reconciledApps []string
+	afterAppReconcile := func(app kcv1alpha1.App, kcClient *fakekc.Clientset) error {
+		existingApp, err := kcClient.KappctrlV1alpha1().Apps(app.Namespace).Get(context.TODO(), app.Name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if existingApp.Status.Fetch == nil {
+			return fmt.Errorf("Expected fetch to have run for app '%s'", app.Name)
+		}
+		if existingApp.Status.Fetch.Error != "" {
+			return fmt.Errorf("Expected fetch to succeed for app '%s': %s", app.Name, existingApp.Status.Fetch.Error)
+		}
+		reconciledApps = append(reconciledApps, app.Name)
+		return nil
+	}
+
+	reconciler := NewReconciler(nil, runner, logger.NewNoopLogger())
+
+	err := reconciler.Reconcile(configs, ReconcileOpts{
+		Concurrency:       2,
+		AfterAppReconcile: afterAppReconcile,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"app1", "app2"}, reconciledApps)
+}
+
+// noopCmdRunner succeeds every command without running it.
+type noopCmdRunner struct{}
+
+func (noopCmdRunner) Run(*goexec.Cmd) error                          { return nil }
+func (noopCmdRunner) RunWithCancel(*goexec.Cmd, chan struct{}) error { return nil }
+
+// markerFailingCmdRunner fails any command whose stdin contains the
+// given marker and succeeds everything else.
+type markerFailingCmdRunner struct{ marker string }
+
+func (r markerFailingCmdRunner) Run(cmd *goexec.Cmd) error {
+	if cmd.Stdin != nil {
+		bs, _ := io.ReadAll(cmd.Stdin)
+		cmd.Stdin = bytes.NewReader(bs)
+		if strings.Contains(string(bs), r.marker) {
+			return fmt.Errorf("Fake command failure")
+		}
+	}
+	return nil
+}
+
+func (r markerFailingCmdRunner) RunWithCancel(cmd *goexec.Cmd, _ chan struct{}) error {
+	return r.Run(cmd)
+}
+
+func Test_Reconcile_AggregatesFailuresAcrossApps(t *testing.T) {
+	failingApp := devApp("app2")
+	failingApp.Spec.Fetch = []kcv1alpha1.AppFetch{
+		{Inline: &kcv1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "marker: fail-this-app"}}},
+	}
+
+	configs := Configs{Apps: []kcv1alpha1.App{devApp("app1"), failingApp}, Secrets: []corev1.Secret{devKubeconfigSecret()}}
+
+	reconciler := NewReconciler(nil, markerFailingCmdRunner{marker: "fail-this-app"}, logger.NewNoopLogger())
+
+	err := reconciler.Reconcile(configs, ReconcileOpts{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "app/app2")
+	assert.NotContains(t, err.Error(), "app/app1")
+}
+
+func Test_Reconcile_NoErrorWhenAllAppsSucceed(t *testing.T) {
+	configs := Configs{Apps: []kcv1alpha1.App{devApp("app1"), devApp("app2")}, Secrets: []corev1.Secret{devKubeconfigSecret()}}
+
+	reconciler := NewReconciler(nil, noopCmdRunner{}, logger.NewNoopLogger())
+
+	err := reconciler.Reconcile(configs, ReconcileOpts{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,20 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReconcilerControllerOptionsApplyConfiguredConcurrency(t *testing.T) {
+	opts := reconcilerControllerOptions(nil, 5)
+	assert.Equal(t, 5, opts.MaxConcurrentReconciles)
+}
+
+func TestReconcilerControllerOptionsTreatLowValuesAsOne(t *testing.T) {
+	assert.Equal(t, 1, reconcilerControllerOptions(nil, 0).MaxConcurrentReconciles)
+	assert.Equal(t, 1, reconcilerControllerOptions(nil, -3).MaxConcurrentReconciles)
+}
@@ -20,7 +20,9 @@ func main() {
 	ctrlOpts := Options{}
 	var sidecarexec bool
 
-	flag.IntVar(&ctrlOpts.Concurrency, "concurrency", 10, "Max concurrent reconciles")
+	flag.IntVar(&ctrlOpts.Concurrency, "concurrency", 10, "Max concurrent App reconciles. Higher values increase throughput on large clusters at the cost of more concurrent fetch/template/deploy processes and API server load.")
+	flag.IntVar(&ctrlOpts.PkgInstallConcurrency, "packageinstall-concurrency", 1, "Max concurrent PackageInstall reconciles. Higher values increase throughput at the cost of additional API server load.")
+	flag.IntVar(&ctrlOpts.PkgRepoConcurrency, "packagerepository-concurrency", 1, "Max concurrent PackageRepository reconciles. Higher values speed up syncing many repositories at the cost of more concurrent fetch processes and API server load.")
 	flag.StringVar(&ctrlOpts.Namespace, "namespace", "", "Namespace to watch")
 	flag.StringVar(&ctrlOpts.PackagingGlobalNS, "packaging-global-namespace", "", "The namespace used for global packaging resources")
 	flag.StringVar(&ctrlOpts.MetricsBindAddress, "metrics-bind-address", ":8080", "Address for metrics server. If 0, then metrics server doesnt listen on any port.")
@@ -30,6 +32,19 @@ func main() {
 	flag.BoolVar(&sidecarexec, "sidecarexec", false, "Run sidecarexec")
 	flag.BoolVar(&ctrlOpts.StartAPIServer, "start-api-server", true, "Start apiserver")
 	flag.StringVar(&ctrlOpts.TLSCipherSuites, "tls-cipher-suites", "", "comma separated list of acceptable cipher suites. Empty list will use defaults from underlying libraries.")
+	flag.IntVar(&ctrlOpts.AppHistoryMaxEntries, "app-history-max-entries", 0, "Number of reconcile outcomes kept per App in a history ConfigMap. If 0, history recording is disabled.")
+	flag.StringVar(&ctrlOpts.GlobalPauseConfigMap, "global-pause-configmap", "", "Name of a ConfigMap in the kapp-controller namespace that pauses all App reconciles while its 'pause' key is 'true'. Empty disables the switch.")
+	flag.IntVar(&ctrlOpts.AppMaxResourceCount, "app-max-resource-count", 0, "Max number of resources a single App's templated output may contain. If 0, no limit is enforced.")
+	flag.StringVar(&ctrlOpts.AppNotificationWebhook, "app-notification-webhook", "", "URL that terminal App reconcile outcomes are POSTed to as JSON. Empty disables notifications.")
+	flag.StringVar(&ctrlOpts.AppNotificationWebhookSecret, "app-notification-webhook-hmac-secret", "", "Secret used to HMAC-SHA256 sign webhook payloads (X-Hub-Signature-256 header). Empty disables signing.")
+	flag.DurationVar(&ctrlOpts.AppReconcileTimeout, "app-reconcile-timeout", time.Duration(0), "Duration after which a single App reconcile is canceled and a timeout is recorded in its status. If 0, no deadline is enforced.")
+	flag.DurationVar(&ctrlOpts.AppDeletionTimeout, "app-deletion-timeout", time.Duration(0), "Duration after which a failing App deletion records a timeout in its DeleteFailed condition. If 0, no deadline is enforced.")
+	flag.BoolVar(&ctrlOpts.AppDeletionForceFinalizerRemoval, "dangerous-app-deletion-force-finalizer-removal", false, "If set to true, force-remove an App's delete finalizer once app-deletion-timeout has passed. Deployed resources may be leaked.")
+	flag.DurationVar(&ctrlOpts.AppDebouncePeriod, "app-debounce-period", time.Duration(0), "Quiet period during which rapid successive App spec edits coalesce into a single reconcile. If 0, debouncing is disabled.")
+	flag.StringVar(&ctrlOpts.ReadinessListenAddr, "readiness-listen-addr", "", "Address serving a /readyz endpoint reporting aggregate reconcile health. Empty disables the endpoint.")
+	flag.DurationVar(&ctrlOpts.ReadinessMaxSuccessAge, "readiness-max-success-age", time.Hour, "Max age of an App's last successful reconcile before it counts as unhealthy for readiness.")
+	flag.Float64Var(&ctrlOpts.ReadinessMinHealthyFraction, "readiness-min-healthy-fraction", 0.9, "Min fraction of Apps that must be healthy for /readyz to report ready.")
+	flag.StringVar(&ctrlOpts.TraceCollectorAddr, "trace-collector-addr", os.Getenv("KAPPCTRL_TRACE_COLLECTOR_ADDR"), "Address of an OTLP gRPC collector that reconcile trace spans are exported to. Empty disables tracing.")
 	flag.Parse()
 
 	if sidecarexec {
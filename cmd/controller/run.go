@@ -27,6 +27,7 @@ import (
 	"carvel.dev/kapp-controller/pkg/pkgrepository"
 	"carvel.dev/kapp-controller/pkg/reftracker"
 	"carvel.dev/kapp-controller/pkg/sidecarexec"
+	"carvel.dev/kapp-controller/pkg/tracing"
 	"github.com/go-logr/logr"
 	"k8s.io/client-go/kubernetes"
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp" // Initialize gcp client auth plugin
@@ -45,7 +46,12 @@ const (
 )
 
 type Options struct {
-	Concurrency            int
+	Concurrency int
+	// PkgInstallConcurrency and PkgRepoConcurrency bound concurrent
+	// reconciles of PackageInstalls and PackageRepositories; values
+	// below 1 are treated as 1
+	PkgInstallConcurrency  int
+	PkgRepoConcurrency     int
 	Namespace              string
 	EnablePprof            bool
 	APIRequestTimeout      time.Duration
@@ -54,6 +60,34 @@ type Options struct {
 	APIPriorityAndFairness bool
 	StartAPIServer         bool
 	TLSCipherSuites        string
+	AppHistoryMaxEntries   int
+	GlobalPauseConfigMap   string
+	AppNotificationWebhook string
+	// AppNotificationWebhookSecret signs webhook payloads with
+	// HMAC-SHA256 when non-empty
+	AppNotificationWebhookSecret string
+	AppMaxResourceCount          int
+	AppReconcileTimeout          time.Duration
+	AppDeletionTimeout           time.Duration
+	AppDebouncePeriod            time.Duration
+
+	AppDeletionForceFinalizerRemoval bool
+
+	ReadinessListenAddr         string
+	ReadinessMaxSuccessAge      time.Duration
+	ReadinessMinHealthyFraction float64
+	TraceCollectorAddr          string
+}
+
+// reconcilerControllerOptions builds controller-runtime options for a
+// reconciler with the configured max concurrent reconciles applied
+// (values below 1 are treated as 1 so a controller always makes
+// progress).
+func reconcilerControllerOptions(reconciler reconcile.Reconciler, concurrency int) controller.Options {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return controller.Options{Reconciler: reconciler, MaxConcurrentReconciles: concurrency}
 }
 
 // Based on https://github.com/kubernetes-sigs/controller-runtime/blob/8f633b179e1c704a6e40440b528252f147a3362a/examples/builtins/main.go
@@ -61,6 +95,19 @@ func Run(opts Options, runLog logr.Logger) error {
 	runLog.Info("start controller")
 	runLog.Info("setting up manager")
 
+	if opts.TraceCollectorAddr != "" {
+		shutdownTracing, err := tracing.Setup(context.Background(), opts.TraceCollectorAddr)
+		if err != nil {
+			return fmt.Errorf("Setting up tracing: %s", err)
+		}
+		defer func() {
+			err := shutdownTracing(context.Background())
+			if err != nil {
+				runLog.Error(err, "Shutting down tracing")
+			}
+		}()
+	}
+
 	restConfig := config.GetConfigOrDie()
 
 	if opts.APIRequestTimeout != 0 {
@@ -187,6 +234,7 @@ func Run(opts Options, runLog logr.Logger) error {
 	appMetrics := metrics.NewMetrics()
 	appMetrics.ReconcileTimeMetrics.RegisterAllMetrics()
 	appMetrics.ReconcileCountMetrics.RegisterAllMetrics()
+	appMetrics.ReconcileInFlightMetrics.RegisterAllMetrics()
 
 	cacheFolderApps := memdir.NewTmpDir("cache-appcr")
 	err = cacheFolderApps.Create()
@@ -195,25 +243,40 @@ func Run(opts Options, runLog logr.Logger) error {
 	}
 	{ // add controller for apps
 		appFactory := app.CRDAppFactory{
-			CoreClient:  coreClient,
-			AppClient:   kcClient,
-			KcConfig:    kcConfig,
-			AppMetrics:  appMetrics,
-			CmdRunner:   sidecarCmdExec,
-			Kubeconf:    kubeconf,
-			CompInfo:    compInfo,
-			CacheFolder: cacheFolderApps,
+			CoreClient:       coreClient,
+			AppClient:        kcClient,
+			KcConfig:         kcConfig,
+			AppMetrics:       appMetrics,
+			CmdRunner:        sidecarCmdExec,
+			Kubeconf:         kubeconf,
+			CompInfo:         compInfo,
+			CacheFolder:      cacheFolderApps,
+			MaxResourceCount: opts.AppMaxResourceCount,
+			ReconcileTimeout: opts.AppReconcileTimeout,
+			DeletionTimeout:  opts.AppDeletionTimeout,
+
+			ForceFinalizerRemovalOnTimeout: opts.AppDeletionForceFinalizerRemoval,
+		}
+		if opts.AppHistoryMaxEntries > 0 {
+			appFactory.HistoryRecorder = app.NewReconcileHistoryRecorder(coreClient, opts.AppHistoryMaxEntries)
+		}
+		if opts.GlobalPauseConfigMap != "" {
+			ns := os.Getenv("KAPPCTRL_SYSTEM_NAMESPACE")
+			if ns == "" {
+				return fmt.Errorf("Cannot get kapp-controller namespace")
+			}
+			appFactory.ReconcilePauser = app.NewReconcilePauser(coreClient, ns, opts.GlobalPauseConfigMap)
+		}
+		if opts.AppNotificationWebhook != "" {
+			appFactory.Notifier = app.NewWebhookNotifier(opts.AppNotificationWebhook, opts.AppNotificationWebhookSecret)
 		}
 		reconciler := app.NewReconciler(kcClient, runLog.WithName("app"),
-			appFactory, refTracker, updateStatusTracker, compInfo)
-
-		ctrl, err := controller.New("app", mgr, controller.Options{
-			Reconciler: NewUniqueReconciler(&ErrReconciler{
-				delegate: reconciler,
-				log:      runLog.WithName("er"),
-			}),
-			MaxConcurrentReconciles: opts.Concurrency,
-		})
+			appFactory, refTracker, updateStatusTracker, compInfo, opts.AppDebouncePeriod)
+
+		ctrl, err := controller.New("app", mgr, reconcilerControllerOptions(NewUniqueReconciler(&ErrReconciler{
+			delegate: reconciler,
+			log:      runLog.WithName("er"),
+		}), opts.Concurrency))
 		if err != nil {
 			return fmt.Errorf("Setting up Apps reconciler: %s", err)
 		}
@@ -231,10 +294,7 @@ func Run(opts Options, runLog logr.Logger) error {
 		reconciler := pkginstall.NewReconciler(kcClient, pkgClient, coreClient, pkgToPkgInstallHandler,
 			runLog.WithName("pkgi"), compInfo, kcConfig, appMetrics)
 
-		ctrl, err := controller.New("pkgi", mgr, controller.Options{
-			Reconciler:              reconciler,
-			MaxConcurrentReconciles: 1,
-		})
+		ctrl, err := controller.New("pkgi", mgr, reconcilerControllerOptions(reconciler, opts.PkgInstallConcurrency))
 		if err != nil {
 			return fmt.Errorf("Setting up PackageInstalls reconciler: %s", err)
 		}
@@ -265,11 +325,7 @@ func Run(opts Options, runLog logr.Logger) error {
 		reconciler := pkgrepository.NewReconciler(kcClient, coreClient,
 			runLog.WithName("pkgr"), appFactory, refTracker, updateStatusTracker)
 
-		ctrl, err := controller.New("pkgr", mgr, controller.Options{
-			Reconciler: reconciler,
-			// TODO: Consider making this configurable for multiple PackageRepo reconciles
-			MaxConcurrentReconciles: 1,
-		})
+		ctrl, err := controller.New("pkgr", mgr, reconcilerControllerOptions(reconciler, opts.PkgRepoConcurrency))
 		if err != nil {
 			return fmt.Errorf("Setting up PackageRepositories reconciler: %s", err)
 		}
@@ -282,6 +338,16 @@ func Run(opts Options, runLog logr.Logger) error {
 
 	runLog.Info("starting manager")
 
+	if opts.ReadinessListenAddr != "" {
+		readinessMux := http.NewServeMux()
+		readinessMux.Handle("/readyz", app.NewReadinessHandler(
+			kcClient, opts.ReadinessMaxSuccessAge, opts.ReadinessMinHealthyFraction))
+		runLog.Info("readiness probe running", "listen-addr", opts.ReadinessListenAddr)
+		go func() {
+			runLog.Error(http.ListenAndServe(opts.ReadinessListenAddr, readinessMux), "serving readiness probe")
+		}()
+	}
+
 	if opts.EnablePprof {
 		runLog.Info("DANGEROUS in production setting -- pprof running", "listen-addr", PprofListenAddr)
 		go func() {
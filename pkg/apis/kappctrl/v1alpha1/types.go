@@ -4,6 +4,7 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -80,6 +81,46 @@ type AppSpec struct {
 	// same as the App's namespace (optional; v0.48.0+)
 	// +optional
 	DefaultNamespace string `json:"defaultNamespace,omitempty" protobuf:"bytes,10,opt,name=defaultNamespace"`
+	// Specifies HTTP proxy configuration used while fetching,
+	// overriding the controller's proxy environment (optional)
+	// +optional
+	Proxy *AppProxy `json:"proxy,omitempty"`
+	// Specifies time windows (UTC) during which reconciliation is
+	// allowed to run; outside of all windows reconciliation is
+	// requeued until a window opens (optional; default=always allowed)
+	// +optional
+	SyncWindows []AppSyncWindow `json:"syncWindows,omitempty"`
+}
+
+// +k8s:openapi-gen=true
+// +protobuf=false
+type AppProxy struct {
+	// Proxy URL for plain HTTP requests (optional)
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// Proxy URL for HTTPS requests (optional)
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// Comma separated hosts or domain suffixes excluded
+	// from proxying (optional)
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+}
+
+// AppSyncWindow describes a daily time window during which
+// reconciliation may run. Times use 24h "HH:MM" format, in UTC.
+// A window with end before start spans midnight.
+// +k8s:openapi-gen=true
+// +protobuf=false
+type AppSyncWindow struct {
+	// Days of the week ("Mon".."Sun") the window applies to;
+	// empty means every day (optional)
+	// +optional
+	Days []string `json:"days,omitempty"`
+	// Start of the window, inclusive (required)
+	Start string `json:"start"`
+	// End of the window, exclusive (required)
+	End string `json:"end"`
 }
 
 // +k8s:openapi-gen=true
@@ -118,10 +159,41 @@ type AppStatus struct {
 	ConsecutiveReconcileSuccesses int `json:"consecutiveReconcileSuccesses,omitempty"`
 	// +optional
 	ConsecutiveReconcileFailures int `json:"consecutiveReconcileFailures,omitempty"`
+	// LastReconciledGeneration is the spec generation that was most
+	// recently reconciled successfully
+	// +optional
+	LastReconciledGeneration int64 `json:"lastReconciledGeneration,omitempty"`
+	// LastSuccessfulReconcile is the time of the most recent
+	// successful reconcile
+	// +optional
+	LastSuccessfulReconcile metav1.Time `json:"lastSuccessfulReconcile,omitempty"`
+	// TotalReconcileAttempts is a monotonic count of reconcile and
+	// delete attempts made for this app, successful or not
+	// +optional
+	TotalReconcileAttempts int64 `json:"totalReconcileAttempts,omitempty"`
+	// ControllerVersion is the version of kapp-controller that most
+	// recently reconciled this app
+	// +optional
+	ControllerVersion string `json:"controllerVersion,omitempty"`
+	// ConditionHistory is a bounded record of recent condition
+	// transitions, oldest first (useful for debugging flapping apps)
+	// +optional
+	ConditionHistory []AppConditionTransition `json:"conditionHistory,omitempty"`
 	// +optional
 	GenericStatus `json:",inline"`
 }
 
+// AppConditionTransition records a single condition transition.
+// +protobuf=false
+type AppConditionTransition struct {
+	Type   ConditionType          `json:"type"`
+	Status corev1.ConditionStatus `json:"status"`
+	// +optional
+	Message string `json:"message,omitempty"`
+	// +optional
+	TransitionTime metav1.Time `json:"transitionTime,omitempty"`
+}
+
 // +protobuf=false
 type AppStatusFetch struct {
 	// +optional
@@ -136,6 +208,19 @@ type AppStatusFetch struct {
 	StartedAt metav1.Time `json:"startedAt,omitempty"`
 	// +optional
 	UpdatedAt metav1.Time `json:"updatedAt,omitempty"`
+	// PinnedDigest is the digest the fetch was pinned to via
+	// the fetch-pin-digest annotation (if any)
+	// +optional
+	PinnedDigest string `json:"pinnedDigest,omitempty"`
+	// ResolvedGitSHA is the commit the first git fetch source
+	// resolved to (empty when no git sources are configured)
+	// +optional
+	ResolvedGitSHA string `json:"resolvedGitSHA,omitempty"`
+	// UsedSource records which configured sources satisfied the fetch
+	// when fallbacks are configured: "primary", or "fallback N" where
+	// N is a 1-based index into the fallbacks lists
+	// +optional
+	UsedSource string `json:"usedSource,omitempty"`
 }
 
 // +protobuf=false
@@ -148,6 +233,31 @@ type AppStatusTemplate struct {
 	Error string `json:"error,omitempty"`
 	// +optional
 	UpdatedAt metav1.Time `json:"updatedAt,omitempty"`
+	// ResolvedImages lists images kbld resolved to digest references
+	// during templating (if kbld ran)
+	// +optional
+	ResolvedImages []AppResolvedImage `json:"resolvedImages,omitempty"`
+	// Digest of the templated output of the last successful template
+	// stage; lets a restarted controller recognize unchanged renders
+	// without relying on in-memory state
+	// +optional
+	Digest string `json:"digest,omitempty"`
+	// DataValues is the final set of data values documents used while
+	// templating, with Secret-sourced and sensitive-looking values
+	// redacted (empty when templating consumed no data values)
+	// +optional
+	DataValues string `json:"dataValues,omitempty"`
+}
+
+// AppResolvedImage records a single image resolution made by kbld.
+// +protobuf=false
+type AppResolvedImage struct {
+	// Image is the reference as it appeared in templated config
+	// +optional
+	Image string `json:"image,omitempty"`
+	// Resolved is the digest reference the image resolved to
+	// +optional
+	Resolved string `json:"resolved,omitempty"`
 }
 
 // +protobuf=false
@@ -168,6 +278,26 @@ type AppStatusDeploy struct {
 	UpdatedAt metav1.Time `json:"updatedAt,omitempty"`
 	// +optional
 	KappDeployStatus *KappDeployStatus `json:"kapp,omitempty"`
+	// Structured list of resources kapp failed waiting on (if any)
+	// +optional
+	WaitFailures []AppStatusDeployWaitFailure `json:"waitFailures,omitempty"`
+	// Number of resources kapp reported as done waiting, parsed
+	// from deploy output (if present)
+	// +optional
+	ResourcesReady int `json:"resourcesReady,omitempty"`
+	// +optional
+	ResourcesTotal int `json:"resourcesTotal,omitempty"`
+	// DryRun indicates the deploy only computed and recorded the diff
+	// of pending changes (via the dry-run annotation) without applying
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// AppStatusDeployWaitFailure describes a resource kapp failed waiting on
+// +protobuf=false
+type AppStatusDeployWaitFailure struct {
+	Resource string `json:"resource"`
+	Reason   string `json:"reason"`
 }
 
 // KappDeployStatus contains the associated AppCR deployed resources
@@ -3,6 +3,10 @@
 
 package v1alpha1
 
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // +k8s:openapi-gen=true
 type AppDeploy struct {
 	// Use kapp to deploy resources
@@ -17,6 +21,32 @@ type AppDeployKapp struct {
 	MapNs []string `json:"mapNs,omitempty" protobuf:"bytes,2,rep,name=mapNs"`
 	// Pass through options to kapp deploy (optional)
 	RawOptions []string `json:"rawOptions,omitempty" protobuf:"bytes,3,rep,name=rawOptions"`
+	// Labels to apply to all deployed resources via kapp's
+	// --labels flag (optional)
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// Timeout for how long kapp waits on an individual resource,
+	// passed via kapp's --wait-resource-timeout flag (optional)
+	// +optional
+	WaitResourceTimeout *metav1.Duration `json:"waitResourceTimeout,omitempty"`
+	// Name of the previously deployed kapp app whose resources should
+	// be adopted, passed via kapp's --prev-app flag. Useful when
+	// renaming an app without orphaning its resources (optional)
+	// +optional
+	PrevApp string `json:"prevApp,omitempty"`
+	// Apply changes to ignored resources, passed via kapp's
+	// --apply-ignored flag (optional)
+	// +optional
+	ApplyIgnored bool `json:"applyIgnored,omitempty"`
+	// Wait on ignored resources, passed via kapp's --wait-ignored
+	// flag (optional)
+	// +optional
+	WaitIgnored bool `json:"waitIgnored,omitempty"`
+	// Whether kapp masks potentially sensitive values in diff output,
+	// passed via kapp's --diff-mask flag; when unset kapp's default
+	// (masked) applies (optional)
+	// +optional
+	DiffMask *bool `json:"diffMask,omitempty"`
 
 	// Configuration for inspect command (optional)
 	// as of kapp-controller v0.31.0, inspect is disabled by default
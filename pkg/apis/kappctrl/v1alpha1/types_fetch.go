@@ -24,6 +24,13 @@ type AppFetch struct {
 	// Relative path to place the fetched artifacts
 	// +optional
 	Path string `json:"path,omitempty" protobuf:"bytes,7,opt,name=path"`
+	// Alternative sources (e.g. mirrors) tried in order when fetching
+	// from the source above fails; each fallback fully replaces this
+	// fetch step for the retried attempt (optional)
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Fallbacks []AppFetch `json:"fallbacks,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -76,6 +76,23 @@ func (in *AppClusterKubeconfigSecretRef) DeepCopy() *AppClusterKubeconfigSecretR
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppConditionTransition) DeepCopyInto(out *AppConditionTransition) {
+	*out = *in
+	in.TransitionTime.DeepCopyInto(&out.TransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppConditionTransition.
+func (in *AppConditionTransition) DeepCopy() *AppConditionTransition {
+	if in == nil {
+		return nil
+	}
+	out := new(AppConditionTransition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppDeploy) DeepCopyInto(out *AppDeploy) {
 	*out = *in
@@ -110,6 +127,23 @@ func (in *AppDeployKapp) DeepCopyInto(out *AppDeployKapp) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.WaitResourceTimeout != nil {
+		in, out := &in.WaitResourceTimeout, &out.WaitResourceTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DiffMask != nil {
+		in, out := &in.DiffMask, &out.DiffMask
+		*out = new(bool)
+		**out = **in
+	}
 	if in.Inspect != nil {
 		in, out := &in.Inspect, &out.Inspect
 		*out = new(AppDeployKappInspect)
@@ -208,6 +242,13 @@ func (in *AppFetch) DeepCopyInto(out *AppFetch) {
 		*out = new(AppFetchImgpkgBundle)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Fallbacks != nil {
+		in, out := &in.Fallbacks, &out.Fallbacks
+		*out = make([]AppFetch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -483,6 +524,22 @@ func (in *AppList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppResolvedImage) DeepCopyInto(out *AppResolvedImage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppResolvedImage.
+func (in *AppResolvedImage) DeepCopy() *AppResolvedImage {
+	if in == nil {
+		return nil
+	}
+	out := new(AppResolvedImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppSpec) DeepCopyInto(out *AppSpec) {
 	*out = *in
@@ -517,9 +574,37 @@ func (in *AppSpec) DeepCopyInto(out *AppSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(AppProxy)
+		**out = **in
+	}
+	if in.SyncWindows != nil {
+		in, out := &in.SyncWindows, &out.SyncWindows
+		*out = make([]AppSyncWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppProxy) DeepCopyInto(out *AppProxy) {
+	*out = *in
 	return
 }
 
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppProxy.
+func (in *AppProxy) DeepCopy() *AppProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(AppProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppSpec.
 func (in *AppSpec) DeepCopy() *AppSpec {
 	if in == nil {
@@ -553,6 +638,14 @@ func (in *AppStatus) DeepCopyInto(out *AppStatus) {
 		*out = new(AppStatusInspect)
 		(*in).DeepCopyInto(*out)
 	}
+	in.LastSuccessfulReconcile.DeepCopyInto(&out.LastSuccessfulReconcile)
+	if in.ConditionHistory != nil {
+		in, out := &in.ConditionHistory, &out.ConditionHistory
+		*out = make([]AppConditionTransition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.GenericStatus.DeepCopyInto(&out.GenericStatus)
 	return
 }
@@ -577,6 +670,11 @@ func (in *AppStatusDeploy) DeepCopyInto(out *AppStatusDeploy) {
 		*out = new(KappDeployStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.WaitFailures != nil {
+		in, out := &in.WaitFailures, &out.WaitFailures
+		*out = make([]AppStatusDeployWaitFailure, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -590,6 +688,22 @@ func (in *AppStatusDeploy) DeepCopy() *AppStatusDeploy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppStatusDeployWaitFailure) DeepCopyInto(out *AppStatusDeployWaitFailure) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppStatusDeployWaitFailure.
+func (in *AppStatusDeployWaitFailure) DeepCopy() *AppStatusDeployWaitFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(AppStatusDeployWaitFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppStatusFetch) DeepCopyInto(out *AppStatusFetch) {
 	*out = *in
@@ -629,6 +743,11 @@ func (in *AppStatusInspect) DeepCopy() *AppStatusInspect {
 func (in *AppStatusTemplate) DeepCopyInto(out *AppStatusTemplate) {
 	*out = *in
 	in.UpdatedAt.DeepCopyInto(&out.UpdatedAt)
+	if in.ResolvedImages != nil {
+		in, out := &in.ResolvedImages, &out.ResolvedImages
+		*out = make([]AppResolvedImage, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -642,6 +761,27 @@ func (in *AppStatusTemplate) DeepCopy() *AppStatusTemplate {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppSyncWindow) DeepCopyInto(out *AppSyncWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppSyncWindow.
+func (in *AppSyncWindow) DeepCopy() *AppSyncWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(AppSyncWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppTemplate) DeepCopyInto(out *AppTemplate) {
 	*out = *in
@@ -40,6 +40,28 @@ type Hooks struct {
 type Opts struct {
 	DefaultSyncPeriod time.Duration
 	MinimumSyncPeriod time.Duration
+
+	// ReconcileTimeout bounds a single reconcile; once exceeded
+	// the deploy stage is canceled and a timeout is recorded in
+	// status. If 0, no deadline is enforced.
+	ReconcileTimeout time.Duration
+
+	// MaxResourceCount caps how many resources a single App's
+	// templated output may contain; exceeding it fails reconcile
+	// before the deploy stage. If 0, no limit is enforced.
+	MaxResourceCount int
+
+	// DeletionTimeout bounds how long deletion may keep failing
+	// (measured from the deletion timestamp) before the timeout is
+	// recorded in the DeleteFailed condition. If 0, no deadline is
+	// enforced.
+	DeletionTimeout time.Duration
+
+	// ForceFinalizerRemovalOnTimeout removes the delete finalizer
+	// once DeletionTimeout has passed even though deployed resources
+	// may be left behind, so a stuck delete cannot block namespace
+	// deletion forever.
+	ForceFinalizerRemovalOnTimeout bool
 }
 
 type App struct {
@@ -62,6 +84,20 @@ type App struct {
 	pendingStatusUpdate   bool
 	flushAllStatusUpdates bool
 	metadata              *deploy.Meta
+
+	// lastTemplateDataValues holds the redacted data values used by
+	// the most recent template stage for surfacing in status
+	lastTemplateDataValues string
+
+	// fetchUsedSource records which source combination satisfied the
+	// most recent fetch ("primary" or "fallback N"); empty unless
+	// fallback sources are configured
+	fetchUsedSource string
+
+	stageSubs     []chan StageEvent
+	stageSubsLock sync.Mutex
+
+	reconcileStartTime time.Time
 }
 
 func NewApp(app v1alpha1.App, hooks Hooks,
@@ -85,6 +121,39 @@ func (a *App) StatusAsYAMLBytes() ([]byte, error) {
 	return yaml.Marshal(a.Status())
 }
 
+// ReconcileUpToDate returns true when the app's current spec generation
+// was already reconciled successfully and the periodic resync is not yet
+// due, meaning a full reconcile can be skipped.
+func (a *App) ReconcileUpToDate() bool {
+	if a.app.DeletionTimestamp != nil || a.app.Spec.Canceled || a.app.Spec.Paused {
+		return false
+	}
+	if a.app.Status.LastReconciledGeneration != a.app.Generation {
+		return false
+	}
+
+	timerOpts := ReconcileTimerOpts{
+		DefaultSyncPeriod: a.opts.DefaultSyncPeriod,
+		MinimumSyncPeriod: a.opts.MinimumSyncPeriod,
+	}
+
+	return !NewReconcileTimer(a.app, timerOpts).IsReadyAt(time.Now())
+}
+
+// SetLogger replaces the logger used for subsequent fetch, template
+// and deploy operations (e.g. to correlate entries of one reconcile).
+func (a *App) SetLogger(log logr.Logger) { a.log = log }
+
+// DurationUntilResync returns the time until the next periodic resync.
+func (a *App) DurationUntilResync() time.Duration {
+	timerOpts := ReconcileTimerOpts{
+		DefaultSyncPeriod: a.opts.DefaultSyncPeriod,
+		MinimumSyncPeriod: a.opts.MinimumSyncPeriod,
+	}
+
+	return NewReconcileTimer(a.app, timerOpts).DurationUntilReady(nil)
+}
+
 func (a *App) blockDeletion() error   { return a.hooks.BlockDeletion() }
 func (a *App) unblockDeletion() error { return a.hooks.UnblockDeletion() }
 
@@ -320,7 +389,7 @@ func (a *App) isNamespaceTerminatingOrTerminated(namespace string) bool {
 // secrets are not always populated quick enough for Apps to use
 // the secret, and private auth is only supported for images/bundles,
 // so this helps to narrow down when to retry a fetch attempt.
-func (a App) HasImageOrImgpkgBundle() bool {
+func (a *App) HasImageOrImgpkgBundle() bool {
 	for _, fetch := range a.app.Spec.Fetch {
 		if fetch.ImgpkgBundle != nil || fetch.Image != nil {
 			return true
@@ -5,6 +5,8 @@ package app
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
 	ctldep "carvel.dev/kapp-controller/pkg/deploy"
@@ -27,10 +29,33 @@ func (a *App) deploy(tplOutput string, changedFunc func(exec.CmdRunResult)) exec
 	for _, dep := range a.app.Spec.Deploy {
 		switch {
 		case dep.Kapp != nil:
-			cancelCh, closeCancelCh := a.newCancelCh(onCanceled, onDeleted)
+			canceledByUser := false
+			onCancelAnnotation := func(app v1alpha1.App) bool {
+				if hasCancelAnnotation(app) {
+					canceledByUser = true
+					return true
+				}
+				return false
+			}
+
+			cancelCh, closeCancelCh := a.newCancelCh(onCancelAnnotation, onCanceled, onDeleted)
 			defer closeCancelCh()
 
-			kapp, err := a.newKapp(*dep.Kapp, cancelCh)
+			if a.opts.ReconcileTimeout > 0 {
+				remaining := a.opts.ReconcileTimeout - time.Since(a.reconcileStartTime)
+				if remaining <= 0 {
+					return exec.NewCmdRunResultWithErr(fmt.Errorf("Deploy exceeded reconcile timeout (%s)", a.opts.ReconcileTimeout))
+				}
+				timer := time.AfterFunc(remaining, closeCancelCh)
+				defer timer.Stop()
+			}
+
+			kappDeploy := *dep.Kapp
+			if a.dryRun() {
+				kappDeploy.RawOptions = append(append([]string{}, kappDeploy.RawOptions...), dryRunKappDeployOpts()...)
+			}
+
+			kapp, err := a.newDeploy(kappDeploy, cancelCh)
 			if err != nil {
 				return exec.NewCmdRunResultWithErr(fmt.Errorf("Preparing kapp: %s", err))
 			}
@@ -38,6 +63,14 @@ func (a *App) deploy(tplOutput string, changedFunc func(exec.CmdRunResult)) exec
 			result = kapp.Deploy(tplOutput, a.startFlushingAllStatusUpdates, changedFunc)
 			a.trySaveMetadata(kapp)
 
+			if canceledByUser {
+				result.AttachErrorf("%s", fmt.Errorf("Reconcile canceled by user"))
+			}
+
+			if a.opts.ReconcileTimeout > 0 && time.Since(a.reconcileStartTime) >= a.opts.ReconcileTimeout {
+				result.AttachErrorf("%s", fmt.Errorf("Deploy exceeded reconcile timeout (%s)", a.opts.ReconcileTimeout))
+			}
+
 		default:
 			result.AttachErrorf("%s", fmt.Errorf("Unsupported way to deploy"))
 		}
@@ -57,14 +90,18 @@ func (a *App) delete(changedFunc func(exec.CmdRunResult)) exec.CmdRunResult {
 
 	var result exec.CmdRunResult
 
-	if !a.app.Spec.NoopDelete && !a.noopDeleteDueToTerminatingNamespaces() {
+	orphaned := a.orphaningResources()
+
+	deleteRan := !a.app.Spec.NoopDelete && !orphaned && !a.noopDeleteDueToTerminatingNamespaces()
+
+	if deleteRan {
 		for _, dep := range a.app.Spec.Deploy {
 			switch {
 			case dep.Kapp != nil:
 				cancelCh, closeCancelCh := a.newCancelCh(onCanceled)
 				defer closeCancelCh()
 
-				kapp, err := a.newKapp(*dep.Kapp, cancelCh)
+				kapp, err := a.newDeploy(*dep.Kapp, cancelCh)
 				if err != nil {
 					return exec.NewCmdRunResultWithErr(fmt.Errorf("Preparing kapp: %s", err))
 				}
@@ -81,7 +118,38 @@ func (a *App) delete(changedFunc func(exec.CmdRunResult)) exec.CmdRunResult {
 		}
 	}
 
+	// Keep the finalizer if delete did not verifiably clean up all
+	// resources so that deletion is retried instead of leaking them
+	if result.Error == nil && deleteRan {
+		err := a.verifyDeleteCompleted()
+		if err != nil {
+			result.AttachErrorf("%s", err)
+		}
+	}
+
+	// Once deletion has been failing past the configured timeout,
+	// record the timeout and optionally drop the finalizer so a stuck
+	// delete cannot block namespace deletion forever
+	if result.Error != nil && a.deletionTimedOut() {
+		result.AttachErrorf("%s", fmt.Errorf("Delete exceeded deletion timeout (%s)", a.opts.DeletionTimeout))
+
+		if a.opts.ForceFinalizerRemovalOnTimeout {
+			a.log.Info("Force removing finalizer after deletion timeout; deployed resources may be leaked")
+
+			err := a.unblockDeletion()
+			if err != nil {
+				result.AttachErrorf("Unblocking for deploy: %s", err)
+			}
+		}
+
+		return result
+	}
+
 	if result.Error == nil {
+		if orphaned {
+			a.app.Status.FriendlyDescription = "Delete skipped, deployed resources orphaned"
+		}
+
 		err := a.unblockDeletion()
 		if err != nil {
 			return exec.NewCmdRunResultWithErr(fmt.Errorf("Unblocking for deploy: %s", err))
@@ -91,6 +159,53 @@ func (a *App) delete(changedFunc func(exec.CmdRunResult)) exec.CmdRunResult {
 	return result
 }
 
+// deletionTimedOut reports whether deletion has been in progress longer
+// than the configured deletion timeout (never when no timeout is set).
+func (a *App) deletionTimedOut() bool {
+	if a.opts.DeletionTimeout == 0 || a.app.DeletionTimestamp == nil {
+		return false
+	}
+	return time.Since(a.app.DeletionTimestamp.Time) >= a.opts.DeletionTimeout
+}
+
+// verifyDeleteCompleted confirms via kapp inspect that the underlying
+// kapp app is gone before the delete finalizer is removed. kapp inspect
+// erroring with "does not exist" is the expected outcome after a
+// successful delete; any other inspect failure (e.g. a transient API
+// error) proves nothing about cleanup and is surfaced so deletion is
+// verified again on retry.
+func (a *App) verifyDeleteCompleted() error {
+	for _, dep := range a.app.Spec.Deploy {
+		if dep.Kapp == nil {
+			continue
+		}
+
+		cancelCh, closeCancelCh := a.newCancelCh(onCanceled)
+		defer closeCancelCh()
+
+		kapp, err := a.newDeploy(*dep.Kapp, cancelCh)
+		if err != nil {
+			return fmt.Errorf("Preparing kapp: %s", err)
+		}
+
+		result := kapp.Inspect()
+		switch {
+		case result.Error == nil:
+			return fmt.Errorf("Expected app resources to be deleted, but they still exist")
+		case !inspectSaysAppIsGone(result):
+			return fmt.Errorf("Verifying delete: %s", result.Error)
+		}
+	}
+
+	return nil
+}
+
+// inspectSaysAppIsGone reports whether a failed kapp inspect failed
+// because the kapp app no longer exists.
+func inspectSaysAppIsGone(result exec.CmdRunResult) bool {
+	return strings.Contains(result.Stderr, "does not exist")
+}
+
 func (a *App) inspect() exec.CmdRunResult {
 	if len(a.app.Spec.Deploy) != 1 {
 		return exec.NewCmdRunResultWithErr(fmt.Errorf("Expected exactly one deploy option"))
@@ -105,7 +220,7 @@ func (a *App) inspect() exec.CmdRunResult {
 				cancelCh, closeCancelCh := a.newCancelCh(onCanceled, onDeleted)
 				defer closeCancelCh()
 
-				kapp, err := a.newKapp(*dep.Kapp, cancelCh)
+				kapp, err := a.newDeploy(*dep.Kapp, cancelCh)
 				if err != nil {
 					return exec.NewCmdRunResultWithErr(fmt.Errorf("Preparing kapp: %s", err))
 				}
@@ -126,7 +241,13 @@ func (a *App) inspect() exec.CmdRunResult {
 }
 
 // trySaveMetadata if unable to save the kapp metadata into an App meta continue and do not fail the deploy.
-func (a *App) trySaveMetadata(kapp *ctldep.Kapp) {
+// Metadata is kapp specific, so deployers from other backends are skipped.
+func (a *App) trySaveMetadata(deploy ctldep.Deploy) {
+	kapp, ok := deploy.(*ctldep.Kapp)
+	if !ok {
+		return
+	}
+
 	meta, err := kapp.InternalAppMeta()
 	if err != nil {
 		return
@@ -135,20 +256,45 @@ func (a *App) trySaveMetadata(kapp *ctldep.Kapp) {
 	a.metadata = meta
 }
 
-func (a *App) newKapp(kapp v1alpha1.AppDeployKapp, cancelCh chan struct{}) (*ctldep.Kapp, error) {
+// deployBackendAnnKey selects which registered deploy backend deploys
+// the app; when absent the default kapp backend is used.
+const deployBackendAnnKey = "kappctrl.k14s.io/deploy-backend"
 
-	return a.deployFactory.NewKapp(kapp, a.app.Spec.ServiceAccountName,
+func (a *App) newDeploy(kapp v1alpha1.AppDeployKapp, cancelCh chan struct{}) (ctldep.Deploy, error) {
+
+	return a.deployFactory.NewDeploy(a.app.Annotations[deployBackendAnnKey], kapp, a.app.Spec.ServiceAccountName,
 		a.app.Spec.Cluster, cancelCh, kubeconfig.AccessLocation{Name: a.app.Name, Namespace: a.app.Namespace},
 		a.app.Spec.DefaultNamespace, a.app.Namespace,
 	)
 }
 
+// cancelAnnKey cancels an in-flight reconcile when set on the App (any
+// value), e.g. to abort a deploy stuck waiting on resources. Unlike
+// spec.canceled it does not require a spec change, so it can be applied
+// while the controller is mid-reconcile.
+const cancelAnnKey = "kappctrl.k14s.io/cancel"
+
+// orphanAnnKey skips deleting deployed resources when the App itself is
+// deleted, leaving them in the cluster unmanaged. Unlike spec.noopDelete
+// it can be set as a last-minute decision without a spec change.
+const orphanAnnKey = "kappctrl.k14s.io/orphan"
+
+func (a *App) orphaningResources() bool {
+	_, found := a.app.Annotations[orphanAnnKey]
+	return found
+}
+
 type cancelCondition func(v1alpha1.App) bool
 
 func onCanceled(app v1alpha1.App) bool {
 	return app.Spec.Canceled
 }
 
+func hasCancelAnnotation(app v1alpha1.App) bool {
+	_, found := app.Annotations[cancelAnnKey]
+	return found
+}
+
 func onDeleted(app v1alpha1.App) bool {
 	return app.DeletionTimestamp != nil
 }
@@ -5,6 +5,7 @@ package app
 
 import (
 	"path/filepath"
+	"time"
 
 	kcv1alpha1 "carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
 	kcclient "carvel.dev/kapp-controller/pkg/client/clientset/versioned"
@@ -34,6 +35,19 @@ type CRDAppFactory struct {
 	CompInfo         ComponentInfo
 	DeployFactory    deploy.Factory
 	CacheFolder      *memdir.TmpDir
+	HistoryRecorder  *ReconcileHistoryRecorder
+	ReconcilePauser  *ReconcilePauser
+	// Notifier is notified of terminal reconcile transitions (optional)
+	Notifier Notifier
+	// MaxResourceCount caps resources per App deploy (0 disables)
+	MaxResourceCount int
+	// ReconcileTimeout bounds a single App reconcile (0 disables)
+	ReconcileTimeout time.Duration
+	// DeletionTimeout bounds failing App deletions (0 disables)
+	DeletionTimeout time.Duration
+	// ForceFinalizerRemovalOnTimeout drops the delete finalizer
+	// after DeletionTimeout passes, possibly leaking resources
+	ForceFinalizerRemovalOnTimeout bool
 }
 
 // NewCRDApp creates a CRDApp injecting necessary dependencies.
@@ -48,8 +62,14 @@ func (f *CRDAppFactory) NewCRDApp(app *kcv1alpha1.App, log logr.Logger) *CRDApp
 	templateFactory := template.NewFactory(f.CoreClient, fetchFactory, f.KbldAllowBuild, f.CmdRunner)
 	deployFactory := deploy.NewFactory(f.CoreClient, f.Kubeconf, f.KcConfig, f.CmdRunner, log)
 
-	return NewCRDApp(app, log, f.AppMetrics, f.AppClient, fetchFactory, templateFactory, deployFactory, f.CompInfo, Opts{
-		DefaultSyncPeriod: f.KcConfig.AppDefaultSyncPeriod(),
-		MinimumSyncPeriod: f.KcConfig.AppMinimumSyncPeriod(),
-	})
+	crdApp := NewCRDApp(app, log, f.AppMetrics, f.AppClient, fetchFactory, templateFactory, deployFactory, f.CompInfo, Opts{
+		DefaultSyncPeriod:              f.KcConfig.AppDefaultSyncPeriod(),
+		MinimumSyncPeriod:              f.KcConfig.AppMinimumSyncPeriod(),
+		MaxResourceCount:               f.MaxResourceCount,
+		ReconcileTimeout:               f.ReconcileTimeout,
+		DeletionTimeout:                f.DeletionTimeout,
+		ForceFinalizerRemovalOnTimeout: f.ForceFinalizerRemovalOnTimeout,
+	}, f.HistoryRecorder, f.ReconcilePauser, f.Notifier)
+	crdApp.coreClient = f.CoreClient
+	return crdApp
 }
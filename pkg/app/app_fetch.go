@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
 	"carvel.dev/kapp-controller/pkg/exec"
 )
 
@@ -26,23 +27,63 @@ func (a *App) fetch(dstPath string) (string, exec.CmdRunResult) {
 
 	var result exec.CmdRunResult
 
+	a.fetchUsedSource = ""
+
+	attempts := a.fetchAttempts()
+	for i, sources := range attempts {
+		result = a.runFetchAttempt(sources, dstPath)
+		if result.Error == nil {
+			if len(attempts) > 1 {
+				a.fetchUsedSource = usedFetchSourceName(i)
+			}
+			break
+		}
+	}
+
+	// if only one fetch, update dstPath for backwards compatibility
+	if len(a.app.Spec.Fetch) == 1 && a.app.Spec.Fetch[0].Path == "" {
+		dstPath = path.Join(dstPath, "0")
+	}
+
+	if result.Error != nil {
+		if strings.Contains(result.Stderr, caCertMissingError) {
+			result.Stderr = fmt.Sprintf("%s%s", result.Stderr, addCACertMissingHintMsg)
+		}
+	}
+
+	return dstPath, result
+}
+
+// runFetchAttempt fetches the given sources (one per configured fetch
+// step) into dstPath via a single vendir run.
+func (a *App) runFetchAttempt(sources []v1alpha1.AppFetch, dstPath string) exec.CmdRunResult {
+	var result exec.CmdRunResult
+
 	vendir := a.fetchFactory.NewVendir(a.app.Namespace)
+	if a.app.Spec.Proxy != nil {
+		vendir.SetAppProxy(a.app.Spec.Proxy)
+	}
 
 	// Because vendir doesn't allow placing contents in the vendir root, we
 	// place all contents in sub dirs. For backwards compatibility, we must
 	// update dstPath to point to dstPath/0 if there is just one fetch step
-	for i, fetch := range a.app.Spec.Fetch {
+	pinDigest := a.fetchPinDigest()
+
+	for i, fetch := range sources {
+		if pinDigest != "" {
+			fetch = pinFetchToDigest(fetch, pinDigest)
+		}
 		err := vendir.AddDir(fetch, strconv.Itoa(i))
 		if err != nil {
 			result.AttachErrorf(fmt.Sprintf("Fetching (%d): ", i)+"%s", err)
-			return "", result
+			return result
 		}
 	}
 
 	conf, err := vendir.ConfigBytes()
 	if err != nil {
 		result.AttachErrorf("Fetching: %v", err)
-		return "", result
+		return result
 	}
 
 	result = vendir.Run(conf, dstPath, a.cacheID())
@@ -61,7 +102,7 @@ func (a *App) fetch(dstPath string) (string, exec.CmdRunResult) {
 			newConf, err := vendir.ConfigBytes()
 			if err != nil {
 				result.AttachErrorf("Fetching: %v", err)
-				return "", result
+				return result
 			}
 			if bytes.Equal(conf, newConf) {
 				// no secrets/configmaps have changed, no point in retrying
@@ -72,28 +113,44 @@ func (a *App) fetch(dstPath string) (string, exec.CmdRunResult) {
 				break
 			}
 		}
-		if result.Error != nil {
-			if strings.Contains(result.Stderr, caCertMissingError) {
-				result.Stderr = fmt.Sprintf("%s%s", result.Stderr, addCACertMissingHintMsg)
-			}
-			return "", result
-		}
-	}
-
-	// if only one fetch, update dstPath for backwards compatibility
-	if len(a.app.Spec.Fetch) == 1 && a.app.Spec.Fetch[0].Path == "" {
-		dstPath = path.Join(dstPath, "0")
-	}
-
-	if result.Error != nil {
-		if strings.Contains(result.Stderr, caCertMissingError) {
-			result.Stderr = fmt.Sprintf("%s%s", result.Stderr, addCACertMissingHintMsg)
-		}
 	}
 
-	return dstPath, result
+	return result
 }
 
 func (a *App) cacheID() string {
 	return string(a.app.ObjectMeta.UID)
 }
+
+// fetchSourceTypes returns the distinct fetch source types configured
+// on the app (e.g. "git", "image"), used as a metrics label dimension.
+func (a *App) fetchSourceTypes() []string {
+	var types []string
+	seen := map[string]bool{}
+
+	for _, fetch := range a.app.Spec.Fetch {
+		var sourceType string
+		switch {
+		case fetch.Inline != nil:
+			sourceType = "inline"
+		case fetch.Image != nil:
+			sourceType = "image"
+		case fetch.HTTP != nil:
+			sourceType = "http"
+		case fetch.Git != nil:
+			sourceType = "git"
+		case fetch.HelmChart != nil:
+			sourceType = "helmChart"
+		case fetch.ImgpkgBundle != nil:
+			sourceType = "imgpkgBundle"
+		default:
+			sourceType = "unknown"
+		}
+		if !seen[sourceType] {
+			seen[sourceType] = true
+			types = append(types, sourceType)
+		}
+	}
+
+	return types
+}
@@ -4,12 +4,16 @@
 package app
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	ctldep "carvel.dev/kapp-controller/pkg/deploy"
 	"carvel.dev/kapp-controller/pkg/exec"
 	"carvel.dev/kapp-controller/pkg/memdir"
+	"carvel.dev/kapp-controller/pkg/tracing"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -19,6 +23,8 @@ import (
 func (a *App) Reconcile(force bool) (reconcile.Result, error) {
 	defer a.flushUpdateStatus("app reconciled")
 
+	a.reconcileStartTime = time.Now()
+
 	var err error
 
 	a.appMetrics.ReconcileCountMetrics.InitMetrics(a.Kind(), a.Name(), a.Namespace())
@@ -66,6 +72,8 @@ func (a *App) reconcileDelete() error {
 		return err
 	}
 
+	clearTemplateRenderCache(string(a.app.UID))
+
 	err = a.updateStatus("marking deleting")
 	if err != nil {
 		return err
@@ -103,6 +111,9 @@ func (a *App) reconcileDeploy() error {
 }
 
 func (a *App) reconcileFetchTemplateDeploy() exec.CmdRunResult {
+	tracingCtx, reconcileSpan := tracing.StartReconcileSpan(context.Background(), a.Kind(), a.Name(), a.Namespace())
+	defer reconcileSpan.End()
+
 	reconcileStartTime := time.Now()
 	a.appMetrics.IsFirstReconcile = a.appMetrics.ReconcileCountMetrics.GetReconcileAttemptCounterValue(a.Kind(), a.Name(), a.Namespace()) == 1
 	defer func() {
@@ -123,17 +134,25 @@ func (a *App) reconcileFetchTemplateDeploy() exec.CmdRunResult {
 
 	{
 		a.resetLastFetchStartedAt()
+		a.notifyStageStarted("fetch")
 
 		var fetchResult exec.CmdRunResult
+		_, fetchSpan := tracing.StartStageSpan(tracingCtx, "fetch")
 		assetsPath, fetchResult = a.fetch(assetsPath)
+		tracing.RecordError(fetchSpan, fetchResult.Error)
+		fetchSpan.End()
+		a.notifyStageCompleted("fetch", fetchResult.Error)
 
 		a.app.Status.Fetch = &v1alpha1.AppStatusFetch{
-			Stderr:    fetchResult.Stderr,
-			Stdout:    fetchResult.Stdout,
-			ExitCode:  fetchResult.ExitCode,
-			Error:     fetchResult.ErrorStr(),
-			StartedAt: a.app.Status.Fetch.StartedAt,
-			UpdatedAt: metav1.NewTime(time.Now().UTC()),
+			Stderr:         fetchResult.Stderr,
+			Stdout:         fetchResult.Stdout,
+			ExitCode:       fetchResult.ExitCode,
+			Error:          fetchResult.ErrorStr(),
+			StartedAt:      a.app.Status.Fetch.StartedAt,
+			UpdatedAt:      metav1.NewTime(time.Now().UTC()),
+			PinnedDigest:   a.fetchPinDigest(),
+			UsedSource:     a.fetchUsedSource,
+			ResolvedGitSHA: resolvedGitSHA(fetchResult.Stdout),
 		}
 
 		a.appMetrics.ReconcileTimeMetrics.RegisterFetchTime(a.Kind(), a.Name(), a.Namespace(), a.appMetrics.IsFirstReconcile,
@@ -150,14 +169,33 @@ func (a *App) reconcileFetchTemplateDeploy() exec.CmdRunResult {
 	}
 
 	templateStartTime := time.Now()
+	a.notifyStageStarted("template")
 
+	// Prime the render cache from the digest persisted in status so a
+	// controller restart does not treat an unchanged render as new
+	if a.app.Status.Template != nil {
+		seedTemplateRenderHash(string(a.app.UID), a.app.Status.Template.Digest)
+	}
+
+	_, templateSpan := tracing.StartStageSpan(tracingCtx, "template")
 	tplResult := a.template(assetsPath)
+	tracing.RecordError(templateSpan, tplResult.Error)
+	templateSpan.End()
+	a.notifyStageCompleted("template", tplResult.Error)
+
+	tplDigest := ""
+	if tplResult.Error == nil {
+		tplDigest = templateOutputDigest(tplResult.Stdout)
+	}
 
 	a.app.Status.Template = &v1alpha1.AppStatusTemplate{
-		Stderr:    tplResult.Stderr,
-		ExitCode:  tplResult.ExitCode,
-		Error:     tplResult.ErrorStr(),
-		UpdatedAt: metav1.NewTime(time.Now().UTC()),
+		Stderr:         tplResult.Stderr,
+		ExitCode:       tplResult.ExitCode,
+		Error:          tplResult.ErrorStr(),
+		UpdatedAt:      metav1.NewTime(time.Now().UTC()),
+		ResolvedImages: parseKbldResolvedImages(tplResult.Stderr),
+		Digest:         tplDigest,
+		DataValues:     a.lastTemplateDataValues,
 	}
 
 	a.appMetrics.ReconcileTimeMetrics.RegisterTemplateTime(a.Kind(), a.Name(), a.Namespace(), a.appMetrics.IsFirstReconcile,
@@ -172,9 +210,28 @@ func (a *App) reconcileFetchTemplateDeploy() exec.CmdRunResult {
 		return tplResult
 	}
 
+	// Printed only at higher log verbosity; renders can be large
+	if diff := templateOutputDiff(string(a.app.UID), tplResult.Stdout); diff != "" {
+		a.log.V(1).Info("Templated output changed since last reconcile", "diff", diff)
+	}
+
+	if a.opts.MaxResourceCount > 0 {
+		if count := countResources(tplResult.Stdout); count > a.opts.MaxResourceCount {
+			return exec.NewCmdRunResultWithErr(fmt.Errorf(
+				"Templated output has %d resources, exceeding the configured max of %d", count, a.opts.MaxResourceCount))
+		}
+	}
+
 	a.resetLastDeployStartedAt()
+	a.notifyStageStarted("deploy")
+
+	_, deploySpan := tracing.StartStageSpan(tracingCtx, "deploy")
+	deployResult := a.updateLastDeploy(a.deploy(tplResult.Stdout, a.updateLastDeployNoReturn))
+	tracing.RecordError(deploySpan, deployResult.Error)
+	deploySpan.End()
+	a.notifyStageCompleted("deploy", deployResult.Error)
 
-	return a.updateLastDeploy(a.deploy(tplResult.Stdout, a.updateLastDeployNoReturn))
+	return deployResult
 }
 
 func (a *App) updateLastDeploy(result exec.CmdRunResult) exec.CmdRunResult {
@@ -189,6 +246,16 @@ func (a *App) updateLastDeploy(result exec.CmdRunResult) exec.CmdRunResult {
 		StartedAt:        a.app.Status.Deploy.StartedAt,
 		UpdatedAt:        metav1.NewTime(time.Now().UTC()),
 		KappDeployStatus: a.app.Status.Deploy.KappDeployStatus,
+		DryRun:           a.dryRun() && a.app.DeletionTimestamp == nil,
+	}
+
+	if result.Error != nil {
+		a.app.Status.Deploy.WaitFailures = ctldep.ParseWaitFailures(result.Stderr)
+	}
+
+	if ready, total, found := ctldep.ParseResourceProgress(result.Stdout); found {
+		a.app.Status.Deploy.ResourcesReady = ready
+		a.app.Status.Deploy.ResourcesTotal = total
 	}
 
 	defer a.updateStatus("marking last deploy")
@@ -272,7 +339,12 @@ func (a *App) setReconciling() {
 	})
 
 	a.appMetrics.ReconcileCountMetrics.RegisterReconcileAttempt(a.Kind(), a.Name(), a.Namespace())
+	a.app.Status.TotalReconcileAttempts++
 	a.app.Status.FriendlyDescription = "Reconciling"
+
+	if kcVersion, err := a.compInfo.KappControllerVersion(); err == nil {
+		a.app.Status.ControllerVersion = kcVersion.String()
+	}
 }
 
 func (a *App) setReconcileCompleted(result exec.CmdRunResult) {
@@ -288,17 +360,37 @@ func (a *App) setReconcileCompleted(result exec.CmdRunResult) {
 		a.app.Status.ConsecutiveReconcileSuccesses = 0
 		a.app.Status.FriendlyDescription = fmt.Sprintf("Reconcile failed: %s", result.ErrorStr())
 		a.appMetrics.ReconcileCountMetrics.RegisterReconcileFailure(a.Kind(), a.Name(), a.Namespace())
+		for _, sourceType := range a.fetchSourceTypes() {
+			a.appMetrics.ReconcileCountMetrics.RegisterReconcileFailureForSource(a.Kind(), sourceType)
+		}
 		a.setUsefulErrorMessage(result)
 	} else {
+		succeededMsg := ""
+		if a.dryRun() && a.app.DeletionTimestamp == nil {
+			succeededMsg = "Dry run: diff recorded, no changes applied"
+		}
 		a.app.Status.Conditions = append(a.app.Status.Conditions, v1alpha1.Condition{
 			Type:    v1alpha1.ReconcileSucceeded,
 			Status:  corev1.ConditionTrue,
-			Message: "",
+			Message: succeededMsg,
 		})
 		a.app.Status.ConsecutiveReconcileSuccesses++
 		a.app.Status.ConsecutiveReconcileFailures = 0
-		a.app.Status.FriendlyDescription = "Reconcile succeeded"
+		if len(succeededMsg) == 0 {
+			a.app.Status.LastReconciledGeneration = a.app.Generation
+			a.app.Status.LastSuccessfulReconcile = metav1.Now()
+			a.app.Status.FriendlyDescription = "Reconcile succeeded"
+		} else {
+			// A dry run applied nothing, so the generation must not be
+			// marked reconciled; otherwise removing the annotation
+			// (which does not bump the generation) would leave the App
+			// looking up to date and skip the real deploy
+			a.app.Status.FriendlyDescription = "Dry run succeeded (no changes applied)"
+		}
 		a.appMetrics.ReconcileCountMetrics.RegisterReconcileSuccess(a.Kind(), a.Name(), a.Namespace())
+		for _, sourceType := range a.fetchSourceTypes() {
+			a.appMetrics.ReconcileCountMetrics.RegisterReconcileSuccessForSource(a.Kind(), sourceType)
+		}
 		a.app.Status.UsefulErrorMessage = ""
 	}
 }
@@ -312,6 +404,7 @@ func (a *App) setDeleting() {
 	})
 
 	a.appMetrics.ReconcileCountMetrics.RegisterReconcileDeleteAttempt(a.Kind(), a.Name(), a.Namespace())
+	a.app.Status.TotalReconcileAttempts++
 	a.app.Status.FriendlyDescription = "Deleting"
 }
 
@@ -346,3 +439,15 @@ func (a *App) setUsefulErrorMessage(result exec.CmdRunResult) {
 		a.app.Status.UsefulErrorMessage = result.ErrorStr()
 	}
 }
+
+// countResources counts the non-empty YAML documents in templated
+// output, which is how many resources a deploy would apply.
+func countResources(stdout string) int {
+	count := 0
+	for _, doc := range strings.Split("\n"+stdout, "\n---\n") {
+		if strings.TrimSpace(doc) != "" {
+			count++
+		}
+	}
+	return count
+}
@@ -51,7 +51,7 @@ func Test_NoInspectReconcile_IfNoDeployAttempted(t *testing.T) {
 	tmpFac := template.NewFactory(k8scs, fetchFac, false, exec.NewPlainCmdRunner())
 	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, exec.NewPlainCmdRunner(), log)
 
-	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{}, Opts{MinimumSyncPeriod: 30 * time.Second})
+	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{}, Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
 	_, err := crdApp.Reconcile(false)
 	assert.Nil(t, err, "unexpected error with reconciling", err)
 
@@ -73,6 +73,8 @@ func Test_NoInspectReconcile_IfNoDeployAttempted(t *testing.T) {
 			ExitCode: 1,
 		},
 		ConsecutiveReconcileFailures: 1,
+		TotalReconcileAttempts:       1,
+		ControllerVersion:            "0.0.0",
 	}
 
 	crdApp.app.Status().Fetch.StartedAt = metav1.Time{}
@@ -117,7 +119,7 @@ func Test_NoInspectReconcile_IfInspectNotEnabled(t *testing.T) {
 	tmpFac := template.NewFactory(k8scs, fetchFac, false, exec.NewPlainCmdRunner())
 	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, exec.NewPlainCmdRunner(), log)
 
-	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{}, Opts{MinimumSyncPeriod: 30 * time.Second})
+	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{}, Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
 	_, err := crdApp.Reconcile(false)
 	assert.Nil(t, err, "unexpected error with reconciling", err)
 
@@ -139,6 +141,8 @@ func Test_NoInspectReconcile_IfInspectNotEnabled(t *testing.T) {
 			ExitCode: 0,
 		},
 		ConsecutiveReconcileFailures: 1,
+		TotalReconcileAttempts:       1,
+		ControllerVersion:            "0.0.0",
 		Template: &v1alpha1.AppStatusTemplate{
 			ExitCode: 0,
 		},
@@ -188,7 +192,7 @@ func Test_TemplateError_DisplayedInStatus_UsefulErrorMessageProperty(t *testing.
 	tmpFac := template.NewFactory(k8scs, fetchFac, false, exec.NewPlainCmdRunner())
 	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, exec.NewPlainCmdRunner(), log)
 
-	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{}, Opts{MinimumSyncPeriod: 30 * time.Second})
+	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{}, Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
 	_, err := crdApp.Reconcile(false)
 	assert.Nil(t, err, "Unexpected error with reconciling", err)
 
@@ -213,6 +217,8 @@ func Test_TemplateError_DisplayedInStatus_UsefulErrorMessageProperty(t *testing.
 			ExitCode: 1,
 		},
 		ConsecutiveReconcileFailures: 1,
+		TotalReconcileAttempts:       1,
+		ControllerVersion:            "0.0.0",
 	}
 
 	// Unset time for assertions
@@ -245,7 +251,9 @@ func (f FakeComponentInfo) KubernetesAPIs() ([]string, error) {
 }
 
 func (f FakeComponentInfo) KappControllerVersion() (semver.Version, error) {
-	*f.KCVersionCount++
+	if f.KCVersionCount != nil {
+		*f.KCVersionCount++
+	}
 	return f.KCVersion, nil
 }
 
@@ -25,6 +25,7 @@ func (a *App) template(dirPath string) exec.CmdRunResult {
 
 	var result exec.CmdRunResult
 	var isStream bool
+	var dataValuesDocs []string
 
 	additionalValues := a.buildDownwardAPIAdditionalValues()
 
@@ -47,6 +48,16 @@ func (a *App) template(dirPath string) exec.CmdRunResult {
 			return result
 		}
 
+		// Capture the (redacted) data values this step consumes so they
+		// can be surfaced in status for reproducibility
+		if valuesFrom := ctltpl.ValuesSources(tpl); len(valuesFrom) > 0 {
+			vals := a.templateFactory.NewValues(valuesFrom, appContext, additionalValues)
+			resolved, err := vals.Resolved(dirPath)
+			if err == nil && resolved != "" {
+				dataValuesDocs = append(dataValuesDocs, resolved)
+			}
+		}
+
 		if isStream {
 			result = template.TemplateStream(strings.NewReader(result.Stdout), dirPath)
 		} else {
@@ -57,6 +68,8 @@ func (a *App) template(dirPath string) exec.CmdRunResult {
 		}
 	}
 
+	a.lastTemplateDataValues = strings.Join(dataValuesDocs, "---\n")
+
 	return result
 }
 
@@ -0,0 +1,106 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_CancelAnnotation_CancelsInFlightReconcile(t *testing.T) {
+	log := logf.Log.WithName("kc")
+
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      "simple-app",
+			Namespace: "pkg-standalone",
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+
+	kubeconfigSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-kubeconfig", Namespace: "pkg-standalone"},
+		Data: map[string][]byte{"value": []byte(`
+apiVersion: v1
+kind: Config
+clusters:
+- cluster: {server: https://cluster.example.com}
+  name: dst-cluster
+users:
+- user: {token: token}
+  name: dst-user
+contexts:
+- context: {cluster: dst-cluster, user: dst-user}
+  name: dst-ctx
+current-context: dst-ctx
+`)},
+	}
+
+	// Deploy blocks until canceled, simulating a stuck reconcile
+	cmdRunner := blockingCmdRunner{}
+	k8scs := k8sfake.NewSimpleClientset(kubeconfigSecret)
+	kappcs := fake.NewSimpleClientset(&app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
+
+	reconcileDone := make(chan error)
+	go func() {
+		_, err := crdApp.Reconcile(false)
+		reconcileDone <- err
+	}()
+
+	// Set the cancel annotation while the deploy is blocked
+	time.Sleep(300 * time.Millisecond)
+	existingApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "simple-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	existingApp.Annotations = map[string]string{cancelAnnKey: ""}
+	_, err = kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Update(context.Background(), existingApp, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	select {
+	case err := <-reconcileDone:
+		assert.Nil(t, err, "unexpected error with reconciling", err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("Expected cancel annotation to unblock the in-flight reconcile")
+	}
+
+	status := crdApp.app.Status()
+	require.NotNil(t, status.Deploy)
+	assert.Contains(t, status.Deploy.Error, "canceled by user")
+	assert.Contains(t, status.FriendlyDescription, "Reconcile failed")
+}
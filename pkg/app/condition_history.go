@@ -0,0 +1,49 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"time"
+
+	kcv1alpha1 "carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// conditionHistoryMaxLen bounds how many condition transitions are kept
+// in status; older transitions are dropped first.
+const conditionHistoryMaxLen = 10
+
+// appendConditionTransitions extends the condition history with any
+// conditions present in newConds but not in prevConds (i.e. new
+// transitions), keeping the history bounded to conditionHistoryMaxLen
+// entries (oldest dropped first).
+func appendConditionTransitions(history []kcv1alpha1.AppConditionTransition,
+	prevConds []kcv1alpha1.Condition, newConds []kcv1alpha1.Condition) []kcv1alpha1.AppConditionTransition {
+
+	for _, cond := range newConds {
+		if hasCondition(prevConds, cond) {
+			continue
+		}
+		history = append(history, kcv1alpha1.AppConditionTransition{
+			Type:           cond.Type,
+			Status:         cond.Status,
+			Message:        cond.Message,
+			TransitionTime: metav1.NewTime(time.Now()),
+		})
+	}
+
+	if len(history) > conditionHistoryMaxLen {
+		history = history[len(history)-conditionHistoryMaxLen:]
+	}
+	return history
+}
+
+func hasCondition(conds []kcv1alpha1.Condition, cond kcv1alpha1.Condition) bool {
+	for _, c := range conds {
+		if c.Type == cond.Type && c.Status == cond.Status {
+			return true
+		}
+	}
+	return false
+}
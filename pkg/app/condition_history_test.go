@@ -0,0 +1,52 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestConditionHistoryRecordsTransitions(t *testing.T) {
+	reconciling := v1alpha1.Condition{Type: v1alpha1.Reconciling, Status: corev1.ConditionTrue}
+	succeeded := v1alpha1.Condition{Type: v1alpha1.ReconcileSucceeded, Status: corev1.ConditionTrue, Message: "all good"}
+
+	history := appendConditionTransitions(nil, nil, []v1alpha1.Condition{reconciling})
+	require.Len(t, history, 1)
+	assert.Equal(t, v1alpha1.Reconciling, history[0].Type)
+	assert.False(t, history[0].TransitionTime.IsZero())
+
+	history = appendConditionTransitions(history, []v1alpha1.Condition{reconciling}, []v1alpha1.Condition{succeeded})
+	require.Len(t, history, 2)
+	assert.Equal(t, v1alpha1.ReconcileSucceeded, history[1].Type)
+	assert.Equal(t, "all good", history[1].Message)
+}
+
+func TestConditionHistoryIgnoresUnchangedConditions(t *testing.T) {
+	succeeded := v1alpha1.Condition{Type: v1alpha1.ReconcileSucceeded, Status: corev1.ConditionTrue}
+
+	history := appendConditionTransitions(nil, []v1alpha1.Condition{succeeded}, []v1alpha1.Condition{succeeded})
+	assert.Empty(t, history)
+}
+
+func TestConditionHistoryIsBounded(t *testing.T) {
+	var history []v1alpha1.AppConditionTransition
+
+	reconciling := v1alpha1.Condition{Type: v1alpha1.Reconciling, Status: corev1.ConditionTrue}
+	succeeded := v1alpha1.Condition{Type: v1alpha1.ReconcileSucceeded, Status: corev1.ConditionTrue}
+
+	// Alternate the two conditions well past the bound
+	for i := 0; i < conditionHistoryMaxLen; i++ {
+		history = appendConditionTransitions(history, nil, []v1alpha1.Condition{reconciling})
+		history = appendConditionTransitions(history, nil, []v1alpha1.Condition{succeeded})
+	}
+
+	require.Len(t, history, conditionHistoryMaxLen)
+	// Oldest entries dropped first; the latest transition is retained
+	assert.Equal(t, v1alpha1.ReconcileSucceeded, history[len(history)-1].Type)
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/k14s/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_Reconcile_RecordsControllerVersion(t *testing.T) {
+	log := logf.Log.WithName("kc")
+
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      "simple-app",
+			Namespace: "pkg-standalone",
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+
+	cmdRunner := noopTracingCmdRunner{}
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(&app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	compInfo := FakeComponentInfo{KCVersion: semver.MustParse("0.57.0")}
+	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, compInfo,
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
+
+	_, err := crdApp.Reconcile(true)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+
+	assert.Equal(t, "0.57.0", crdApp.app.Status().ControllerVersion,
+		"Expected status to record the controller version that reconciled the app")
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"time"
 
 	kcv1alpha1 "carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
 	kcclient "carvel.dev/kapp-controller/pkg/client/clientset/versioned"
@@ -17,22 +18,37 @@ import (
 	"carvel.dev/kapp-controller/pkg/template"
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 type CRDApp struct {
-	app       *App
-	appModel  *kcv1alpha1.App
-	log       logr.Logger
-	appClient kcclient.Interface
+	app             *App
+	appModel        *kcv1alpha1.App
+	log             logr.Logger
+	appClient       kcclient.Interface
+	historyRecorder *ReconcileHistoryRecorder
+	pauser          *ReconcilePauser
+	notifier        Notifier
+	appMetrics      *metrics.Metrics
+
+	// coreClient (optional) enables the referenced-secret existence
+	// gate; without it the gate is skipped
+	coreClient kubernetes.Interface
+
+	// statusAPIVersion caches the negotiated kappctrl API group
+	// version used for status updates ("" until first negotiation)
+	statusAPIVersion string
 }
 
 // NewCRDApp creates new CRD app
 func NewCRDApp(appModel *kcv1alpha1.App, log logr.Logger, appMetrics *metrics.Metrics, appClient kcclient.Interface, fetchFactory fetch.Factory,
 	templateFactory template.Factory, deployFactory deploy.Factory,
-	compInfo ComponentInfo, opts Opts) *CRDApp {
+	compInfo ComponentInfo, opts Opts, historyRecorder *ReconcileHistoryRecorder, pauser *ReconcilePauser, notifier Notifier) *CRDApp {
 
-	crdApp := &CRDApp{appModel: appModel, log: log, appClient: appClient}
+	crdApp := &CRDApp{appModel: appModel, log: log, appClient: appClient, historyRecorder: historyRecorder,
+		pauser: pauser, notifier: notifier, appMetrics: appMetrics}
 
 	crdApp.app = NewApp(*appModel, Hooks{
 		BlockDeletion:   crdApp.blockDeletion,
@@ -81,22 +97,84 @@ func (a *CRDApp) updateStatus(desc string) error {
 }
 
 func (a *CRDApp) updateStatusOnce() error {
+	// Status updates go through the negotiated served version; only
+	// v1alpha1 has a generated client today, so other negotiated
+	// versions are rejected rather than silently downgraded
+	if version := a.negotiatedStatusAPIVersion(); version != "v1alpha1" {
+		return fmt.Errorf("Expected status API version '%s' to be supported by the client, but it is not", version)
+	}
+
 	existingApp, err := a.appClient.KappctrlV1alpha1().Apps(a.appModel.Namespace).Get(context.Background(), a.appModel.Name, metav1.GetOptions{})
 	if err != nil {
 		return fmt.Errorf("Fetching app: %s", err)
 	}
 
-	if !reflect.DeepEqual(existingApp.Status, a.app.Status()) {
-		existingApp.Status = a.app.Status()
+	newStatus := a.app.Status()
+	// Condition history lives only in the cluster copy; carry it
+	// forward, extended with any transitions this status introduces
+	newStatus.ConditionHistory = appendConditionTransitions(
+		existingApp.Status.ConditionHistory, existingApp.Status.Conditions, newStatus.Conditions)
+
+	if !reflect.DeepEqual(existingApp.Status, newStatus) {
+		prevStatus := existingApp.Status
+		existingApp.Status = newStatus
 		_, err = a.appClient.KappctrlV1alpha1().Apps(existingApp.Namespace).UpdateStatus(context.Background(), existingApp, metav1.UpdateOptions{})
 		if err != nil {
 			return err
 		}
+		a.notifyTerminalTransition(prevStatus, existingApp.Status)
 	}
 
 	return nil
 }
 
+// negotiatedStatusAPIVersion resolves (once per CRDApp) which kappctrl
+// API group version status updates go through, preferring the version
+// the server reports as served. Falls back to v1alpha1 when discovery
+// cannot be consulted so status updates keep working against minimal
+// clients.
+func (a *CRDApp) negotiatedStatusAPIVersion() string {
+	if a.statusAPIVersion == "" {
+		version, err := negotiateStatusAPIVersion(a.appClient.Discovery())
+		if err != nil {
+			version = "v1alpha1"
+		}
+		a.statusAPIVersion = version
+	}
+	return a.statusAPIVersion
+}
+
+// notifyTerminalTransition fires the configured notifier (if any) when
+// the status transitions into a terminal reconcile state. Notification
+// happens in a separate goroutine to avoid blocking status updates.
+func (a *CRDApp) notifyTerminalTransition(prevStatus, newStatus kcv1alpha1.AppStatus) {
+	if a.notifier == nil {
+		return
+	}
+
+	newCond, terminal := terminalCondition(newStatus.GenericStatus)
+	if !terminal {
+		return
+	}
+	if prevCond, prevTerminal := terminalCondition(prevStatus.GenericStatus); prevTerminal && prevCond == newCond {
+		return
+	}
+
+	event := ReconcileEvent{
+		Name:        a.appModel.Name,
+		Namespace:   a.appModel.Namespace,
+		Succeeded:   newCond == kcv1alpha1.ReconcileSucceeded,
+		Description: newStatus.FriendlyDescription,
+	}
+
+	go func() {
+		err := a.notifier.Notify(event)
+		if err != nil {
+			a.log.Error(err, "Notifying reconcile event")
+		}
+	}()
+}
+
 func (a *CRDApp) updateApp(updateFunc func(*kcv1alpha1.App)) error {
 	a.log.Info("Updating app")
 
@@ -116,7 +194,148 @@ func (a *CRDApp) updateApp(updateFunc func(*kcv1alpha1.App)) error {
 }
 
 func (a *CRDApp) Reconcile(force bool) (reconcile.Result, error) {
-	return a.app.Reconcile(force)
+	// Correlation ID scoping log entries to this reconcile
+	log := a.log.WithValues("reconcile-id", string(uuid.NewUUID()))
+
+	if a.appMetrics != nil && a.appMetrics.ReconcileInFlightMetrics != nil {
+		a.appMetrics.RegisterInFlightStart()
+		// Decremented via defer so panics do not leak in-flight reconciles
+		defer a.appMetrics.RegisterInFlightEnd()
+	}
+
+	if a.pauser != nil {
+		paused, pauseErr := a.pauser.IsPaused()
+		switch {
+		case pauseErr != nil:
+			log.Error(pauseErr, "Checking global reconcile pause")
+		case paused:
+			log.Info("Skipping reconcile: globally paused")
+			markErr := a.markGloballyPaused()
+			if markErr != nil {
+				log.Error(markErr, "Marking app globally paused")
+			}
+			return reconcile.Result{RequeueAfter: 30 * time.Second}, nil
+		}
+	}
+
+	// Deletion is never gated on dependencies
+	if a.appModel.DeletionTimestamp == nil {
+		pendingDep, depErr := a.unreconciledDependency()
+		if depErr != nil {
+			log.Error(depErr, "Checking app dependencies")
+		}
+		if pendingDep != nil {
+			log.Info("Skipping reconcile: waiting for dependency", "dependency", pendingDep.Description())
+			markErr := a.markWaitingForDependency(*pendingDep)
+			if markErr != nil {
+				log.Error(markErr, "Marking app waiting for dependency")
+			}
+			return reconcile.Result{RequeueAfter: dependencyRequeuePeriod}, nil
+		}
+	}
+
+	// A referenced secret that does not exist yet would make fetch fail
+	// repeatedly; wait for it instead. Deletion is never gated on it.
+	if a.appModel.DeletionTimestamp == nil {
+		missingSecret, secretErr := a.missingReferencedSecret()
+		if secretErr != nil {
+			log.Error(secretErr, "Checking referenced secrets")
+		}
+		if missingSecret != "" {
+			log.Info("Skipping reconcile: waiting for referenced secret", "secret", missingSecret)
+			markErr := a.markWaitingForSecret(missingSecret)
+			if markErr != nil {
+				log.Error(markErr, "Marking app waiting for secret")
+			}
+			return reconcile.Result{RequeueAfter: missingSecretRequeuePeriod}, nil
+		}
+	}
+
+	// Deletion is never gated on sync windows
+	if a.appModel.DeletionTimestamp == nil {
+		inWindow, windowErr := withinSyncWindows(a.appModel.Spec.SyncWindows, syncWindowNow())
+		if windowErr != nil {
+			log.Error(windowErr, "Checking sync windows")
+		}
+		if windowErr == nil && !inWindow {
+			log.Info("Skipping reconcile: outside of configured sync windows")
+			markErr := a.markOutsideSyncWindow()
+			if markErr != nil {
+				log.Error(markErr, "Marking app outside sync window")
+			}
+			return reconcile.Result{RequeueAfter: syncWindowRequeuePeriod}, nil
+		}
+	}
+
+	if !force && a.app.ReconcileUpToDate() {
+		log.Info("Skipping reconcile: spec generation unchanged since last successful reconcile")
+		return reconcile.Result{RequeueAfter: a.app.DurationUntilResync()}, nil
+	}
+
+	a.app.SetLogger(log)
+
+	result, err := a.app.Reconcile(force)
+	annErr := a.recordResolvedGitSHA()
+	if annErr != nil {
+		log.Error(annErr, "Recording resolved git commit")
+	}
+	if a.historyRecorder != nil {
+		recordErr := a.historyRecorder.Record(a.appModel.Name, a.appModel.Namespace, a.app.Status())
+		if recordErr != nil {
+			log.Error(recordErr, "Recording reconcile history")
+		}
+	}
+
+	// A deploy that is still converging should be revisited sooner
+	// than the full sync period so status reflects progress
+	if err == nil && a.deployInProgress() && result.RequeueAfter > deployProgressRequeuePeriod {
+		result.RequeueAfter = deployProgressRequeuePeriod
+	}
+
+	return result, err
+}
+
+// deployProgressRequeuePeriod is how soon a reconcile is requeued while
+// the last deploy is still converging (vs the full sync period).
+const deployProgressRequeuePeriod = 10 * time.Second
+
+// deployInProgress reports whether the last deploy succeeded so far but
+// is still converging: it has not finished, or kapp reported not all
+// resources ready yet.
+func (a *CRDApp) deployInProgress() bool {
+	status := a.app.Status()
+	for _, cond := range status.Conditions {
+		if cond.Type == kcv1alpha1.ReconcileFailed || cond.Type == kcv1alpha1.DeleteFailed {
+			return false
+		}
+	}
+
+	deploy := status.Deploy
+	if deploy == nil {
+		return false
+	}
+	if !deploy.Finished {
+		return true
+	}
+	return deploy.ResourcesTotal > 0 && deploy.ResourcesReady < deploy.ResourcesTotal
+}
+
+// markGloballyPaused records a status note indicating that reconciliation
+// is globally paused, leaving the rest of the status (and any deployed
+// resources) untouched.
+func (a *CRDApp) markGloballyPaused() error {
+	existingApp, err := a.appClient.KappctrlV1alpha1().Apps(a.appModel.Namespace).Get(context.Background(), a.appModel.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Fetching app: %s", err)
+	}
+
+	if existingApp.Status.FriendlyDescription == globallyPausedDesc {
+		return nil
+	}
+
+	existingApp.Status.FriendlyDescription = globallyPausedDesc
+	_, err = a.appClient.KappctrlV1alpha1().Apps(existingApp.Namespace).UpdateStatus(context.Background(), existingApp, metav1.UpdateOptions{})
+	return err
 }
 
 func (a *CRDApp) watchChanges(callback func(kcv1alpha1.App), cancelCh chan struct{}) error {
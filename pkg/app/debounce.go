@@ -0,0 +1,68 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"sync"
+	"time"
+)
+
+// specDebouncer coalesces rapid successive spec edits: a reconcile for
+// a freshly bumped generation is postponed until no further bump has
+// arrived within the quiet period, so several quick edits result in a
+// single reconcile of the final spec.
+type specDebouncer struct {
+	quietPeriod time.Duration
+
+	lock sync.Mutex
+	seen map[string]generationBump
+}
+
+type generationBump struct {
+	generation int64
+	seenAt     time.Time
+}
+
+// newSpecDebouncer returns a debouncer with the given quiet period
+// (0 disables debouncing).
+func newSpecDebouncer(quietPeriod time.Duration) *specDebouncer {
+	return &specDebouncer{quietPeriod: quietPeriod, seen: map[string]generationBump{}}
+}
+
+// postpone reports whether reconciling the given generation should be
+// postponed, and for how long. The first observation of an app and
+// generations that have been stable for the quiet period proceed
+// immediately.
+func (d *specDebouncer) postpone(key string, generation int64, now time.Time) (bool, time.Duration) {
+	if d.quietPeriod == 0 {
+		return false, 0
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	last, found := d.seen[key]
+	if !found {
+		d.seen[key] = generationBump{generation: generation, seenAt: now}
+		return false, 0
+	}
+
+	if generation != last.generation {
+		d.seen[key] = generationBump{generation: generation, seenAt: now}
+		return true, d.quietPeriod
+	}
+
+	if elapsed := now.Sub(last.seenAt); elapsed < d.quietPeriod {
+		return true, d.quietPeriod - elapsed
+	}
+
+	return false, 0
+}
+
+// forget drops debounce state for an app (e.g. once it is deleted).
+func (d *specDebouncer) forget(key string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	delete(d.seen, key)
+}
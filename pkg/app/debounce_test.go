@@ -0,0 +1,69 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_SpecDebouncer_CoalescesRapidEdits(t *testing.T) {
+	debouncer := newSpecDebouncer(5 * time.Second)
+	now := time.Now()
+
+	// First observation of the app proceeds immediately
+	postpone, _ := debouncer.postpone("ns/app", 1, now)
+	assert.False(t, postpone)
+
+	// Three quick edits each reset the quiet period
+	postpone, after := debouncer.postpone("ns/app", 2, now.Add(1*time.Second))
+	assert.True(t, postpone)
+	assert.Equal(t, 5*time.Second, after)
+
+	postpone, after = debouncer.postpone("ns/app", 3, now.Add(2*time.Second))
+	assert.True(t, postpone)
+	assert.Equal(t, 5*time.Second, after)
+
+	postpone, after = debouncer.postpone("ns/app", 4, now.Add(3*time.Second))
+	assert.True(t, postpone)
+	assert.Equal(t, 5*time.Second, after)
+
+	// Requeue with the same generation inside the quiet window waits
+	// out the remainder
+	postpone, after = debouncer.postpone("ns/app", 4, now.Add(5*time.Second))
+	assert.True(t, postpone)
+	assert.Equal(t, 3*time.Second, after)
+
+	// Once the final spec has been stable for the quiet period a single
+	// reconcile proceeds
+	postpone, _ = debouncer.postpone("ns/app", 4, now.Add(8*time.Second))
+	assert.False(t, postpone)
+}
+
+func Test_SpecDebouncer_DisabledWithoutQuietPeriod(t *testing.T) {
+	debouncer := newSpecDebouncer(0)
+	now := time.Now()
+
+	for generation := int64(1); generation <= 3; generation++ {
+		postpone, _ := debouncer.postpone("ns/app", generation, now)
+		assert.False(t, postpone)
+	}
+}
+
+func Test_SpecDebouncer_ForgetClearsState(t *testing.T) {
+	debouncer := newSpecDebouncer(5 * time.Second)
+	now := time.Now()
+
+	postpone, _ := debouncer.postpone("ns/app", 1, now)
+	assert.False(t, postpone)
+
+	debouncer.forget("ns/app")
+
+	// After forgetting, a new observation proceeds immediately even
+	// with a bumped generation
+	postpone, _ = debouncer.postpone("ns/app", 2, now.Add(1*time.Second))
+	assert.False(t, postpone)
+}
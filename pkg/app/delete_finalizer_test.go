@@ -0,0 +1,140 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	goexec "os/exec"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// deleteVerifyCmdRunner succeeds for kapp delete and reports whether the
+// kapp app still exists when inspected afterwards. A non-nil inspectErr
+// simulates a transient inspect failure (no "does not exist" output).
+type deleteVerifyCmdRunner struct {
+	appStillExists bool
+	inspectErr     error
+}
+
+func (deleteVerifyCmdRunner) Run(*goexec.Cmd) error { return nil }
+
+func (r deleteVerifyCmdRunner) RunWithCancel(cmd *goexec.Cmd, _ chan struct{}) error {
+	if len(cmd.Args) > 1 && cmd.Args[1] == "inspect" {
+		if r.inspectErr != nil {
+			return r.inspectErr
+		}
+		if !r.appStillExists {
+			fmt.Fprintf(cmd.Stderr, "kapp: Error: App 'simple-app' (namespace: pkg-standalone) does not exist\n")
+			return goexec.ErrNotFound // any error; kapp errors when app is gone
+		}
+	}
+	return nil
+}
+
+func deletingApp() v1alpha1.App {
+	deletionTime := metav1.NewTime(time.Now())
+	return v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:               uuid.NewUUID(),
+			Name:              "simple-app",
+			Namespace:         "pkg-standalone",
+			DeletionTimestamp: &deletionTime,
+			Finalizers:        []string{deleteFinalizerName},
+		},
+		Spec: v1alpha1.AppSpec{
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+}
+
+func deleteTestKubeconfigSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-kubeconfig", Namespace: "pkg-standalone"},
+		Data: map[string][]byte{"value": []byte(`
+apiVersion: v1
+kind: Config
+clusters:
+- cluster: {server: https://cluster.example.com}
+  name: dst-cluster
+users:
+- user: {token: token}
+  name: dst-user
+contexts:
+- context: {cluster: dst-cluster, user: dst-user}
+  name: dst-ctx
+current-context: dst-ctx
+`)},
+	}
+}
+
+func reconcileDeletingApp(t *testing.T, cmdRunner deleteVerifyCmdRunner) (*CRDApp, *fake.Clientset) {
+	log := logf.Log.WithName("kc")
+	app := deletingApp()
+
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(&app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
+
+	_, err := crdApp.Reconcile(false)
+	assert.Nil(t, err, "unexpected error with reconciling", err)
+
+	return crdApp, kappcs
+}
+
+func Test_Delete_FinalizerRetainedWhenCleanupIncomplete(t *testing.T) {
+	crdApp, kappcs := reconcileDeletingApp(t, deleteVerifyCmdRunner{appStillExists: true})
+
+	existingApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "simple-app", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Contains(t, existingApp.Finalizers, deleteFinalizerName, "expected finalizer to be retained")
+
+	status := crdApp.app.Status()
+	assert.Contains(t, status.FriendlyDescription, "Delete failed")
+	assert.Contains(t, status.FriendlyDescription, "still exist")
+}
+
+func Test_Delete_FinalizerRemovedAfterCleanupVerified(t *testing.T) {
+	_, kappcs := reconcileDeletingApp(t, deleteVerifyCmdRunner{appStillExists: false})
+
+	existingApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "simple-app", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.NotContains(t, existingApp.Finalizers, deleteFinalizerName, "expected finalizer to be removed")
+}
+
+func Test_Delete_FinalizerRetainedOnTransientInspectError(t *testing.T) {
+	crdApp, kappcs := reconcileDeletingApp(t, deleteVerifyCmdRunner{inspectErr: fmt.Errorf("fake API throttling")})
+
+	existingApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "simple-app", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Contains(t, existingApp.Finalizers, deleteFinalizerName, "expected finalizer to be retained")
+
+	status := crdApp.app.Status()
+	assert.Contains(t, status.FriendlyDescription, "Verifying delete")
+}
@@ -0,0 +1,91 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func reconcileTimedOutDeletingApp(t *testing.T, opts Opts) (*CRDApp, *fake.Clientset) {
+	log := logf.Log.WithName("kc")
+	app := deletingApp()
+
+	// Deletion has been in progress for longer than any timeout under test
+	deletionTime := metav1.NewTime(time.Now().Add(-time.Hour))
+	app.DeletionTimestamp = &deletionTime
+
+	// App resources still exist after delete, so deletion keeps failing
+	cmdRunner := deleteVerifyCmdRunner{appStillExists: true}
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(&app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		opts, nil, nil, nil)
+
+	_, err := crdApp.Reconcile(false)
+	assert.Nil(t, err, "unexpected error with reconciling", err)
+
+	return crdApp, kappcs
+}
+
+func Test_Delete_TimeoutRecordedInDeleteFailedCondition(t *testing.T) {
+	crdApp, kappcs := reconcileTimedOutDeletingApp(t, Opts{
+		MinimumSyncPeriod: 30 * time.Second,
+		DeletionTimeout:   time.Minute,
+	})
+
+	status := crdApp.app.Status()
+	if assert.Len(t, status.Conditions, 1) {
+		assert.Equal(t, v1alpha1.DeleteFailed, status.Conditions[0].Type)
+		assert.Contains(t, status.Conditions[0].Message, "Delete exceeded deletion timeout (1m0s)")
+	}
+
+	// Without force removal the finalizer stays so deletion is retried
+	existingApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "simple-app", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Contains(t, existingApp.Finalizers, deleteFinalizerName, "expected finalizer to be retained")
+}
+
+func Test_Delete_TimeoutForceRemovesFinalizer(t *testing.T) {
+	crdApp, kappcs := reconcileTimedOutDeletingApp(t, Opts{
+		MinimumSyncPeriod:              30 * time.Second,
+		DeletionTimeout:                time.Minute,
+		ForceFinalizerRemovalOnTimeout: true,
+	})
+
+	status := crdApp.app.Status()
+	if assert.Len(t, status.Conditions, 1) {
+		assert.Equal(t, v1alpha1.DeleteFailed, status.Conditions[0].Type)
+		assert.Contains(t, status.Conditions[0].Message, "Delete exceeded deletion timeout (1m0s)")
+	}
+
+	existingApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "simple-app", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.NotContains(t, existingApp.Finalizers, deleteFinalizerName, "expected finalizer to be force removed")
+}
+
+func Test_Delete_NoTimeoutWithoutConfiguredDeadline(t *testing.T) {
+	crdApp, _ := reconcileTimedOutDeletingApp(t, Opts{MinimumSyncPeriod: 30 * time.Second})
+
+	status := crdApp.app.Status()
+	assert.NotContains(t, status.FriendlyDescription, "deletion timeout")
+	assert.Contains(t, status.FriendlyDescription, "still exist")
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	kcv1alpha1 "carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DependsOnAnnKey lists Apps that must reconcile successfully before
+// this App is reconciled. Value is a comma separated list of App names,
+// optionally namespace qualified as 'namespace/name'.
+const DependsOnAnnKey = "kappctrl.k14s.io/depends-on"
+
+// dependencyRequeuePeriod is how soon a reconcile is retried while
+// waiting for a dependency App to reconcile successfully.
+const dependencyRequeuePeriod = 15 * time.Second
+
+type appDependencyRef struct {
+	Namespace string
+	Name      string
+}
+
+func (r appDependencyRef) Description() string {
+	return fmt.Sprintf("%s/%s", r.Namespace, r.Name)
+}
+
+// dependencyRefs parses the depends-on annotation, defaulting
+// unqualified names to the App's own namespace.
+func (a *CRDApp) dependencyRefs() []appDependencyRef {
+	annVal, found := a.appModel.Annotations[DependsOnAnnKey]
+	if !found {
+		return nil
+	}
+
+	var refs []appDependencyRef
+	for _, item := range strings.Split(annVal, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		ref := appDependencyRef{Namespace: a.appModel.Namespace, Name: item}
+		if idx := strings.Index(item, "/"); idx >= 0 {
+			ref.Namespace = item[:idx]
+			ref.Name = item[idx+1:]
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// unreconciledDependency returns the first dependency App that has not
+// (yet) reconciled successfully, or nil if all dependencies are ready.
+func (a *CRDApp) unreconciledDependency() (*appDependencyRef, error) {
+	for _, ref := range a.dependencyRefs() {
+		depApp, err := a.appClient.KappctrlV1alpha1().Apps(ref.Namespace).Get(context.Background(), ref.Name, metav1.GetOptions{})
+		if err != nil {
+			return &ref, fmt.Errorf("Fetching dependency app '%s': %s", ref.Description(), err)
+		}
+		if !appReconciledSuccessfully(*depApp) {
+			return &ref, nil
+		}
+	}
+	return nil, nil
+}
+
+func appReconciledSuccessfully(app kcv1alpha1.App) bool {
+	if app.Status.ObservedGeneration != app.Generation {
+		return false
+	}
+	for _, cond := range app.Status.Conditions {
+		if cond.Type == kcv1alpha1.ReconcileSucceeded && cond.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
+// markWaitingForDependency records a status note indicating which
+// dependency is being waited on, leaving the rest of the status (and
+// any deployed resources) untouched.
+func (a *CRDApp) markWaitingForDependency(ref appDependencyRef) error {
+	existingApp, err := a.appClient.KappctrlV1alpha1().Apps(a.appModel.Namespace).Get(context.Background(), a.appModel.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Fetching app: %s", err)
+	}
+
+	desc := fmt.Sprintf("Waiting for dependency app '%s' to reconcile successfully", ref.Description())
+	if existingApp.Status.FriendlyDescription == desc {
+		return nil
+	}
+
+	existingApp.Status.FriendlyDescription = desc
+	_, err = a.appClient.KappctrlV1alpha1().Apps(existingApp.Namespace).UpdateStatus(context.Background(), existingApp, metav1.UpdateOptions{})
+	return err
+}
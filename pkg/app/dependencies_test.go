@@ -0,0 +1,142 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	kcclient "carvel.dev/kapp-controller/pkg/client/clientset/versioned"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func dependencyApp(name string, reconciled bool) *v1alpha1.App {
+	app := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      name,
+			Namespace: "pkg-standalone",
+		},
+	}
+	if reconciled {
+		app.Status.Conditions = []v1alpha1.Condition{
+			{Type: v1alpha1.ReconcileSucceeded, Status: corev1.ConditionTrue},
+		}
+	}
+	return app
+}
+
+func dependentCRDApp(t *testing.T, kappcs kcclient.Interface, app *v1alpha1.App) *CRDApp {
+	log := logf.Log.WithName("kc")
+
+	cmdRunner := noopTracingCmdRunner{}
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	return NewCRDApp(app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
+}
+
+func Test_Reconcile_WaitsForDependencyApp(t *testing.T) {
+	depApp := dependencyApp("dep-app", false)
+
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:         uuid.NewUUID(),
+			Name:        "dependent-app",
+			Namespace:   "pkg-standalone",
+			Annotations: map[string]string{DependsOnAnnKey: "dep-app"},
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+
+	kappcs := fake.NewSimpleClientset(depApp, &app)
+	crdApp := dependentCRDApp(t, kappcs, &app)
+
+	result, err := crdApp.Reconcile(true)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+	require.Equal(t, dependencyRequeuePeriod, result.RequeueAfter,
+		"Expected reconcile to be requeued while dependency is unreconciled")
+
+	waitingApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "dependent-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, "Waiting for dependency app 'pkg-standalone/dep-app' to reconcile successfully",
+		waitingApp.Status.FriendlyDescription)
+	require.Nil(t, waitingApp.Status.Deploy, "Expected no deploy to have run while waiting for dependency")
+
+	// Mark the dependency as successfully reconciled and retry
+	depApp.Status.Conditions = []v1alpha1.Condition{
+		{Type: v1alpha1.ReconcileSucceeded, Status: corev1.ConditionTrue},
+	}
+	_, err = kappcs.KappctrlV1alpha1().Apps("pkg-standalone").UpdateStatus(context.Background(), depApp, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	_, err = crdApp.Reconcile(true)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+	require.NotNil(t, crdApp.app.Status().Deploy, "Expected deploy to run once dependency reconciled")
+}
+
+func Test_Reconcile_ProceedsWhenDependencyReconciled(t *testing.T) {
+	depApp := dependencyApp("dep-app", true)
+
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:         uuid.NewUUID(),
+			Name:        "dependent-app",
+			Namespace:   "pkg-standalone",
+			Annotations: map[string]string{DependsOnAnnKey: "pkg-standalone/dep-app"},
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+
+	kappcs := fake.NewSimpleClientset(depApp, &app)
+	crdApp := dependentCRDApp(t, kappcs, &app)
+
+	result, err := crdApp.Reconcile(true)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+	require.NotEqual(t, dependencyRequeuePeriod, result.RequeueAfter,
+		"Expected reconcile to proceed when dependency already reconciled")
+	require.NotNil(t, crdApp.app.Status().Deploy, "Expected deploy to run when dependency reconciled")
+}
@@ -0,0 +1,98 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/exec"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// fakeBackendDeploy records deploy invocations and always succeeds
+type fakeBackendDeploy struct {
+	deployCount *int
+}
+
+func (d fakeBackendDeploy) Deploy(_ string, startedApplyingFunc func(), changedFunc func(exec.CmdRunResult)) exec.CmdRunResult {
+	*d.deployCount++
+	result := exec.CmdRunResult{Finished: true}
+	changedFunc(result)
+	return result
+}
+
+func (d fakeBackendDeploy) Delete(func(), func(exec.CmdRunResult)) exec.CmdRunResult {
+	return exec.CmdRunResult{Finished: true}
+}
+
+func (d fakeBackendDeploy) Inspect() exec.CmdRunResult { return exec.CmdRunResult{Finished: true} }
+
+type fakeDeployBackend struct {
+	deployCount *int
+}
+
+func (b fakeDeployBackend) Name() string { return "fake-test-backend" }
+
+func (b fakeDeployBackend) NewDeploy(deploy.BackendOpts) (deploy.Deploy, error) {
+	return fakeBackendDeploy{b.deployCount}, nil
+}
+
+func Test_Reconcile_UsesRegisteredDeployBackend(t *testing.T) {
+	deployCount := 0
+	deploy.RegisterBackend(fakeDeployBackend{&deployCount})
+
+	log := logf.Log.WithName("kc")
+
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      "simple-app",
+			Namespace: "pkg-standalone",
+			Annotations: map[string]string{
+				"kappctrl.k14s.io/deploy-backend": "fake-test-backend",
+			},
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+
+	cmdRunner := noopTracingCmdRunner{}
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(&app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
+
+	_, err := crdApp.Reconcile(true)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+
+	require.Equal(t, 1, deployCount, "Expected deploy to go through the registered backend")
+}
@@ -0,0 +1,21 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+// dryRunAnnKey makes the deploy stage run kapp with --diff-run so the
+// diff of pending changes is recorded in status (.status.deploy.stdout)
+// without applying anything to the cluster. Fetch and template still
+// run normally.
+const dryRunAnnKey = "kappctrl.k14s.io/dry-run"
+
+func (a *App) dryRun() bool {
+	_, found := a.app.Annotations[dryRunAnnKey]
+	return found
+}
+
+// dryRunKappDeployOpts returns the raw options that turn a kapp deploy
+// into a diff-only run with the diff included in its output.
+func dryRunKappDeployOpts() []string {
+	return []string{"--diff-run=true", "--diff-changes=true"}
+}
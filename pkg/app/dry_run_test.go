@@ -0,0 +1,118 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func dryRunTestApp(annotations map[string]string) v1alpha1.App {
+	return v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:         uuid.NewUUID(),
+			Name:        "simple-app",
+			Namespace:   "pkg-standalone",
+			Annotations: annotations,
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+}
+
+func dryRunTestReconcile(t *testing.T, app *v1alpha1.App) (*CRDApp, [][]string) {
+	log := logf.Log.WithName("kc")
+
+	var cmds [][]string
+	cmdRunner := argsRecordingCmdRunner{lock: &sync.Mutex{}, cmds: &cmds}
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	crdApp := NewCRDApp(app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
+
+	_, err := crdApp.Reconcile(true)
+	require.NoError(t, err)
+
+	return crdApp, cmds
+}
+
+func kappDeployArgs(cmds [][]string) []string {
+	for _, args := range cmds {
+		if len(args) > 1 && args[0] == "kapp" && args[1] == "deploy" {
+			return args
+		}
+	}
+	return nil
+}
+
+func Test_DryRunAnnotation_DeploysWithDiffRun(t *testing.T) {
+	app := dryRunTestApp(map[string]string{dryRunAnnKey: ""})
+
+	crdApp, cmds := dryRunTestReconcile(t, &app)
+
+	deployArgs := kappDeployArgs(cmds)
+	require.NotNil(t, deployArgs, "Expected a kapp deploy to have run")
+	assert.Contains(t, deployArgs, "--diff-run=true")
+	assert.Contains(t, deployArgs, "--diff-changes=true")
+
+	require.NotNil(t, crdApp.app.Status().Deploy)
+	assert.True(t, crdApp.app.Status().Deploy.DryRun)
+
+	// A dry run must not mark the generation as reconciled; otherwise
+	// removing the annotation would skip the real deploy
+	status := crdApp.app.Status()
+	assert.True(t, status.LastSuccessfulReconcile.IsZero())
+	assert.Equal(t, "Dry run succeeded (no changes applied)", status.FriendlyDescription)
+	require.Len(t, status.Conditions, 1)
+	assert.Equal(t, v1alpha1.ReconcileSucceeded, status.Conditions[0].Type)
+	assert.Equal(t, "Dry run: diff recorded, no changes applied", status.Conditions[0].Message)
+}
+
+func Test_NoDryRunWithoutAnnotation(t *testing.T) {
+	app := dryRunTestApp(nil)
+
+	crdApp, cmds := dryRunTestReconcile(t, &app)
+
+	deployArgs := kappDeployArgs(cmds)
+	require.NotNil(t, deployArgs, "Expected a kapp deploy to have run")
+	assert.NotContains(t, deployArgs, "--diff-run=true")
+
+	require.NotNil(t, crdApp.app.Status().Deploy)
+	assert.False(t, crdApp.app.Status().Deploy.DryRun)
+
+	status := crdApp.app.Status()
+	assert.False(t, status.LastSuccessfulReconcile.IsZero())
+	assert.Equal(t, "Reconcile succeeded", status.FriendlyDescription)
+}
@@ -0,0 +1,59 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"strings"
+	"sync"
+)
+
+// FetchErrorClassifier decides whether a failed fetch warrants the
+// accelerated failure backoff (transient errors like rate limiting) or
+// should wait for the regular sync period (permanent errors like bad
+// credentials, which retrying quickly cannot fix).
+type FetchErrorClassifier interface {
+	Retryable(errOutput string) bool
+}
+
+var (
+	fetchErrorClassifierLock sync.Mutex
+	fetchErrorClassifier     FetchErrorClassifier = defaultFetchErrorClassifier{}
+)
+
+// SetFetchErrorClassifier replaces the classifier consulted when
+// scheduling retries of failed fetches, letting downstream builds
+// tailor which errors back off quickly.
+func SetFetchErrorClassifier(classifier FetchErrorClassifier) {
+	fetchErrorClassifierLock.Lock()
+	defer fetchErrorClassifierLock.Unlock()
+	fetchErrorClassifier = classifier
+}
+
+func currentFetchErrorClassifier() FetchErrorClassifier {
+	fetchErrorClassifierLock.Lock()
+	defer fetchErrorClassifierLock.Unlock()
+	return fetchErrorClassifier
+}
+
+// defaultFetchErrorClassifier treats fetch errors as retryable unless
+// they are well-known permanent authentication/authorization failures.
+type defaultFetchErrorClassifier struct{}
+
+var permanentFetchErrorMarkers = []string{
+	"401 unauthorized",
+	"403 forbidden",
+	"authentication required",
+	"authorization failed",
+	"invalid username or password",
+}
+
+func (defaultFetchErrorClassifier) Retryable(errOutput string) bool {
+	lowered := strings.ToLower(errOutput)
+	for _, marker := range permanentFetchErrorMarkers {
+		if strings.Contains(lowered, marker) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,63 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fetchFailedApp(stderr string) v1alpha1.App {
+	return v1alpha1.App{
+		Status: v1alpha1.AppStatus{
+			Fetch: &v1alpha1.AppStatusFetch{
+				ExitCode: 1,
+				Stderr:   stderr,
+			},
+			ConsecutiveReconcileFailures: 1,
+			GenericStatus: v1alpha1.GenericStatus{
+				Conditions: []v1alpha1.Condition{{Type: v1alpha1.ReconcileFailed}},
+			},
+		},
+	}
+}
+
+func TestRateLimitedFetchUsesFailureBackoff(t *testing.T) {
+	app := fetchFailedApp("vendir: Error: 429 Too Many Requests")
+
+	delay := NewReconcileTimer(app, defaultTimerOpts).DurationUntilReady(nil)
+
+	assert.Less(t, delay, defaultTimerOpts.MinimumSyncPeriod,
+		"Expected rate limited fetch to retry before the regular sync period")
+}
+
+func TestAuthFetchFailureSkipsFailureBackoff(t *testing.T) {
+	app := fetchFailedApp("vendir: Error: 401 Unauthorized")
+
+	delay := NewReconcileTimer(app, defaultTimerOpts).DurationUntilReady(nil)
+
+	// Jitter subtracts at most 5s from the sync period
+	assert.GreaterOrEqual(t, delay, defaultTimerOpts.MinimumSyncPeriod-5*time.Second,
+		"Expected auth fetch failure to wait for the regular sync period")
+}
+
+func TestFetchErrorClassifierIsPluggable(t *testing.T) {
+	defer SetFetchErrorClassifier(defaultFetchErrorClassifier{})
+	SetFetchErrorClassifier(neverRetryClassifier{})
+
+	app := fetchFailedApp("vendir: Error: 429 Too Many Requests")
+
+	delay := NewReconcileTimer(app, defaultTimerOpts).DurationUntilReady(nil)
+
+	require.GreaterOrEqual(t, delay, defaultTimerOpts.MinimumSyncPeriod-5*time.Second,
+		"Expected custom classifier to suppress the failure backoff")
+}
+
+type neverRetryClassifier struct{}
+
+func (neverRetryClassifier) Retryable(string) bool { return false }
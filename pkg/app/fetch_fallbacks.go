@@ -0,0 +1,52 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+)
+
+// fetchAttempts returns the ordered source combinations the fetch stage
+// tries: the primary sources first, then one combination per fallback
+// level where each fetch step is replaced by its Nth fallback. Steps
+// with fewer fallbacks keep their primary source. A fallback without an
+// explicit path inherits the path of the step it replaces.
+func (a *App) fetchAttempts() [][]v1alpha1.AppFetch {
+	maxFallbacks := 0
+	for _, fetch := range a.app.Spec.Fetch {
+		if len(fetch.Fallbacks) > maxFallbacks {
+			maxFallbacks = len(fetch.Fallbacks)
+		}
+	}
+
+	attempts := [][]v1alpha1.AppFetch{a.app.Spec.Fetch}
+
+	for n := 1; n <= maxFallbacks; n++ {
+		var sources []v1alpha1.AppFetch
+		for _, fetch := range a.app.Spec.Fetch {
+			if n <= len(fetch.Fallbacks) {
+				fallback := fetch.Fallbacks[n-1]
+				if fallback.Path == "" {
+					fallback.Path = fetch.Path
+				}
+				fetch = fallback
+			}
+			sources = append(sources, fetch)
+		}
+		attempts = append(attempts, sources)
+	}
+
+	return attempts
+}
+
+// usedFetchSourceName describes the source combination of the given
+// fetch attempt for recording in status.
+func usedFetchSourceName(attempt int) string {
+	if attempt == 0 {
+		return "primary"
+	}
+	return fmt.Sprintf("fallback %d", attempt)
+}
@@ -0,0 +1,178 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	goexec "os/exec"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/exec"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// vendirFailingCmdRunner fails the first failures vendir invocations
+// and succeeds otherwise
+type vendirFailingCmdRunner struct {
+	failures *int
+}
+
+func (r vendirFailingCmdRunner) Run(cmd *goexec.Cmd) error {
+	if len(cmd.Args) > 0 && cmd.Args[0] == "vendir" && *r.failures > 0 {
+		*r.failures--
+		return fmt.Errorf("connection refused")
+	}
+	return nil
+}
+
+func (r vendirFailingCmdRunner) RunWithCancel(cmd *goexec.Cmd, _ chan struct{}) error {
+	return r.Run(cmd)
+}
+
+func Test_FetchAttempts_ReplaceStepsWithFallbacksInOrder(t *testing.T) {
+	app := v1alpha1.App{
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{
+					HTTP: &v1alpha1.AppFetchHTTP{URL: "https://primary.example.com/app.tgz"},
+					Path: "config",
+					Fallbacks: []v1alpha1.AppFetch{
+						v1alpha1.AppFetch{HTTP: &v1alpha1.AppFetchHTTP{URL: "https://mirror1.example.com/app.tgz"}},
+						v1alpha1.AppFetch{HTTP: &v1alpha1.AppFetchHTTP{URL: "https://mirror2.example.com/app.tgz"}, Path: "other"},
+					},
+				},
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+		},
+	}
+
+	a := NewApp(app, Hooks{}, fetch.Factory{}, template.Factory{}, deploy.Factory{}, logf.Log.WithName("kc"), Opts{}, nil, FakeComponentInfo{})
+
+	attempts := a.fetchAttempts()
+	require.Len(t, attempts, 3)
+
+	assert.Equal(t, "https://primary.example.com/app.tgz", attempts[0][0].HTTP.URL)
+	assert.Equal(t, "https://mirror1.example.com/app.tgz", attempts[1][0].HTTP.URL)
+	assert.Equal(t, "https://mirror2.example.com/app.tgz", attempts[2][0].HTTP.URL)
+
+	// Fallbacks without an explicit path inherit the step's path
+	assert.Equal(t, "config", attempts[1][0].Path)
+	assert.Equal(t, "other", attempts[2][0].Path)
+
+	// Steps without fallbacks keep their primary source in every attempt
+	for _, sources := range attempts {
+		require.Len(t, sources, 2)
+		assert.NotNil(t, sources[1].Inline)
+	}
+}
+
+func fetchFallbackTestApp() v1alpha1.App {
+	return v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      "simple-app",
+			Namespace: "pkg-standalone",
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{
+					HTTP: &v1alpha1.AppFetchHTTP{URL: "https://primary.example.com/app.tgz"},
+					Fallbacks: []v1alpha1.AppFetch{
+						v1alpha1.AppFetch{HTTP: &v1alpha1.AppFetchHTTP{URL: "https://mirror.example.com/app.tgz"}},
+					},
+				},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+}
+
+func fetchFallbackTestCRDApp(t *testing.T, app *v1alpha1.App, cmdRunner exec.CmdRunner) *CRDApp {
+	log := logf.Log.WithName("kc")
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	return NewCRDApp(app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
+}
+
+func Test_Fetch_FallbackUsedWhenPrimaryFails(t *testing.T) {
+	app := fetchFallbackTestApp()
+
+	failures := 1
+	crdApp := fetchFallbackTestCRDApp(t, &app, vendirFailingCmdRunner{failures: &failures})
+
+	_, err := crdApp.Reconcile(true)
+	require.NoError(t, err)
+
+	require.NotNil(t, crdApp.app.Status().Fetch)
+	assert.Equal(t, 0, crdApp.app.Status().Fetch.ExitCode)
+	assert.Equal(t, "fallback 1", crdApp.app.Status().Fetch.UsedSource)
+}
+
+func Test_Fetch_PrimaryRecordedWhenItSucceeds(t *testing.T) {
+	app := fetchFallbackTestApp()
+
+	failures := 0
+	crdApp := fetchFallbackTestCRDApp(t, &app, vendirFailingCmdRunner{failures: &failures})
+
+	_, err := crdApp.Reconcile(true)
+	require.NoError(t, err)
+
+	require.NotNil(t, crdApp.app.Status().Fetch)
+	assert.Equal(t, "primary", crdApp.app.Status().Fetch.UsedSource)
+}
+
+func Test_Fetch_UsedSourceEmptyWithoutFallbacks(t *testing.T) {
+	app := fetchFallbackTestApp()
+	app.Spec.Fetch[0].Fallbacks = nil
+
+	failures := 0
+	crdApp := fetchFallbackTestCRDApp(t, &app, vendirFailingCmdRunner{failures: &failures})
+
+	_, err := crdApp.Reconcile(true)
+	require.NoError(t, err)
+
+	require.NotNil(t, crdApp.app.Status().Fetch)
+	assert.Equal(t, "", crdApp.app.Status().Fetch.UsedSource)
+}
+
+func Test_Fetch_FailsWhenAllSourcesFail(t *testing.T) {
+	app := fetchFallbackTestApp()
+
+	failures := 2
+	crdApp := fetchFallbackTestCRDApp(t, &app, vendirFailingCmdRunner{failures: &failures})
+
+	_, err := crdApp.Reconcile(true)
+	require.NoError(t, err)
+
+	require.NotNil(t, crdApp.app.Status().Fetch)
+	assert.NotEqual(t, 0, crdApp.app.Status().Fetch.ExitCode)
+	assert.Contains(t, crdApp.app.Status().Fetch.Error, "Fetching resources")
+	assert.Equal(t, "", crdApp.app.Status().Fetch.UsedSource)
+}
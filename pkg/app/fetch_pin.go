@@ -0,0 +1,48 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"strings"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+)
+
+// FetchPinDigestAnnKey pins image and imgpkgBundle fetches to a
+// previously-resolved digest, overriding any tag or tag selection
+// in the spec. Useful for rolling back without editing the spec.
+const FetchPinDigestAnnKey = "kappctrl.carvel.dev/fetch-pin-digest"
+
+func (a *App) fetchPinDigest() string {
+	return a.app.Annotations[FetchPinDigestAnnKey]
+}
+
+// pinFetchToDigest returns a copy of the fetch step with image and
+// imgpkgBundle URLs rewritten to reference the given digest. Other
+// fetch sources are returned unchanged.
+func pinFetchToDigest(fetch v1alpha1.AppFetch, digest string) v1alpha1.AppFetch {
+	pinned := *fetch.DeepCopy()
+
+	if pinned.Image != nil {
+		pinned.Image.URL = pinImageURL(pinned.Image.URL, digest)
+		pinned.Image.TagSelection = nil
+	}
+	if pinned.ImgpkgBundle != nil {
+		pinned.ImgpkgBundle.Image = pinImageURL(pinned.ImgpkgBundle.Image, digest)
+		pinned.ImgpkgBundle.TagSelection = nil
+	}
+
+	return pinned
+}
+
+func pinImageURL(url string, digest string) string {
+	repo := url
+	// Tag or digest separators only appear after the last path segment
+	// (colons before that are part of a registry host:port)
+	lastSegIdx := strings.LastIndex(url, "/") + 1
+	if idx := strings.IndexAny(url[lastSegIdx:], ":@"); idx >= 0 {
+		repo = url[:lastSegIdx+idx]
+	}
+	return repo + "@" + digest
+}
@@ -0,0 +1,107 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	versions "carvel.dev/vendir/pkg/vendir/versions/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_PinFetchToDigest_RewritesImageURLs(t *testing.T) {
+	digest := "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+	pinned := pinFetchToDigest(v1alpha1.AppFetch{
+		Image: &v1alpha1.AppFetchImage{URL: "registry.example.com:5000/app1-config:v0.1.0"},
+	}, digest)
+	require.Equal(t, "registry.example.com:5000/app1-config@"+digest, pinned.Image.URL)
+
+	pinned = pinFetchToDigest(v1alpha1.AppFetch{
+		ImgpkgBundle: &v1alpha1.AppFetchImgpkgBundle{Image: "username/bundle@sha256:bbbb"},
+	}, digest)
+	require.Equal(t, "username/bundle@"+digest, pinned.ImgpkgBundle.Image)
+
+	// Tag selection conflicts with an explicit digest
+	pinned = pinFetchToDigest(v1alpha1.AppFetch{
+		Image: &v1alpha1.AppFetchImage{URL: "username/app1-config", TagSelection: &versions.VersionSelection{}},
+	}, digest)
+	require.Equal(t, "username/app1-config@"+digest, pinned.Image.URL)
+	require.Nil(t, pinned.Image.TagSelection)
+
+	// Non-image fetch sources are untouched
+	inline := v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}}
+	require.Equal(t, inline, pinFetchToDigest(inline, digest))
+}
+
+func Test_FetchPinDigest_RecordedInStatus(t *testing.T) {
+	log := logf.Log.WithName("kc")
+	digest := "sha256:aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+	pinnedApp := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:         uuid.NewUUID(),
+			Name:        "simple-app",
+			Namespace:   "pkg-standalone",
+			Annotations: map[string]string{FetchPinDigestAnnKey: digest},
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+
+	var cmdCount int32
+	cmdRunner := countingCmdRunner{count: &cmdCount}
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(&pinnedApp)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	crdApp := NewCRDApp(&pinnedApp, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
+
+	_, err := crdApp.Reconcile(true)
+	assert.Nil(t, err, "unexpected error with reconciling", err)
+	require.NotNil(t, crdApp.app.Status().Fetch)
+	assert.Equal(t, digest, crdApp.app.Status().Fetch.PinnedDigest)
+
+	// Unpinned apps leave the field empty
+	unpinnedApp := pinnedApp.DeepCopy()
+	unpinnedApp.Annotations = nil
+	unpinnedApp.Status = v1alpha1.AppStatus{}
+	kappcs2 := fake.NewSimpleClientset(unpinnedApp)
+
+	crdApp2 := NewCRDApp(unpinnedApp, log, metrics.NewMetrics(), kappcs2, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
+
+	_, err = crdApp2.Reconcile(true)
+	assert.Nil(t, err, "unexpected error with reconciling", err)
+	require.NotNil(t, crdApp2.app.Status().Fetch)
+	assert.Equal(t, "", crdApp2.app.Status().Fetch.PinnedDigest)
+}
@@ -0,0 +1,83 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_FetchSourceTypes_DistinctPerSource(t *testing.T) {
+	app := &App{app: v1alpha1.App{
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Git: &v1alpha1.AppFetchGit{URL: "https://example.com/repo"}},
+				v1alpha1.AppFetch{HTTP: &v1alpha1.AppFetchHTTP{URL: "https://example.com/file"}},
+				v1alpha1.AppFetch{Git: &v1alpha1.AppFetchGit{URL: "https://example.com/other"}},
+			},
+		},
+	}}
+
+	require.Equal(t, []string{"git", "http"}, app.fetchSourceTypes())
+	require.Empty(t, (&App{}).fetchSourceTypes())
+}
+
+func Test_Reconcile_RegistersFetchSourceMetrics(t *testing.T) {
+	log := logf.Log.WithName("kc")
+
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      "metrics-app",
+			Namespace: "pkg-standalone",
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+
+	cmdRunner := noopTracingCmdRunner{}
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(&app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	appMetrics := metrics.NewMetrics()
+	crdApp := NewCRDApp(&app, log, appMetrics, kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
+
+	_, err := crdApp.Reconcile(true)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+
+	require.Equal(t, int64(1), appMetrics.ReconcileCountMetrics.GetReconcileSuccessForSourceValue("App", "inline"),
+		"Expected a success to be counted for the inline fetch source")
+	require.Zero(t, appMetrics.ReconcileCountMetrics.GetReconcileFailureForSourceValue("App", "inline"))
+	require.Zero(t, appMetrics.ReconcileCountMetrics.GetReconcileSuccessForSourceValue("App", "git"),
+		"Expected no success to be counted for unused source types")
+}
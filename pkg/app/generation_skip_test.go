@@ -0,0 +1,114 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	goexec "os/exec"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// countingCmdRunner succeeds immediately and counts invocations so tests
+// can tell whether any fetch/template/deploy commands ran.
+type countingCmdRunner struct {
+	count *int32
+}
+
+func (r countingCmdRunner) Run(*goexec.Cmd) error {
+	atomic.AddInt32(r.count, 1)
+	return nil
+}
+
+func (r countingCmdRunner) RunWithCancel(*goexec.Cmd, chan struct{}) error {
+	atomic.AddInt32(r.count, 1)
+	return nil
+}
+
+func Test_GenerationUnchanged_SkipsReconcileAfterSuccess(t *testing.T) {
+	log := logf.Log.WithName("kc")
+
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:        uuid.NewUUID(),
+			Name:       "simple-app",
+			Namespace:  "pkg-standalone",
+			Generation: 1,
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+
+	var cmdCount int32
+	cmdRunner := countingCmdRunner{count: &cmdCount}
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(&app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	opts := Opts{DefaultSyncPeriod: 30 * time.Second, MinimumSyncPeriod: 30 * time.Second}
+
+	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{}, opts, nil, nil, nil)
+
+	_, err := crdApp.Reconcile(false)
+	assert.Nil(t, err, "unexpected error with reconciling", err)
+
+	status := crdApp.app.Status()
+	assert.Equal(t, "Reconcile succeeded", status.FriendlyDescription)
+	assert.Equal(t, int64(1), status.LastReconciledGeneration)
+
+	countAfterFirst := atomic.LoadInt32(&cmdCount)
+	assert.NotZero(t, countAfterFirst)
+
+	// Same generation, successful last result: full reconcile is skipped
+	result, err := crdApp.Reconcile(false)
+	assert.Nil(t, err, "unexpected error with reconciling", err)
+	assert.NotZero(t, result.RequeueAfter, "expected requeue until next resync")
+	assert.Equal(t, countAfterFirst, atomic.LoadInt32(&cmdCount), "expected no commands to run when skipped")
+
+	// Force still reconciles even when generation is unchanged
+	_, err = crdApp.Reconcile(true)
+	assert.Nil(t, err, "unexpected error with reconciling", err)
+	assert.Greater(t, atomic.LoadInt32(&cmdCount), countAfterFirst)
+
+	// Bumping the generation triggers a full reconcile again
+	countBeforeBump := atomic.LoadInt32(&cmdCount)
+
+	bumpedApp := app.DeepCopy()
+	bumpedApp.Generation = 2
+	bumpedApp.Status = crdApp.app.Status()
+
+	crdApp = NewCRDApp(bumpedApp, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{}, opts, nil, nil, nil)
+
+	_, err = crdApp.Reconcile(false)
+	assert.Nil(t, err, "unexpected error with reconciling", err)
+	assert.Greater(t, atomic.LoadInt32(&cmdCount), countBeforeBump)
+	assert.Equal(t, int64(2), crdApp.app.Status().LastReconciledGeneration)
+}
@@ -0,0 +1,40 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"strings"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+)
+
+// parseKbldResolvedImages extracts image resolutions from kbld's
+// diagnostic output. kbld reports each resolution on its own line, e.g.
+//
+//	resolve | final: nginx:1.14.2 -> index.docker.io/library/nginx@sha256:...
+//
+// Returns nil when the output carries no resolutions (e.g. kbld did
+// not run during templating).
+func parseKbldResolvedImages(output string) []v1alpha1.AppResolvedImage {
+	var images []v1alpha1.AppResolvedImage
+
+	for _, line := range strings.Split(output, "\n") {
+		_, resolution, found := strings.Cut(line, "final: ")
+		if !found {
+			continue
+		}
+		image, resolved, found := strings.Cut(resolution, " -> ")
+		if !found {
+			continue
+		}
+		image = strings.TrimSpace(image)
+		resolved = strings.TrimSpace(resolved)
+		if image == "" || resolved == "" {
+			continue
+		}
+		images = append(images, v1alpha1.AppResolvedImage{Image: image, Resolved: resolved})
+	}
+
+	return images
+}
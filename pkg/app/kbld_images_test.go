@@ -0,0 +1,33 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKbldResolvedImages(t *testing.T) {
+	output := `resolve | final: nginx:1.14.2 -> index.docker.io/library/nginx@sha256:f7988fb6c02e
+resolve | final: registry.example.com/my-app -> registry.example.com/my-app@sha256:aaaa1111
+some unrelated log line
+`
+
+	images := parseKbldResolvedImages(output)
+
+	require.Equal(t, []v1alpha1.AppResolvedImage{
+		{Image: "nginx:1.14.2", Resolved: "index.docker.io/library/nginx@sha256:f7988fb6c02e"},
+		{Image: "registry.example.com/my-app", Resolved: "registry.example.com/my-app@sha256:aaaa1111"},
+	}, images)
+}
+
+func TestParseKbldResolvedImagesWithoutKbldOutput(t *testing.T) {
+	assert.Nil(t, parseKbldResolvedImages(""))
+	assert.Nil(t, parseKbldResolvedImages("ytt: some warning\nanother line"))
+	// A final: line without the arrow separator is ignored
+	assert.Nil(t, parseKbldResolvedImages("resolve | final: incomplete line"))
+}
@@ -0,0 +1,65 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// missingSecretRequeuePeriod is how soon a reconcile is retried while
+// waiting for a referenced secret to be created.
+const missingSecretRequeuePeriod = 15 * time.Second
+
+// missingReferencedSecret returns the name of the first secret the App
+// spec references that does not exist (yet), or "" when all referenced
+// secrets exist. Skipped (returns "") when no core client is available.
+func (a *CRDApp) missingReferencedSecret() (string, error) {
+	if a.coreClient == nil {
+		return "", nil
+	}
+
+	var names []string
+	for ref := range a.app.SecretRefs() {
+		names = append(names, ref.RefName())
+	}
+	// Sorted so repeated reconciles report the same missing secret
+	sort.Strings(names)
+
+	for _, name := range names {
+		_, err := a.coreClient.CoreV1().Secrets(a.appModel.Namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return name, nil
+			}
+			return "", fmt.Errorf("Checking referenced secret '%s': %s", name, err)
+		}
+	}
+
+	return "", nil
+}
+
+// markWaitingForSecret records a status note indicating which referenced
+// secret is being waited on, leaving the rest of the status (and any
+// deployed resources) untouched.
+func (a *CRDApp) markWaitingForSecret(secretName string) error {
+	existingApp, err := a.appClient.KappctrlV1alpha1().Apps(a.appModel.Namespace).Get(context.Background(), a.appModel.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Fetching app: %s", err)
+	}
+
+	desc := fmt.Sprintf("Waiting for secret '%s' to exist", secretName)
+	if existingApp.Status.FriendlyDescription == desc {
+		return nil
+	}
+
+	existingApp.Status.FriendlyDescription = desc
+	_, err = a.appClient.KappctrlV1alpha1().Apps(existingApp.Namespace).UpdateStatus(context.Background(), existingApp, metav1.UpdateOptions{})
+	return err
+}
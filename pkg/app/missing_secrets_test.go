@@ -0,0 +1,109 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+)
+
+func missingSecretTestApp() v1alpha1.App {
+	return v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      "simple-app",
+			Namespace: "pkg-standalone",
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{HTTP: &v1alpha1.AppFetchHTTP{
+					URL:       "https://example.com/manifests.tgz",
+					SecretRef: &v1alpha1.AppFetchLocalRef{Name: "fetch-creds"},
+				}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+}
+
+func Test_Reconcile_WaitsForMissingReferencedSecret(t *testing.T) {
+	log := logf.Log.WithName("kc")
+	app := missingSecretTestApp()
+
+	cmdRunner := noopTracingCmdRunner{}
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(&app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
+	crdApp.coreClient = k8scs
+
+	// Referenced fetch secret does not exist yet; the reconcile is
+	// postponed without running any fetch
+	result, err := crdApp.Reconcile(true)
+	require.NoError(t, err)
+	assert.Equal(t, missingSecretRequeuePeriod, result.RequeueAfter)
+
+	waitingApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "simple-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "Waiting for secret 'fetch-creds' to exist", waitingApp.Status.FriendlyDescription)
+	assert.Nil(t, waitingApp.Status.Fetch)
+
+	// Once the secret exists the reconcile proceeds normally
+	_, err = k8scs.CoreV1().Secrets("pkg-standalone").Create(context.Background(),
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "fetch-creds", Namespace: "pkg-standalone"}}, metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	_, err = crdApp.Reconcile(true)
+	require.NoError(t, err)
+
+	reconciledApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "simple-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, reconciledApp.Status.Fetch)
+	assert.Equal(t, 0, reconciledApp.Status.Fetch.ExitCode)
+}
+
+func Test_Reconcile_SecretGateSkippedWithoutCoreClient(t *testing.T) {
+	app := missingSecretTestApp()
+
+	kappcs := fake.NewSimpleClientset(&app)
+	crdApp := dependentCRDApp(t, kappcs, &app)
+
+	// No core client configured (e.g. in-memory dev reconciles): the
+	// gate is skipped and the reconcile runs as before
+	_, err := crdApp.Reconcile(true)
+	require.NoError(t, err)
+
+	reconciledApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "simple-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, reconciledApp.Status.Fetch)
+}
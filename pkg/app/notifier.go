@@ -0,0 +1,107 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	kcv1alpha1 "carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ReconcileEvent describes a terminal reconcile outcome for an App.
+type ReconcileEvent struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Succeeded is true for ReconcileSucceeded transitions and false
+	// for ReconcileFailed/DeleteFailed transitions
+	Succeeded   bool   `json:"succeeded"`
+	Description string `json:"description,omitempty"`
+}
+
+// Notifier is notified when an App reaches a terminal reconcile state.
+// Implementations should tolerate being called from multiple goroutines.
+type Notifier interface {
+	Notify(event ReconcileEvent) error
+}
+
+// webhookSignatureHeader carries the hex encoded HMAC-SHA256 signature
+// of the payload (GitHub webhook style, "sha256=<hex>").
+const webhookSignatureHeader = "X-Hub-Signature-256"
+
+// WebhookNotifier posts reconcile events as JSON to a configured URL.
+type WebhookNotifier struct {
+	url        string
+	hmacSecret []byte
+	client     *http.Client
+}
+
+// NewWebhookNotifier returns a notifier posting to given webhook URL.
+// A non-empty hmacSecret makes every payload carry an HMAC-SHA256
+// signature header so receivers can verify authenticity.
+func NewWebhookNotifier(url string, hmacSecret string) *WebhookNotifier {
+	notifier := &WebhookNotifier{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+	if hmacSecret != "" {
+		notifier.hmacSecret = []byte(hmacSecret)
+	}
+	return notifier
+}
+
+// Notify posts the event as a JSON payload to the webhook URL, signed
+// when a HMAC secret is configured.
+func (n *WebhookNotifier) Notify(event ReconcileEvent) error {
+	bs, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("Marshaling reconcile event: %s", err)
+	}
+
+	req, err := http.NewRequest("POST", n.url, bytes.NewReader(bs))
+	if err != nil {
+		return fmt.Errorf("Building reconcile event request: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(n.hmacSecret) > 0 {
+		req.Header.Set(webhookSignatureHeader, hmacSignature(n.hmacSecret, bs))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Posting reconcile event: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Posting reconcile event: unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// hmacSignature computes the signature header value for given payload.
+func hmacSignature(secret []byte, payload []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// terminalCondition returns the terminal condition type set to true in
+// given status (ReconcileSucceeded, ReconcileFailed or DeleteFailed), if any.
+func terminalCondition(status kcv1alpha1.GenericStatus) (kcv1alpha1.ConditionType, bool) {
+	for _, cond := range status.Conditions {
+		switch cond.Type {
+		case kcv1alpha1.ReconcileSucceeded, kcv1alpha1.ReconcileFailed, kcv1alpha1.DeleteFailed:
+			if cond.Status == corev1.ConditionTrue {
+				return cond.Type, true
+			}
+		}
+	}
+	return "", false
+}
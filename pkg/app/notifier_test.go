@@ -0,0 +1,145 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+	goexec "os/exec"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/exec"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// chanNotifier delivers received events on a channel so tests can wait
+// for the asynchronous notification goroutine.
+type chanNotifier struct {
+	events chan ReconcileEvent
+}
+
+func (n chanNotifier) Notify(event ReconcileEvent) error {
+	n.events <- event
+	return nil
+}
+
+// erroringCmdRunner fails every command execution.
+type erroringCmdRunner struct{}
+
+func (r erroringCmdRunner) Run(cmd *goexec.Cmd) error { return fmt.Errorf("fake command failure") }
+func (r erroringCmdRunner) RunWithCancel(cmd *goexec.Cmd, cancelCh chan struct{}) error {
+	return fmt.Errorf("fake command failure")
+}
+
+func notifierTestApp() v1alpha1.App {
+	return v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      "simple-app",
+			Namespace: "pkg-standalone",
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+}
+
+func notifierTestCRDApp(t *testing.T, app *v1alpha1.App, cmdRunner exec.CmdRunner, notifier Notifier) *CRDApp {
+	log := logf.Log.WithName("kc")
+
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	return NewCRDApp(app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, notifier)
+}
+
+func Test_Notifier_FiredOnReconcileSuccess(t *testing.T) {
+	app := notifierTestApp()
+
+	var cmdCount int32
+	notifier := chanNotifier{events: make(chan ReconcileEvent, 10)}
+	crdApp := notifierTestCRDApp(t, &app, countingCmdRunner{count: &cmdCount}, notifier)
+
+	_, err := crdApp.Reconcile(true)
+	require.NoError(t, err)
+
+	select {
+	case event := <-notifier.events:
+		assert.Equal(t, "simple-app", event.Name)
+		assert.Equal(t, "pkg-standalone", event.Namespace)
+		assert.True(t, event.Succeeded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected notifier to receive a reconcile event")
+	}
+}
+
+func Test_Notifier_FiredOnReconcileFailure(t *testing.T) {
+	app := notifierTestApp()
+
+	notifier := chanNotifier{events: make(chan ReconcileEvent, 10)}
+	crdApp := notifierTestCRDApp(t, &app, erroringCmdRunner{}, notifier)
+
+	_, err := crdApp.Reconcile(true)
+	require.NoError(t, err)
+
+	select {
+	case event := <-notifier.events:
+		assert.Equal(t, "simple-app", event.Name)
+		assert.False(t, event.Succeeded)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected notifier to receive a reconcile event")
+	}
+}
+
+func Test_Notifier_NotFiredOnIntermediateTransitions(t *testing.T) {
+	app := notifierTestApp()
+
+	var cmdCount int32
+	notifier := chanNotifier{events: make(chan ReconcileEvent, 10)}
+	crdApp := notifierTestCRDApp(t, &app, countingCmdRunner{count: &cmdCount}, notifier)
+
+	_, err := crdApp.Reconcile(true)
+	require.NoError(t, err)
+
+	// Only the terminal transition produces an event, even though the
+	// status is updated once per stage during the reconcile
+	select {
+	case <-notifier.events:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected notifier to receive a reconcile event")
+	}
+
+	select {
+	case event := <-notifier.events:
+		t.Fatalf("Expected no additional events, but got %#v", event)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
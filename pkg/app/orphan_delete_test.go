@@ -0,0 +1,69 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	goexec "os/exec"
+	"sync"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// argsRecordingCmdRunner records the args of every command it runs.
+type argsRecordingCmdRunner struct {
+	lock *sync.Mutex
+	cmds *[][]string
+}
+
+func (r argsRecordingCmdRunner) Run(cmd *goexec.Cmd) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	*r.cmds = append(*r.cmds, cmd.Args)
+	return nil
+}
+
+func (r argsRecordingCmdRunner) RunWithCancel(cmd *goexec.Cmd, _ chan struct{}) error {
+	return r.Run(cmd)
+}
+
+func Test_Delete_OrphanAnnotationKeepsResources(t *testing.T) {
+	log := logf.Log.WithName("kc")
+	app := deletingApp()
+	app.Annotations = map[string]string{orphanAnnKey: ""}
+
+	var cmds [][]string
+	cmdRunner := argsRecordingCmdRunner{lock: &sync.Mutex{}, cmds: &cmds}
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(&app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
+
+	_, err := crdApp.Reconcile(false)
+	assert.Nil(t, err, "unexpected error with reconciling", err)
+
+	for _, args := range cmds {
+		assert.NotContains(t, args, "delete", "expected no delete to be issued to deployed resources")
+	}
+
+	existingApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "simple-app", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.NotContains(t, existingApp.Finalizers, deleteFinalizerName, "expected finalizer to be removed")
+	assert.Contains(t, existingApp.Status.FriendlyDescription, "orphaned")
+}
@@ -0,0 +1,67 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	kcclient "carvel.dev/kapp-controller/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ReadinessHandler serves an HTTP endpoint reporting aggregate reconcile
+// health. It considers an App healthy when its last successful reconcile
+// happened within maxSuccessAge, and reports ready (200) while the
+// fraction of healthy Apps stays at or above minHealthyFraction;
+// otherwise it reports 503. Apps that have never reconciled successfully
+// count as unhealthy.
+type ReadinessHandler struct {
+	appClient          kcclient.Interface
+	maxSuccessAge      time.Duration
+	minHealthyFraction float64
+	timeNow            func() time.Time
+}
+
+// NewReadinessHandler returns a new ReadinessHandler.
+func NewReadinessHandler(appClient kcclient.Interface, maxSuccessAge time.Duration, minHealthyFraction float64) *ReadinessHandler {
+	return &ReadinessHandler{appClient: appClient, maxSuccessAge: maxSuccessAge,
+		minHealthyFraction: minHealthyFraction, timeNow: time.Now}
+}
+
+func (h *ReadinessHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	healthy, total, err := h.reconcileHealth(r.Context())
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, "error: %s\n", err)
+		return
+	}
+
+	// No Apps means nothing can be unhealthy
+	ready := total == 0 || float64(healthy)/float64(total) >= h.minHealthyFraction
+	if !ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	fmt.Fprintf(w, "healthy: %d/%d apps\n", healthy, total)
+}
+
+func (h *ReadinessHandler) reconcileHealth(ctx context.Context) (int, int, error) {
+	appList, err := h.appClient.KappctrlV1alpha1().Apps("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return 0, 0, fmt.Errorf("Listing apps: %s", err)
+	}
+
+	healthy := 0
+	for _, app := range appList.Items {
+		lastSuccess := app.Status.LastSuccessfulReconcile
+		if !lastSuccess.IsZero() && h.timeNow().Sub(lastSuccess.Time) <= h.maxSuccessAge {
+			healthy++
+		}
+	}
+
+	return healthy, len(appList.Items), nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func appWithLastSuccess(name string, lastSuccess time.Time) *v1alpha1.App {
+	return &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status:     v1alpha1.AppStatus{LastSuccessfulReconcile: metav1.NewTime(lastSuccess)},
+	}
+}
+
+func Test_ReadinessHandler_ReportsHealthy(t *testing.T) {
+	now := time.Now()
+	kappcs := fake.NewSimpleClientset(
+		appWithLastSuccess("app1", now.Add(-5*time.Minute)),
+		appWithLastSuccess("app2", now.Add(-10*time.Minute)),
+	)
+
+	handler := NewReadinessHandler(kappcs, time.Hour, 0.9)
+	handler.timeNow = func() time.Time { return now }
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/readyz", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "healthy: 2/2 apps\n", recorder.Body.String())
+}
+
+func Test_ReadinessHandler_ReportsDegraded(t *testing.T) {
+	now := time.Now()
+	kappcs := fake.NewSimpleClientset(
+		appWithLastSuccess("app1", now.Add(-5*time.Minute)),
+		// Stale last success and never reconciled successfully
+		appWithLastSuccess("app2", now.Add(-2*time.Hour)),
+		&v1alpha1.App{ObjectMeta: metav1.ObjectMeta{Name: "app3", Namespace: "default"}},
+	)
+
+	handler := NewReadinessHandler(kappcs, time.Hour, 0.9)
+	handler.timeNow = func() time.Time { return now }
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/readyz", nil))
+
+	require.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, "healthy: 1/3 apps\n", recorder.Body.String())
+}
+
+func Test_ReadinessHandler_ReadyWithoutApps(t *testing.T) {
+	handler := NewReadinessHandler(fake.NewSimpleClientset(), time.Hour, 0.9)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest("GET", "/readyz", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
@@ -0,0 +1,132 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kcv1alpha1 "carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	reconcileHistoryConfigMapSuffix = "-reconcile-history"
+	reconcileHistoryDataKey         = "history"
+)
+
+// ReconcileHistoryEntry is a single recorded reconcile outcome.
+type ReconcileHistoryEntry struct {
+	Timestamp metav1.Time `json:"timestamp"`
+	Stage     string      `json:"stage"`
+	Result    string      `json:"result"`
+
+	// DeployDuration is how long a successfully finished deploy took
+	// (zero when the deploy failed or has not finished).
+	DeployDuration metav1.Duration `json:"deployDuration,omitempty"`
+}
+
+// ReconcileHistoryRecorder persists a rolling summary of reconcile
+// outcomes into a per-App ConfigMap. It's optional; a nil recorder
+// disables history recording.
+type ReconcileHistoryRecorder struct {
+	coreClient kubernetes.Interface
+	maxEntries int
+}
+
+// NewReconcileHistoryRecorder returns a recorder that keeps at
+// most maxEntries entries per App.
+func NewReconcileHistoryRecorder(coreClient kubernetes.Interface, maxEntries int) *ReconcileHistoryRecorder {
+	return &ReconcileHistoryRecorder{coreClient: coreClient, maxEntries: maxEntries}
+}
+
+// Record appends an entry summarizing given status to the App's history
+// ConfigMap, dropping oldest entries beyond the configured size.
+func (r *ReconcileHistoryRecorder) Record(name, namespace string, status kcv1alpha1.AppStatus) error {
+	entries, existingCM, err := r.existingEntries(name, namespace)
+	if err != nil {
+		return err
+	}
+
+	entries = append(entries, newReconcileHistoryEntry(status))
+	if len(entries) > r.maxEntries {
+		entries = entries[len(entries)-r.maxEntries:]
+	}
+
+	historyBs, err := yaml.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("Marshaling reconcile history: %s", err)
+	}
+
+	if existingCM == nil {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + reconcileHistoryConfigMapSuffix,
+				Namespace: namespace,
+			},
+			Data: map[string]string{reconcileHistoryDataKey: string(historyBs)},
+		}
+		_, err = r.coreClient.CoreV1().ConfigMaps(namespace).Create(context.Background(), cm, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("Creating reconcile history: %s", err)
+		}
+		return nil
+	}
+
+	existingCM.Data = map[string]string{reconcileHistoryDataKey: string(historyBs)}
+
+	_, err = r.coreClient.CoreV1().ConfigMaps(namespace).Update(context.Background(), existingCM, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("Updating reconcile history: %s", err)
+	}
+
+	return nil
+}
+
+func (r *ReconcileHistoryRecorder) existingEntries(name, namespace string) ([]ReconcileHistoryEntry, *corev1.ConfigMap, error) {
+	cm, err := r.coreClient.CoreV1().ConfigMaps(namespace).Get(
+		context.Background(), name+reconcileHistoryConfigMapSuffix, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("Fetching reconcile history: %s", err)
+	}
+
+	var entries []ReconcileHistoryEntry
+	err = yaml.Unmarshal([]byte(cm.Data[reconcileHistoryDataKey]), &entries)
+	if err != nil {
+		// Do not fail reconcile recording on corrupted history; start over
+		entries = nil
+	}
+
+	return entries, cm, nil
+}
+
+func newReconcileHistoryEntry(status kcv1alpha1.AppStatus) ReconcileHistoryEntry {
+	entry := ReconcileHistoryEntry{
+		Timestamp: metav1.NewTime(time.Now().UTC()),
+		Stage:     "reconcile",
+		Result:    status.FriendlyDescription,
+	}
+
+	switch {
+	case status.Fetch != nil && status.Fetch.Error != "":
+		entry.Stage = "fetch"
+	case status.Template != nil && status.Template.Error != "":
+		entry.Stage = "template"
+	case status.Deploy != nil:
+		entry.Stage = "deploy"
+		if status.Deploy.Finished && status.Deploy.ExitCode == 0 && !status.Deploy.StartedAt.IsZero() {
+			entry.DeployDuration = metav1.Duration{Duration: status.Deploy.UpdatedAt.Sub(status.Deploy.StartedAt.Time)}
+		}
+	}
+
+	return entry
+}
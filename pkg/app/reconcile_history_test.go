@@ -0,0 +1,69 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	apppkg "carvel.dev/kapp-controller/pkg/app"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/yaml"
+)
+
+func Test_ReconcileHistoryRecorder_RotatesAtConfiguredSize(t *testing.T) {
+	k8scs := k8sfake.NewSimpleClientset()
+	recorder := apppkg.NewReconcileHistoryRecorder(k8scs, 2)
+
+	for i := 0; i < 3; i++ {
+		status := v1alpha1.AppStatus{
+			Deploy:        &v1alpha1.AppStatusDeploy{},
+			GenericStatus: v1alpha1.GenericStatus{FriendlyDescription: fmt.Sprintf("Reconcile succeeded (%d)", i)},
+		}
+		err := recorder.Record("simple-app", "default", status)
+		require.NoError(t, err)
+	}
+
+	cm, err := k8scs.CoreV1().ConfigMaps("default").Get(
+		context.Background(), "simple-app-reconcile-history", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	var entries []apppkg.ReconcileHistoryEntry
+	err = yaml.Unmarshal([]byte(cm.Data["history"]), &entries)
+	require.NoError(t, err)
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, "Reconcile succeeded (1)", entries[0].Result)
+	assert.Equal(t, "Reconcile succeeded (2)", entries[1].Result)
+	assert.Equal(t, "deploy", entries[0].Stage)
+}
+
+func Test_ReconcileHistoryRecorder_RecordsFailedStage(t *testing.T) {
+	k8scs := k8sfake.NewSimpleClientset()
+	recorder := apppkg.NewReconcileHistoryRecorder(k8scs, 5)
+
+	status := v1alpha1.AppStatus{
+		Fetch:         &v1alpha1.AppStatusFetch{Error: "Fetching resources: exit status 1", ExitCode: 1},
+		GenericStatus: v1alpha1.GenericStatus{FriendlyDescription: "Reconcile failed: Fetching resources: exit status 1"},
+	}
+	err := recorder.Record("simple-app", "default", status)
+	require.NoError(t, err)
+
+	cm, err := k8scs.CoreV1().ConfigMaps("default").Get(
+		context.Background(), "simple-app-reconcile-history", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	var entries []apppkg.ReconcileHistoryEntry
+	err = yaml.Unmarshal([]byte(cm.Data["history"]), &entries)
+	require.NoError(t, err)
+
+	require.Len(t, entries, 1)
+	assert.Equal(t, "fetch", entries[0].Stage)
+	assert.Equal(t, "Reconcile failed: Fetching resources: exit status 1", entries[0].Result)
+}
@@ -0,0 +1,96 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/go-logr/logr/funcr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+var reconcileIDRegexp = regexp.MustCompile(`"reconcile-id"="([^"]+)"`)
+
+func Test_ReconcileID_AttachedToLogEntriesWithinOneReconcile(t *testing.T) {
+	var logLines []string
+	log := funcr.New(func(_ string, args string) {
+		logLines = append(logLines, args)
+	}, funcr.Options{})
+
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      "simple-app",
+			Namespace: "pkg-standalone",
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+
+	var cmdCount int32
+	cmdRunner := countingCmdRunner{count: &cmdCount}
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(&app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
+
+	_, err := crdApp.Reconcile(true)
+	require.NoError(t, err)
+
+	firstIDs := uniqueReconcileIDs(logLines)
+	require.Len(t, firstIDs, 1, "expected a single reconcile ID across entries of one reconcile")
+
+	logLines = nil
+	_, err = crdApp.Reconcile(true)
+	require.NoError(t, err)
+
+	secondIDs := uniqueReconcileIDs(logLines)
+	require.Len(t, secondIDs, 1)
+	assert.NotEqual(t, firstIDs[0], secondIDs[0], "expected a fresh ID per reconcile")
+}
+
+func uniqueReconcileIDs(lines []string) []string {
+	seen := map[string]struct{}{}
+	var ids []string
+	for _, line := range lines {
+		match := reconcileIDRegexp.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		if _, found := seen[match[1]]; !found {
+			seen[match[1]] = struct{}{}
+			ids = append(ids, match[1])
+		}
+	}
+	return ids
+}
@@ -0,0 +1,49 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// pauseDataKey is the ConfigMap data key checked by ReconcilePauser.
+	pauseDataKey = "pause"
+	// globallyPausedDesc is recorded in App status while paused.
+	globallyPausedDesc = "Reconcile globally paused"
+)
+
+// ReconcilePauser reports whether reconciliation of all Apps is
+// globally paused via a ConfigMap switch. It allows operators to
+// stop reconciles in an emergency without deleting any resources.
+type ReconcilePauser struct {
+	coreClient kubernetes.Interface
+	namespace  string
+	name       string
+}
+
+// NewReconcilePauser returns a ReconcilePauser checking the named
+// ConfigMap in the given namespace.
+func NewReconcilePauser(coreClient kubernetes.Interface, namespace string, name string) *ReconcilePauser {
+	return &ReconcilePauser{coreClient: coreClient, namespace: namespace, name: name}
+}
+
+// IsPaused returns true when the pause ConfigMap exists and its
+// "pause" key is set to "true". A missing ConfigMap means not paused.
+func (p *ReconcilePauser) IsPaused() (bool, error) {
+	configMap, err := p.coreClient.CoreV1().ConfigMaps(p.namespace).Get(context.Background(), p.name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("Getting pause ConfigMap: %s", err)
+	}
+
+	return configMap.Data[pauseDataKey] == "true", nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_GlobalPause_SkipsReconcileWhileEnabled(t *testing.T) {
+	log := logf.Log.WithName("kc")
+
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      "simple-app",
+			Namespace: "pkg-standalone",
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+		},
+	}
+
+	pauseConfigMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "kc-pause", Namespace: "kapp-controller"},
+		Data:       map[string]string{"pause": "true"},
+	}
+
+	k8scs := k8sfake.NewSimpleClientset(pauseConfigMap)
+	kappcs := fake.NewSimpleClientset(&app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, blockingCmdRunner{})
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, blockingCmdRunner{})
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, blockingCmdRunner{}, log)
+
+	pauser := NewReconcilePauser(k8scs, "kapp-controller", "kc-pause")
+
+	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, pauser, nil)
+
+	result, err := crdApp.Reconcile(false)
+	assert.Nil(t, err, "unexpected error with reconciling", err)
+	assert.NotZero(t, result.RequeueAfter, "expected requeue while paused")
+
+	// No fetch (or any other stage) ran; only the pause note was recorded
+	assert.Nil(t, crdApp.app.Status().Fetch)
+
+	pausedApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "simple-app", metav1.GetOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "Reconcile globally paused", pausedApp.Status.FriendlyDescription)
+
+	// Disabling the pause lets reconciles proceed again
+	pauseConfigMap.Data["pause"] = "false"
+	_, err = k8scs.CoreV1().ConfigMaps("kapp-controller").Update(context.Background(), pauseConfigMap, metav1.UpdateOptions{})
+	assert.Nil(t, err)
+
+	_, err = crdApp.Reconcile(false)
+	assert.Nil(t, err, "unexpected error with reconciling", err)
+	assert.NotNil(t, crdApp.app.Status().Fetch, "expected fetch to run once unpaused")
+}
+
+func Test_ReconcilePauser_MissingConfigMapMeansNotPaused(t *testing.T) {
+	pauser := NewReconcilePauser(k8sfake.NewSimpleClientset(), "kapp-controller", "kc-pause")
+
+	paused, err := pauser.IsPaused()
+	assert.Nil(t, err)
+	assert.False(t, paused)
+}
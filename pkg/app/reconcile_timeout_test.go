@@ -0,0 +1,97 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	goexec "os/exec"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// blockingCmdRunner succeeds immediately for plain runs and blocks
+// cancelable runs until they are canceled.
+type blockingCmdRunner struct{}
+
+func (blockingCmdRunner) Run(*goexec.Cmd) error { return nil }
+
+func (blockingCmdRunner) RunWithCancel(_ *goexec.Cmd, cancelCh chan struct{}) error {
+	<-cancelCh
+	return goexec.ErrNotFound // any error; deploy was canceled
+}
+
+func Test_ReconcileTimeout_CancelsDeployAndRecordsTimeout(t *testing.T) {
+	log := logf.Log.WithName("kc")
+
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      "simple-app",
+			Namespace: "pkg-standalone",
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+
+	kubeconfigSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-kubeconfig", Namespace: "pkg-standalone"},
+		Data: map[string][]byte{"value": []byte(`
+apiVersion: v1
+kind: Config
+clusters:
+- cluster: {server: https://cluster.example.com}
+  name: dst-cluster
+users:
+- user: {token: token}
+  name: dst-user
+contexts:
+- context: {cluster: dst-cluster, user: dst-user}
+  name: dst-ctx
+current-context: dst-ctx
+`)},
+	}
+
+	cmdRunner := blockingCmdRunner{}
+	k8scs := k8sfake.NewSimpleClientset(kubeconfigSecret)
+	kappcs := fake.NewSimpleClientset(&app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second, ReconcileTimeout: 200 * time.Millisecond}, nil, nil, nil)
+
+	_, err := crdApp.Reconcile(false)
+	assert.Nil(t, err, "unexpected error with reconciling", err)
+
+	status := crdApp.app.Status()
+	assert.NotNil(t, status.Deploy)
+	assert.Contains(t, status.Deploy.Error, "exceeded reconcile timeout")
+	assert.Contains(t, status.FriendlyDescription, "Reconcile failed")
+}
@@ -37,6 +37,9 @@ func NewReconcileTimer(app v1alpha1.App, opts ReconcileTimerOpts) ReconcileTimer
 
 func (rt ReconcileTimer) DurationUntilReady(err error) time.Duration {
 	if err != nil || rt.hasReconcileStatus(v1alpha1.ReconcileFailed) || rt.hasReconcileStatus(v1alpha1.DeleteFailed) {
+		if rt.fetchFailedPermanently() {
+			return rt.applyJitter(rt.syncPeriod())
+		}
 		return rt.failureSyncPeriod()
 	}
 
@@ -60,7 +63,7 @@ func (rt ReconcileTimer) IsReadyAt(timeAt time.Time) bool {
 		return true
 	}
 
-	if rt.hasReconcileStatus(v1alpha1.ReconcileFailed) || rt.hasReconcileStatus(v1alpha1.DeleteFailed) {
+	if (rt.hasReconcileStatus(v1alpha1.ReconcileFailed) || rt.hasReconcileStatus(v1alpha1.DeleteFailed)) && !rt.fetchFailedPermanently() {
 		if timeAt.UTC().Sub(lastReconcileTime) >= rt.failureSyncPeriod() {
 			return true
 		}
@@ -100,6 +103,17 @@ func (rt ReconcileTimer) failureSyncPeriod() time.Duration {
 	return rt.syncPeriod()
 }
 
+// fetchFailedPermanently reports whether the recorded failure came from
+// the fetch stage with an error class that does not warrant the
+// accelerated failure backoff (e.g. bad credentials).
+func (rt ReconcileTimer) fetchFailedPermanently() bool {
+	fetch := rt.app.Status.Fetch
+	if fetch == nil || fetch.ExitCode == 0 {
+		return false
+	}
+	return !currentFetchErrorClassifier().Retryable(fetch.Stderr + "\n" + fetch.Error)
+}
+
 func (rt ReconcileTimer) hasReconcileStatus(c v1alpha1.ConditionType) bool {
 	for _, cond := range rt.app.Status.Conditions {
 		if cond.Type == c {
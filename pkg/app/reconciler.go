@@ -6,6 +6,7 @@ package app
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
 	kcv1alpha1 "carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
@@ -31,17 +32,22 @@ type Reconciler struct {
 	appRefTracker   *reftracker.AppRefTracker
 	appUpdateStatus *reftracker.AppUpdateStatus
 	componentInfo   ComponentInfo
+	debouncer       *specDebouncer
 }
 
-// NewReconciler constructs new Reconciler.
+// NewReconciler constructs new Reconciler. debouncePeriod is the quiet
+// period during which rapid successive spec edits coalesce into a
+// single reconcile (0 disables debouncing).
 func NewReconciler(appClient kcclient.Interface, log logr.Logger, crdAppFactory CRDAppFactory,
-	appRefTracker *reftracker.AppRefTracker, appUpdateStatus *reftracker.AppUpdateStatus, componentInfo ComponentInfo) *Reconciler {
+	appRefTracker *reftracker.AppRefTracker, appUpdateStatus *reftracker.AppUpdateStatus,
+	componentInfo ComponentInfo, debouncePeriod time.Duration) *Reconciler {
 	return &Reconciler{appClient: appClient,
 		log:             log,
 		crdAppFactory:   crdAppFactory,
 		appRefTracker:   appRefTracker,
 		appUpdateStatus: appUpdateStatus,
 		componentInfo:   componentInfo,
+		debouncer:       newSpecDebouncer(debouncePeriod),
 	}
 }
 
@@ -80,6 +86,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 	if err != nil {
 		if errors.IsNotFound(err) {
 			log.Info("Could not find App")
+			r.debouncer.forget(request.NamespacedName.String())
 			return reconcile.Result{}, nil // No requeue
 		}
 
@@ -87,6 +94,17 @@ func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (
 		return reconcile.Result{}, err
 	}
 
+	// Rapid successive spec edits are coalesced: a freshly bumped
+	// generation waits out the quiet period so only the final spec is
+	// reconciled. Deletion is never debounced.
+	if existingApp.DeletionTimestamp == nil {
+		postpone, after := r.debouncer.postpone(request.NamespacedName.String(), existingApp.Generation, time.Now())
+		if postpone {
+			log.Info("Postponing reconcile: debouncing rapid spec edits")
+			return reconcile.Result{RequeueAfter: after}, nil
+		}
+	}
+
 	crdApp := r.crdAppFactory.NewCRDApp(existingApp, log)
 	r.UpdateAppRefs(crdApp.ResourceRefs(), existingApp)
 
@@ -0,0 +1,107 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/exec"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// progressDeploy succeeds with a configurable finished flag so a deploy
+// can be left "still converging"
+type progressDeploy struct {
+	finished bool
+}
+
+func (d progressDeploy) Deploy(_ string, _ func(), changedFunc func(exec.CmdRunResult)) exec.CmdRunResult {
+	result := exec.CmdRunResult{Finished: d.finished}
+	changedFunc(result)
+	return result
+}
+
+func (d progressDeploy) Delete(func(), func(exec.CmdRunResult)) exec.CmdRunResult {
+	return exec.CmdRunResult{Finished: true}
+}
+
+func (d progressDeploy) Inspect() exec.CmdRunResult { return exec.CmdRunResult{Finished: true} }
+
+type progressDeployBackend struct {
+	name     string
+	finished bool
+}
+
+func (b progressDeployBackend) Name() string { return b.name }
+
+func (b progressDeployBackend) NewDeploy(deploy.BackendOpts) (deploy.Deploy, error) {
+	return progressDeploy{b.finished}, nil
+}
+
+func requeueTestCRDApp(t *testing.T, backendName string) *CRDApp {
+	log := logf.Log.WithName("kc")
+
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:         uuid.NewUUID(),
+			Name:        "simple-app",
+			Namespace:   "pkg-standalone",
+			Annotations: map[string]string{"kappctrl.k14s.io/deploy-backend": backendName},
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+
+	cmdRunner := noopTracingCmdRunner{}
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(&app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	return NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
+}
+
+func Test_Requeue_ShorterWhileDeployInProgress(t *testing.T) {
+	deploy.RegisterBackend(progressDeployBackend{name: "requeue-progressing", finished: false})
+	deploy.RegisterBackend(progressDeployBackend{name: "requeue-finished", finished: true})
+
+	progressingApp := requeueTestCRDApp(t, "requeue-progressing")
+	progressingResult, err := progressingApp.Reconcile(true)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+
+	reconciledApp := requeueTestCRDApp(t, "requeue-finished")
+	reconciledResult, err := reconciledApp.Reconcile(true)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+
+	require.Equal(t, deployProgressRequeuePeriod, progressingResult.RequeueAfter,
+		"Expected in-progress deploy to requeue after the shorter progress period")
+	require.Greater(t, reconciledResult.RequeueAfter, progressingResult.RequeueAfter,
+		"Expected reconciled app to requeue later than a progressing one")
+}
@@ -0,0 +1,54 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	kcv1alpha1 "carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	vendirconf "carvel.dev/vendir/pkg/vendir/config"
+)
+
+// ResolvedGitSHAAnnKey records on the App the commit its git fetch
+// source resolved to so that kubectl get shows it without inspecting
+// status.
+const ResolvedGitSHAAnnKey = "kappctrl.carvel.dev/resolved-git-sha"
+
+// resolvedGitSHA extracts the commit SHA the first git fetch source
+// resolved to from the vendir lock config printed by the fetch stage.
+// Returns an empty string when no git source was fetched or the output
+// is not a lock config.
+func resolvedGitSHA(lockOutput string) string {
+	lockConfig, err := vendirconf.NewLockConfigFromBytes([]byte(lockOutput))
+	if err != nil {
+		return ""
+	}
+
+	for _, dir := range lockConfig.Directories {
+		for _, contents := range dir.Contents {
+			if contents.Git != nil && contents.Git.SHA != "" {
+				return contents.Git.SHA
+			}
+		}
+	}
+
+	return ""
+}
+
+// recordResolvedGitSHA annotates the App with the commit the last fetch
+// resolved to (no-op when there is none or it is already recorded).
+func (a *CRDApp) recordResolvedGitSHA() error {
+	fetchStatus := a.app.Status().Fetch
+	if fetchStatus == nil || fetchStatus.ResolvedGitSHA == "" {
+		return nil
+	}
+	if a.appModel.Annotations[ResolvedGitSHAAnnKey] == fetchStatus.ResolvedGitSHA {
+		return nil
+	}
+
+	return a.updateApp(func(app *kcv1alpha1.App) {
+		if app.Annotations == nil {
+			app.Annotations = map[string]string{}
+		}
+		app.Annotations[ResolvedGitSHAAnnKey] = fetchStatus.ResolvedGitSHA
+	})
+}
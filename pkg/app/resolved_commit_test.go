@@ -0,0 +1,138 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	goexec "os/exec"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const testGitSHA = "4a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b"
+
+const testGitLockOutput = `apiVersion: vendir.k14s.io/v1alpha1
+kind: LockConfig
+directories:
+- path: "0"
+  contents:
+  - path: .
+    git:
+      sha: ` + testGitSHA + `
+      commitTitle: Add feature
+`
+
+// neverSkipTLSConfig never skips TLS verification
+type neverSkipTLSConfig struct{}
+
+func (neverSkipTLSConfig) ShouldSkipTLSForAuthority(string) bool { return false }
+
+// lockOutputCmdRunner writes the given lock config to stdout of vendir
+// invocations and succeeds without running anything
+type lockOutputCmdRunner struct {
+	output string
+}
+
+func (r lockOutputCmdRunner) Run(cmd *goexec.Cmd) error {
+	if len(cmd.Args) > 0 && cmd.Args[0] == "vendir" && cmd.Stdout != nil {
+		fmt.Fprint(cmd.Stdout, r.output)
+	}
+	return nil
+}
+
+func (r lockOutputCmdRunner) RunWithCancel(cmd *goexec.Cmd, _ chan struct{}) error {
+	return r.Run(cmd)
+}
+
+func Test_ResolvedGitSHA_ParsedFromLockOutput(t *testing.T) {
+	assert.Equal(t, testGitSHA, resolvedGitSHA(testGitLockOutput))
+
+	nonGitLock := `apiVersion: vendir.k14s.io/v1alpha1
+kind: LockConfig
+directories:
+- path: "0"
+  contents:
+  - path: .
+    inline: {}
+`
+	assert.Equal(t, "", resolvedGitSHA(nonGitLock))
+	assert.Equal(t, "", resolvedGitSHA("not a lock config"))
+	assert.Equal(t, "", resolvedGitSHA(""))
+}
+
+func Test_Reconcile_RecordsResolvedGitCommit(t *testing.T) {
+	log := logf.Log.WithName("kc")
+
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      "simple-app",
+			Namespace: "pkg-standalone",
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Git: &v1alpha1.AppFetchGit{URL: "https://example.com/app.git", Ref: "origin/main"}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+
+	cmdRunner := lockOutputCmdRunner{output: testGitLockOutput}
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(&app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{SkipTLSConfig: neverSkipTLSConfig{}}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	crdApp := NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second}, nil, nil, nil)
+
+	_, err := crdApp.Reconcile(true)
+	require.NoError(t, err)
+
+	require.NotNil(t, crdApp.app.Status().Fetch)
+	assert.Equal(t, testGitSHA, crdApp.app.Status().Fetch.ResolvedGitSHA)
+
+	updatedApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "simple-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, testGitSHA, updatedApp.Annotations[ResolvedGitSHAAnnKey])
+}
+
+func Test_Reconcile_NoGitCommitAnnotationForNonGitSources(t *testing.T) {
+	app := fetchFallbackTestApp()
+	app.Spec.Fetch[0].Fallbacks = nil
+
+	failures := 0
+	crdApp := fetchFallbackTestCRDApp(t, &app, vendirFailingCmdRunner{failures: &failures})
+
+	_, err := crdApp.Reconcile(true)
+	require.NoError(t, err)
+
+	require.NotNil(t, crdApp.app.Status().Fetch)
+	assert.Equal(t, "", crdApp.app.Status().Fetch.ResolvedGitSHA)
+	assert.NotContains(t, crdApp.appModel.Annotations, ResolvedGitSHAAnnKey)
+}
@@ -0,0 +1,109 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	goexec "os/exec"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"carvel.dev/kapp-controller/pkg/metrics"
+	"carvel.dev/kapp-controller/pkg/template"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// stdoutWritingCmdRunner succeeds, writing a fixed stdout for every
+// command (enough to feed templated output into the reconcile flow)
+type stdoutWritingCmdRunner struct {
+	stdout string
+}
+
+func (r stdoutWritingCmdRunner) Run(cmd *goexec.Cmd) error {
+	if cmd.Stdout != nil {
+		cmd.Stdout.Write([]byte(r.stdout))
+	}
+	return nil
+}
+
+func (r stdoutWritingCmdRunner) RunWithCancel(cmd *goexec.Cmd, _ chan struct{}) error {
+	return r.Run(cmd)
+}
+
+func resourceLimitCRDApp(t *testing.T, templatedOutput string, maxResourceCount int) *CRDApp {
+	log := logf.Log.WithName("kc")
+
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      "simple-app",
+			Namespace: "pkg-standalone",
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+
+	cmdRunner := stdoutWritingCmdRunner{stdout: templatedOutput}
+	k8scs := k8sfake.NewSimpleClientset(deleteTestKubeconfigSecret())
+	kappcs := fake.NewSimpleClientset(&app)
+	fetchFac := fetch.NewFactory(k8scs, fetch.VendirOpts{}, cmdRunner)
+	tmpFac := template.NewFactory(k8scs, fetchFac, false, cmdRunner)
+	deployFac := deploy.NewFactory(k8scs, kubeconfig.NewKubeconfig(k8scs, log), nil, cmdRunner, log)
+
+	return NewCRDApp(&app, log, metrics.NewMetrics(), kappcs, fetchFac, tmpFac, deployFac, FakeComponentInfo{},
+		Opts{MinimumSyncPeriod: 30 * time.Second, MaxResourceCount: maxResourceCount}, nil, nil, nil)
+}
+
+func Test_MaxResourceCount_UnderLimitDeploys(t *testing.T) {
+	templatedOutput := "kind: ConfigMap\n---\nkind: Secret\n"
+
+	crdApp := resourceLimitCRDApp(t, templatedOutput, 3)
+
+	_, err := crdApp.Reconcile(true)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+
+	require.NotNil(t, crdApp.app.Status().Deploy, "Expected deploy to be attempted under the limit")
+	for _, cond := range crdApp.app.Status().Conditions {
+		assert.NotEqual(t, v1alpha1.ReconcileFailed, cond.Type)
+	}
+}
+
+func Test_MaxResourceCount_OverLimitFailsReconcile(t *testing.T) {
+	templatedOutput := "kind: ConfigMap\n---\nkind: Secret\n---\nkind: Deployment\n"
+
+	crdApp := resourceLimitCRDApp(t, templatedOutput, 2)
+
+	_, err := crdApp.Reconcile(true)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+
+	var failedMsg string
+	for _, cond := range crdApp.app.Status().Conditions {
+		if cond.Type == v1alpha1.ReconcileFailed {
+			failedMsg = cond.Message
+		}
+	}
+	require.Contains(t, failedMsg, "Templated output has 3 resources, exceeding the configured max of 2")
+	assert.Nil(t, crdApp.app.Status().Deploy, "Expected deploy to not be attempted over the limit")
+}
@@ -0,0 +1,87 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+func Test_Reconcile_SkipStateSurvivesRestart(t *testing.T) {
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:        uuid.NewUUID(),
+			Name:       "restarted-app",
+			Namespace:  "pkg-standalone",
+			Generation: 1,
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+
+	kappcs := fake.NewSimpleClientset(&app)
+	crdApp := dependentCRDApp(t, kappcs, &app)
+
+	_, err := crdApp.Reconcile(true)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+
+	reconciledApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "restarted-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.NotNil(t, reconciledApp.Status.Template)
+	require.NotEmpty(t, reconciledApp.Status.Template.Digest, "Expected template digest to be persisted in status")
+	firstDeployUpdatedAt := reconciledApp.Status.Deploy.UpdatedAt
+
+	// Simulate a controller restart: in-memory caches are gone and a
+	// fresh CRDApp is built from the object read off the cluster
+	clearTemplateRenderCache(string(app.UID))
+
+	restartedCRDApp := dependentCRDApp(t, kappcs, reconciledApp)
+
+	_, err = restartedCRDApp.Reconcile(false)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+
+	appAfterRestart, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "restarted-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, firstDeployUpdatedAt, appAfterRestart.Status.Deploy.UpdatedAt,
+		"Expected no redeploy after restart while spec generation is unchanged")
+}
+
+func Test_TemplateDigestSeedPreventsSpuriousDiff(t *testing.T) {
+	key := "template-digest-seeded"
+	defer clearTemplateRenderCache(key)
+
+	// Digest persisted in status before the restart
+	seedTemplateRenderHash(key, templateOutputDigest("key: value\n"))
+
+	// Unchanged render after restart is recognized via the seeded hash
+	require.Empty(t, templateOutputDiff(key, "key: value\n"))
+
+	// A changed render right after seeding has no cached text to diff
+	clearTemplateRenderCache(key)
+	seedTemplateRenderHash(key, templateOutputDigest("key: value\n"))
+	require.Empty(t, templateOutputDiff(key, "key: changed\n"))
+
+	// Once a render is cached in memory, diffing works as usual
+	diff := templateOutputDiff(key, "key: value\n")
+	require.Contains(t, diff, "-key: changed")
+	require.Contains(t, diff, "+key: value")
+}
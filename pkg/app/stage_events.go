@@ -0,0 +1,60 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+// StageEvent describes a reconcile stage transition, mirroring the
+// stage lines the CLI app watcher prints.
+type StageEvent struct {
+	Stage string // "fetch", "template" or "deploy"
+	Phase string // "started", "succeeded" or "failed"
+	// Error holds the failure message when Phase is "failed"
+	Error string
+}
+
+const (
+	// StageEventStarted marks the beginning of a stage
+	StageEventStarted = "started"
+	// StageEventSucceeded marks a stage that completed without error
+	StageEventSucceeded = "succeeded"
+	// StageEventFailed marks a stage that completed with an error
+	StageEventFailed = "failed"
+)
+
+// SubscribeStageEvents returns a channel on which stage transition
+// events are delivered in the order they occur during reconcile.
+// Sends never block reconciliation: events are dropped if the
+// subscriber falls behind the given buffer size.
+func (a *App) SubscribeStageEvents(buffer int) <-chan StageEvent {
+	a.stageSubsLock.Lock()
+	defer a.stageSubsLock.Unlock()
+
+	ch := make(chan StageEvent, buffer)
+	a.stageSubs = append(a.stageSubs, ch)
+	return ch
+}
+
+func (a *App) notifyStageStarted(stage string) {
+	a.notifyStage(StageEvent{Stage: stage, Phase: StageEventStarted})
+}
+
+func (a *App) notifyStageCompleted(stage string, err error) {
+	event := StageEvent{Stage: stage, Phase: StageEventSucceeded}
+	if err != nil {
+		event.Phase = StageEventFailed
+		event.Error = err.Error()
+	}
+	a.notifyStage(event)
+}
+
+func (a *App) notifyStage(event StageEvent) {
+	a.stageSubsLock.Lock()
+	defer a.stageSubsLock.Unlock()
+
+	for _, ch := range a.stageSubs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
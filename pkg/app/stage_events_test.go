@@ -0,0 +1,98 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+func Test_StageEvents_DeliveredInOrder(t *testing.T) {
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      "eventful-app",
+			Namespace: "pkg-standalone",
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+		},
+	}
+
+	kappcs := fake.NewSimpleClientset(&app)
+	crdApp := dependentCRDApp(t, kappcs, &app)
+
+	eventsCh := crdApp.app.SubscribeStageEvents(20)
+
+	_, err := crdApp.Reconcile(true)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+
+	var events []StageEvent
+	for len(eventsCh) > 0 {
+		events = append(events, <-eventsCh)
+	}
+
+	require.Equal(t, []StageEvent{
+		{Stage: "fetch", Phase: StageEventStarted},
+		{Stage: "fetch", Phase: StageEventSucceeded},
+		{Stage: "template", Phase: StageEventStarted},
+		{Stage: "template", Phase: StageEventSucceeded},
+		{Stage: "deploy", Phase: StageEventStarted},
+		{Stage: "deploy", Phase: StageEventSucceeded},
+	}, events)
+}
+
+func Test_StageEvents_FailedStageCarriesError(t *testing.T) {
+	app := v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      "eventful-app",
+			Namespace: "pkg-standalone",
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			// No deploy section; the deploy stage fails
+		},
+	}
+
+	kappcs := fake.NewSimpleClientset(&app)
+	crdApp := dependentCRDApp(t, kappcs, &app)
+
+	eventsCh := crdApp.app.SubscribeStageEvents(20)
+
+	_, err := crdApp.Reconcile(true)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+
+	var events []StageEvent
+	for len(eventsCh) > 0 {
+		events = append(events, <-eventsCh)
+	}
+
+	require.NotEmpty(t, events)
+	last := events[len(events)-1]
+	require.Equal(t, "deploy", last.Stage)
+	require.Equal(t, StageEventFailed, last.Phase)
+	require.Contains(t, last.Error, "Expected exactly one deploy option")
+}
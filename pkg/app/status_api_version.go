@@ -0,0 +1,64 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
+)
+
+// kappctrlAPIGroup is the API group serving App resources.
+const kappctrlAPIGroup = "kappctrl.k14s.io"
+
+// supportedStatusAPIVersions lists the kappctrl group versions this
+// controller has generated clients for, newest first.
+var supportedStatusAPIVersions = []string{"v1alpha1"}
+
+// negotiateStatusAPIVersion picks the kappctrl API group version used
+// for status subresource updates: the version the server reports as
+// preferred when a client for it exists, otherwise the newest supported
+// version the server still serves.
+func negotiateStatusAPIVersion(disco discovery.DiscoveryInterface) (string, error) {
+	groups, err := disco.ServerGroups()
+	if err != nil {
+		return "", fmt.Errorf("Discovering served API groups: %s", err)
+	}
+
+	for _, group := range groups.Groups {
+		if group.Name != kappctrlAPIGroup {
+			continue
+		}
+
+		for _, supported := range supportedStatusAPIVersions {
+			if group.PreferredVersion.Version == supported {
+				return supported, nil
+			}
+		}
+
+		// Preferred version is unknown to this controller; fall back to
+		// the newest supported version the server still serves
+		for _, supported := range supportedStatusAPIVersions {
+			for _, served := range group.Versions {
+				if served.Version == supported {
+					return supported, nil
+				}
+			}
+		}
+
+		return "", fmt.Errorf("Expected a supported version of API group '%s' to be served, but served versions are %v",
+			kappctrlAPIGroup, servedVersionNames(group.Versions))
+	}
+
+	return "", fmt.Errorf("Expected API group '%s' to be served, but it was not", kappctrlAPIGroup)
+}
+
+func servedVersionNames(versions []metav1.GroupVersionForDiscovery) []string {
+	var names []string
+	for _, version := range versions {
+		names = append(names, version.Version)
+	}
+	return names
+}
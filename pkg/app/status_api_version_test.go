@@ -0,0 +1,78 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func fakeClientServingVersions(groupVersions ...string) *fake.Clientset {
+	kappcs := fake.NewSimpleClientset()
+	for _, gv := range groupVersions {
+		kappcs.Fake.Resources = append(kappcs.Fake.Resources, &metav1.APIResourceList{GroupVersion: gv})
+	}
+	return kappcs
+}
+
+func Test_NegotiateStatusAPIVersion_UsesServedPreferredVersion(t *testing.T) {
+	kappcs := fakeClientServingVersions("kappctrl.k14s.io/v1alpha1")
+
+	version, err := negotiateStatusAPIVersion(kappcs.Discovery())
+	require.NoError(t, err)
+	assert.Equal(t, "v1alpha1", version)
+}
+
+func Test_NegotiateStatusAPIVersion_FallsBackToSupportedServedVersion(t *testing.T) {
+	// Preferred version (first listed) is newer than any generated
+	// client, but v1alpha1 is still served
+	kappcs := fakeClientServingVersions("kappctrl.k14s.io/v1beta1", "kappctrl.k14s.io/v1alpha1")
+
+	version, err := negotiateStatusAPIVersion(kappcs.Discovery())
+	require.NoError(t, err)
+	assert.Equal(t, "v1alpha1", version)
+}
+
+func Test_NegotiateStatusAPIVersion_ErrsWhenNoSupportedVersionServed(t *testing.T) {
+	kappcs := fakeClientServingVersions("kappctrl.k14s.io/v1beta1")
+
+	_, err := negotiateStatusAPIVersion(kappcs.Discovery())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "served versions are [v1beta1]")
+}
+
+func Test_NegotiateStatusAPIVersion_ErrsWhenGroupNotServed(t *testing.T) {
+	kappcs := fakeClientServingVersions("data.packaging.carvel.dev/v1alpha1")
+
+	_, err := negotiateStatusAPIVersion(kappcs.Discovery())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Expected API group 'kappctrl.k14s.io' to be served")
+}
+
+func Test_UpdateStatus_UsesNegotiatedServedVersion(t *testing.T) {
+	app := deletingApp()
+	app.DeletionTimestamp = nil
+	app.Finalizers = nil
+
+	kappcs := fake.NewSimpleClientset(&app)
+	kappcs.Fake.Resources = append(kappcs.Fake.Resources,
+		&metav1.APIResourceList{GroupVersion: "kappctrl.k14s.io/v1beta1"},
+		&metav1.APIResourceList{GroupVersion: "kappctrl.k14s.io/v1alpha1"})
+
+	crdApp := dependentCRDApp(t, kappcs, &app)
+	crdApp.app.app.Status.FriendlyDescription = "negotiated"
+
+	err := crdApp.updateStatus("test")
+	require.NoError(t, err)
+	assert.Equal(t, "v1alpha1", crdApp.statusAPIVersion)
+
+	updatedApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), app.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "negotiated", updatedApp.Status.FriendlyDescription)
+}
@@ -0,0 +1,103 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// syncWindowRequeuePeriod is how often an app outside of its sync
+// windows is revisited to check whether a window has opened.
+const syncWindowRequeuePeriod = 1 * time.Minute
+
+const outsideSyncWindowDesc = "Waiting for sync window to open before reconciling"
+
+// syncWindowNow is swapped out in tests to reconcile at a fixed time
+var syncWindowNow = time.Now
+
+// withinSyncWindows reports whether now falls inside any of the given
+// windows. No windows means reconciliation is always allowed.
+func withinSyncWindows(windows []v1alpha1.AppSyncWindow, now time.Time) (bool, error) {
+	if len(windows) == 0 {
+		return true, nil
+	}
+
+	now = now.UTC()
+
+	for _, window := range windows {
+		contains, err := syncWindowContains(window, now)
+		if err != nil {
+			return false, err
+		}
+		if contains {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func syncWindowContains(window v1alpha1.AppSyncWindow, now time.Time) (bool, error) {
+	if len(window.Days) > 0 {
+		day := now.Weekday().String()[:3]
+		matched := false
+		for _, d := range window.Days {
+			if strings.EqualFold(d, day) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+
+	start, err := parseSyncWindowTime(window.Start)
+	if err != nil {
+		return false, fmt.Errorf("Parsing sync window start: %s", err)
+	}
+
+	end, err := parseSyncWindowTime(window.End)
+	if err != nil {
+		return false, fmt.Errorf("Parsing sync window end: %s", err)
+	}
+
+	minute := now.Hour()*60 + now.Minute()
+
+	// End before start means the window spans midnight
+	if end < start {
+		return minute >= start || minute < end, nil
+	}
+	return minute >= start && minute < end, nil
+}
+
+// parseSyncWindowTime returns minutes since midnight for a "HH:MM" time
+func parseSyncWindowTime(value string) (int, error) {
+	parsed, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("Expected time '%s' to be in 'HH:MM' format: %s", value, err)
+	}
+	return parsed.Hour()*60 + parsed.Minute(), nil
+}
+
+func (a *CRDApp) markOutsideSyncWindow() error {
+	existingApp, err := a.appClient.KappctrlV1alpha1().Apps(a.appModel.Namespace).Get(context.Background(), a.appModel.Name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("Fetching app: %s", err)
+	}
+
+	if existingApp.Status.FriendlyDescription == outsideSyncWindowDesc {
+		return nil
+	}
+
+	existingApp.Status.FriendlyDescription = outsideSyncWindowDesc
+	_, err = a.appClient.KappctrlV1alpha1().Apps(existingApp.Namespace).UpdateStatus(context.Background(), existingApp, metav1.UpdateOptions{})
+	return err
+}
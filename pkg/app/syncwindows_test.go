@@ -0,0 +1,118 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+)
+
+func Test_WithinSyncWindows(t *testing.T) {
+	// 2024-03-06 is a Wednesday
+	wednesdayNoon := time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC)
+
+	businessHours := []v1alpha1.AppSyncWindow{
+		{Days: []string{"Mon", "Tue", "Wed", "Thu", "Fri"}, Start: "09:00", End: "17:00"},
+	}
+
+	in, err := withinSyncWindows(businessHours, wednesdayNoon)
+	require.NoError(t, err)
+	require.True(t, in, "Expected noon on a weekday to be within business hours")
+
+	in, err = withinSyncWindows(businessHours, wednesdayNoon.Add(10*time.Hour))
+	require.NoError(t, err)
+	require.False(t, in, "Expected late evening to be outside business hours")
+
+	sunday := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	in, err = withinSyncWindows(businessHours, sunday)
+	require.NoError(t, err)
+	require.False(t, in, "Expected Sunday to be outside a weekday window")
+
+	in, err = withinSyncWindows(nil, wednesdayNoon)
+	require.NoError(t, err)
+	require.True(t, in, "Expected no windows to always allow reconciliation")
+
+	// Overnight window spanning midnight
+	overnight := []v1alpha1.AppSyncWindow{{Start: "22:00", End: "02:00"}}
+	in, err = withinSyncWindows(overnight, time.Date(2024, 3, 6, 23, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.True(t, in)
+	in, err = withinSyncWindows(overnight, time.Date(2024, 3, 6, 1, 30, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.True(t, in)
+	in, err = withinSyncWindows(overnight, wednesdayNoon)
+	require.NoError(t, err)
+	require.False(t, in)
+
+	_, err = withinSyncWindows([]v1alpha1.AppSyncWindow{{Start: "nine", End: "17:00"}}, wednesdayNoon)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Expected time 'nine' to be in 'HH:MM' format")
+}
+
+func syncWindowedApp(windows []v1alpha1.AppSyncWindow) v1alpha1.App {
+	return v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			UID:       uuid.NewUUID(),
+			Name:      "windowed-app",
+			Namespace: "pkg-standalone",
+		},
+		Spec: v1alpha1.AppSpec{
+			Fetch: []v1alpha1.AppFetch{
+				v1alpha1.AppFetch{Inline: &v1alpha1.AppFetchInline{Paths: map[string]string{"file.yml": "key: value"}}},
+			},
+			Template: []v1alpha1.AppTemplate{
+				v1alpha1.AppTemplate{Ytt: &v1alpha1.AppTemplateYtt{}},
+			},
+			Cluster: &v1alpha1.AppCluster{
+				KubeconfigSecretRef: &v1alpha1.AppClusterKubeconfigSecretRef{Name: "cluster-kubeconfig"},
+			},
+			Deploy: []v1alpha1.AppDeploy{
+				v1alpha1.AppDeploy{Kapp: &v1alpha1.AppDeployKapp{}},
+			},
+			SyncWindows: windows,
+		},
+	}
+}
+
+func Test_Reconcile_RequeuesOutsideSyncWindow(t *testing.T) {
+	// 2024-03-06 is a Wednesday; the window only covers the morning
+	syncWindowNow = func() time.Time { return time.Date(2024, 3, 6, 20, 0, 0, 0, time.UTC) }
+	defer func() { syncWindowNow = time.Now }()
+
+	app := syncWindowedApp([]v1alpha1.AppSyncWindow{{Days: []string{"Wed"}, Start: "09:00", End: "12:00"}})
+	kappcs := fake.NewSimpleClientset(&app)
+	crdApp := dependentCRDApp(t, kappcs, &app)
+
+	result, err := crdApp.Reconcile(true)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+	require.Equal(t, syncWindowRequeuePeriod, result.RequeueAfter,
+		"Expected reconcile to be requeued while outside the sync window")
+
+	waitingApp, err := kappcs.KappctrlV1alpha1().Apps("pkg-standalone").Get(context.Background(), "windowed-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, outsideSyncWindowDesc, waitingApp.Status.FriendlyDescription)
+	require.Nil(t, waitingApp.Status.Deploy, "Expected no deploy to have run outside the sync window")
+}
+
+func Test_Reconcile_ProceedsWithinSyncWindow(t *testing.T) {
+	syncWindowNow = func() time.Time { return time.Date(2024, 3, 6, 10, 0, 0, 0, time.UTC) }
+	defer func() { syncWindowNow = time.Now }()
+
+	app := syncWindowedApp([]v1alpha1.AppSyncWindow{{Days: []string{"Wed"}, Start: "09:00", End: "12:00"}})
+	kappcs := fake.NewSimpleClientset(&app)
+	crdApp := dependentCRDApp(t, kappcs, &app)
+
+	result, err := crdApp.Reconcile(true)
+	require.Nil(t, err, "unexpected error with reconciling", err)
+	require.NotEqual(t, syncWindowRequeuePeriod, result.RequeueAfter,
+		"Expected reconcile to proceed within the sync window")
+	require.NotNil(t, crdApp.app.Status().Deploy, "Expected deploy to run within the sync window")
+}
@@ -0,0 +1,86 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// templateRenderCache keeps the most recent successful render per app
+// (keyed by app UID) so the next reconcile can report what changed in
+// the templated output. Entries are dropped when an app is deleted.
+var templateRenderCache = struct {
+	sync.Mutex
+	hashes  map[string]string
+	renders map[string]string
+}{hashes: map[string]string{}, renders: map[string]string{}}
+
+// templateOutputDiff returns a unified diff between the previously
+// cached render for key and newRender, caching newRender for the next
+// reconcile. Returns "" on the first render or when nothing changed.
+func templateOutputDiff(key string, newRender string) string {
+	templateRenderCache.Lock()
+	defer templateRenderCache.Unlock()
+
+	prevHash, seen := templateRenderCache.hashes[key]
+	prevRender, renderCached := templateRenderCache.renders[key]
+
+	newHash := templateOutputDigest(newRender)
+	templateRenderCache.hashes[key] = newHash
+	templateRenderCache.renders[key] = newRender
+
+	if !seen || prevHash == newHash {
+		return ""
+	}
+
+	// A hash seeded from status (post restart) has no render to diff against
+	if !renderCached {
+		return ""
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(prevRender),
+		B:        difflib.SplitLines(newRender),
+		FromFile: "previous render",
+		ToFile:   "current render",
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
+
+// templateOutputDigest returns the digest recorded in status for a
+// templated output, matching what the render cache uses as its hash.
+func templateOutputDigest(render string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(render)))
+}
+
+// seedTemplateRenderHash primes the render cache with a digest
+// persisted in status, so a restarted controller recognizes unchanged
+// renders without having seen them in memory.
+func seedTemplateRenderHash(key string, digest string) {
+	templateRenderCache.Lock()
+	defer templateRenderCache.Unlock()
+
+	if digest == "" {
+		return
+	}
+	if _, seen := templateRenderCache.hashes[key]; !seen {
+		templateRenderCache.hashes[key] = digest
+	}
+}
+
+// clearTemplateRenderCache forgets the cached render for key.
+func clearTemplateRenderCache(key string) {
+	templateRenderCache.Lock()
+	defer templateRenderCache.Unlock()
+	delete(templateRenderCache.hashes, key)
+	delete(templateRenderCache.renders, key)
+}
@@ -0,0 +1,45 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateOutputDiffForChangedRenders(t *testing.T) {
+	key := "template-diff-changed"
+	defer clearTemplateRenderCache(key)
+
+	// First render has nothing to diff against
+	require.Empty(t, templateOutputDiff(key, "replicas: 1\nimage: nginx:1.14\n"))
+
+	diff := templateOutputDiff(key, "replicas: 3\nimage: nginx:1.14\n")
+	require.NotEmpty(t, diff)
+	assert.Contains(t, diff, "-replicas: 1")
+	assert.Contains(t, diff, "+replicas: 3")
+	assert.Contains(t, diff, "--- previous render")
+	assert.Contains(t, diff, "+++ current render")
+	assert.NotContains(t, diff, "-image: nginx:1.14")
+}
+
+func TestTemplateOutputDiffUnchangedRenderIsEmpty(t *testing.T) {
+	key := "template-diff-unchanged"
+	defer clearTemplateRenderCache(key)
+
+	require.Empty(t, templateOutputDiff(key, "key: value\n"))
+	require.Empty(t, templateOutputDiff(key, "key: value\n"))
+}
+
+func TestClearTemplateRenderCacheForgetsRender(t *testing.T) {
+	key := "template-diff-cleared"
+	defer clearTemplateRenderCache(key)
+
+	require.Empty(t, templateOutputDiff(key, "key: value\n"))
+	clearTemplateRenderCache(key)
+	// After clearing, the next render is treated as the first again
+	require.Empty(t, templateOutputDiff(key, "key: changed\n"))
+}
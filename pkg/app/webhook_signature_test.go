@@ -0,0 +1,57 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package app
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_WebhookNotifier_SignsPayloadWithHMAC(t *testing.T) {
+	var receivedBody []byte
+	var receivedSignature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		receivedBody, _ = io.ReadAll(r.Body)
+		receivedSignature = r.Header.Get("X-Hub-Signature-256")
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, "test-secret")
+
+	err := notifier.Notify(ReconcileEvent{Name: "simple-app", Namespace: "pkg-standalone", Succeeded: true})
+	require.NoError(t, err)
+
+	expectedPayload := `{"name":"simple-app","namespace":"pkg-standalone","succeeded":true}`
+	assert.Equal(t, expectedPayload, string(receivedBody))
+
+	// Verify the signature the way a receiver would: recompute the
+	// HMAC over the received body with the shared secret
+	mac := hmac.New(sha256.New, []byte("test-secret"))
+	mac.Write(receivedBody)
+	expectedSignature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	assert.Equal(t, expectedSignature, receivedSignature)
+}
+
+func Test_WebhookNotifier_UnsignedWithoutSecret(t *testing.T) {
+	var signatureHeaderPresent bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, signatureHeaderPresent = r.Header["X-Hub-Signature-256"]
+	}))
+	defer server.Close()
+
+	notifier := NewWebhookNotifier(server.URL, "")
+
+	err := notifier.Notify(ReconcileEvent{Name: "simple-app", Namespace: "pkg-standalone", Succeeded: true})
+	require.NoError(t, err)
+
+	assert.False(t, signatureHeaderPresent)
+}
@@ -0,0 +1,82 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"fmt"
+	"sync"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/exec"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+)
+
+// KappBackendName names the default deploy backend.
+const KappBackendName = "kapp"
+
+// DeployBackend builds deployers for apps selecting it by name.
+// Downstream builds can register alternatives to the default kapp
+// backend before the controller starts.
+type DeployBackend interface {
+	// Name selects this backend in an App's deploy configuration.
+	Name() string
+	// NewDeploy returns a deployer built from the given options.
+	NewDeploy(opts BackendOpts) (Deploy, error)
+}
+
+// BackendOpts carries the dependencies and deploy configuration a
+// backend needs to build a deployer.
+type BackendOpts struct {
+	Suffix           string
+	KappDeploy       v1alpha1.AppDeployKapp
+	ClusterAccess    kubeconfig.AccessInfo
+	GlobalRawOptions []string
+	CancelCh         chan struct{}
+	CmdRunner        exec.CmdRunner
+	AppNamespace     string
+}
+
+var (
+	backendsLock sync.Mutex
+	backends     = map[string]DeployBackend{}
+)
+
+// RegisterBackend makes a deploy backend available under its name,
+// replacing any backend previously registered under that name.
+func RegisterBackend(backend DeployBackend) {
+	backendsLock.Lock()
+	defer backendsLock.Unlock()
+	backends[backend.Name()] = backend
+}
+
+// LookupBackend returns the deploy backend registered under the given
+// name; an empty name selects the default kapp backend.
+func LookupBackend(name string) (DeployBackend, error) {
+	if name == "" {
+		name = KappBackendName
+	}
+
+	backendsLock.Lock()
+	defer backendsLock.Unlock()
+
+	backend, found := backends[name]
+	if !found {
+		return nil, fmt.Errorf("Expected deploy backend '%s' to be registered", name)
+	}
+	return backend, nil
+}
+
+// kappBackend is the default deploy backend wrapping kapp.
+type kappBackend struct{}
+
+func (kappBackend) Name() string { return KappBackendName }
+
+func (kappBackend) NewDeploy(opts BackendOpts) (Deploy, error) {
+	return NewKapp(opts.Suffix, opts.KappDeploy, opts.ClusterAccess,
+		opts.GlobalRawOptions, opts.CancelCh, opts.CmdRunner, opts.AppNamespace), nil
+}
+
+func init() {
+	RegisterBackend(kappBackend{})
+}
@@ -0,0 +1,25 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LookupBackend_DefaultsToKapp(t *testing.T) {
+	backend, err := LookupBackend("")
+	require.NoError(t, err)
+	require.Equal(t, KappBackendName, backend.Name())
+
+	backend, err = LookupBackend(KappBackendName)
+	require.NoError(t, err)
+	require.Equal(t, KappBackendName, backend.Name())
+}
+
+func Test_LookupBackend_UnknownNameErrors(t *testing.T) {
+	_, err := LookupBackend("does-not-exist")
+	require.EqualError(t, err, "Expected deploy backend 'does-not-exist' to be registered")
+}
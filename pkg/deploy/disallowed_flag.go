@@ -0,0 +1,21 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"fmt"
+)
+
+// DisallowedFlagError is returned when a raw option names a flag
+// outside the allowed set for the kapp command being built. The allowed
+// set name makes the rejection reason explicit in status and tooling.
+type DisallowedFlagError struct {
+	Flag       string // e.g. --kubeconfig
+	AllowedSet string // e.g. "deploy", "delete", "inspect"
+}
+
+// Error implements error.
+func (e DisallowedFlagError) Error() string {
+	return fmt.Sprintf("Unexpected flag '%s' specified (not in the %s allowed set)", e.Flag, e.AllowedSet)
+}
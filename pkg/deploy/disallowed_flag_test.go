@@ -0,0 +1,43 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"errors"
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DisallowedDeployFlag_ReportsAllowedSet(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		RawOptions: []string{"--kubeconfig=/tmp/config"},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	_, err := kapp.addDeployArgs([]string{"deploy"})
+	require.Error(t, err)
+
+	var flagErr DisallowedFlagError
+	require.True(t, errors.As(err, &flagErr))
+	assert.Equal(t, "--kubeconfig", flagErr.Flag)
+	assert.Equal(t, "deploy", flagErr.AllowedSet)
+	assert.Contains(t, err.Error(), "not in the deploy allowed set")
+}
+
+func Test_DisallowedInspectFlag_ReportsAllowedSet(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		Inspect: &v1alpha1.AppDeployKappInspect{RawOptions: []string{"--logs=true"}},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	_, err := kapp.addInspectArgs([]string{"inspect"})
+	require.Error(t, err)
+
+	var flagErr DisallowedFlagError
+	require.True(t, errors.As(err, &flagErr))
+	assert.Equal(t, "inspect", flagErr.AllowedSet)
+	assert.Contains(t, err.Error(), "not in the inspect allowed set")
+}
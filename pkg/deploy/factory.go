@@ -20,6 +20,7 @@ type Factory struct {
 
 	kubeconfig *kubeconfig.Kubeconfig
 	cmdRunner  exec.CmdRunner
+	log        logr.Logger
 }
 
 // KappConfiguration provides a way to inject shared kapp settings.
@@ -29,9 +30,9 @@ type KappConfiguration interface {
 
 // NewFactory returns deploy factory.
 func NewFactory(coreClient kubernetes.Interface, kubeconfig *kubeconfig.Kubeconfig,
-	kappConfig KappConfiguration, cmdRunner exec.CmdRunner, _ logr.Logger) Factory {
+	kappConfig KappConfiguration, cmdRunner exec.CmdRunner, log logr.Logger) Factory {
 
-	return Factory{coreClient, kappConfig, kubeconfig, cmdRunner}
+	return Factory{coreClient, kappConfig, kubeconfig, cmdRunner, log}
 }
 
 // NewKapp configures and returns a deployer of type Kapp
@@ -45,10 +46,48 @@ func (f Factory) NewKapp(opts v1alpha1.AppDeployKapp, saName string,
 	}
 
 	const suffix string = ".app"
+	f.warnOnTypedFieldOverlaps(opts)
 	return NewKapp(suffix, opts, clusterAccess,
 		f.globalKappDeployRawOpts(), cancelCh, f.cmdRunner, appNamespace), nil
 }
 
+// warnOnTypedFieldOverlaps surfaces raw options that override typed
+// deploy fields, where kapp would silently prefer one of the values.
+func (f Factory) warnOnTypedFieldOverlaps(opts v1alpha1.AppDeployKapp) {
+	for _, flagName := range typedFieldOverlaps(opts) {
+		f.log.Info("Warning: rawOptions override typed deploy option", "flag", flagName)
+	}
+}
+
+// NewDeploy dispatches to the deploy backend registered under the
+// given name; an empty name selects the default kapp backend.
+func (f Factory) NewDeploy(backendName string, opts v1alpha1.AppDeployKapp, saName string,
+	clusterOpts *v1alpha1.AppCluster, cancelCh chan struct{}, location kubeconfig.AccessLocation,
+	defaultNamespace string, appNamespace string) (Deploy, error) {
+
+	backend, err := LookupBackend(backendName)
+	if err != nil {
+		return nil, err
+	}
+
+	f.warnOnTypedFieldOverlaps(opts)
+
+	clusterAccess, err := f.kubeconfig.ClusterAccess(saName, clusterOpts, location, defaultNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return backend.NewDeploy(BackendOpts{
+		Suffix:           ".app",
+		KappDeploy:       opts,
+		ClusterAccess:    clusterAccess,
+		GlobalRawOptions: f.globalKappDeployRawOpts(),
+		CancelCh:         cancelCh,
+		CmdRunner:        f.cmdRunner,
+		AppNamespace:     appNamespace,
+	})
+}
+
 // NewKappPrivileged is used for package repositories where users aren't required to provide
 // a service account, so it will install resources using its own privileges.
 func (f Factory) NewKappPrivilegedForPackageRepository(opts v1alpha1.AppDeployKapp, clusterAccess kubeconfig.AccessInfo, cancelCh chan struct{}, appNamespace string) (*Kapp, error) {
@@ -10,6 +10,8 @@ import (
 	"os"
 	goexec "os/exec"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -213,31 +215,227 @@ func (a *Kapp) addDeployArgs(args []string) ([]string, error) {
 		args = append(args, []string{"--map-ns", val}...)
 	}
 
+	args, err := a.addLabelsArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err = a.addWaitResourceTimeoutArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err = a.addPrevAppArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err = a.addIgnoredFlagsArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	args, err = a.addDiffMaskArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
 	// Global raw options are applied first to be able to override them within an App
-	args, err := a.addRawOpts(args, a.globalDeployRawOpts, kappAllowedDeployFlagSet)
+	args, err = a.addRawOpts(args, a.globalDeployRawOpts, kappAllowedDeployFlagSet, "deploy")
 	if err != nil {
 		return nil, err
 	}
 
-	return a.addRawOpts(args, a.opts.RawOptions, kappAllowedDeployFlagSet)
+	return a.addRawOpts(args, a.opts.RawOptions, kappAllowedDeployFlagSet, "deploy")
+}
+
+// rawOptFlagValues returns the values of every raw option (global
+// options first) matching the given flag name. Raw options that fail
+// expansion or do not parse are skipped; they are validated separately
+// in addRawOpts.
+func (a *Kapp) rawOptFlagValues(name string) []string {
+	var values []string
+	rawOpts := append(append([]string{}, a.globalDeployRawOpts...), a.opts.RawOptions...)
+	for _, opt := range rawOpts {
+		expanded, err := a.expandRawOptPlaceholders(opt)
+		if err != nil {
+			continue
+		}
+		flag, err := exec.NewFlagFromString(expanded)
+		if err != nil {
+			continue
+		}
+		if flag.Name == name {
+			values = append(values, flag.Value)
+		}
+	}
+	return values
+}
+
+// addLabelsArgs serializes spec'd labels into kapp's --labels flag.
+// Label keys duplicated by a --labels raw option are rejected since
+// kapp would silently prefer one of the values.
+func (a *Kapp) addLabelsArgs(args []string) ([]string, error) {
+	if len(a.opts.Labels) == 0 {
+		return args, nil
+	}
+
+	for _, value := range a.rawOptFlagValues("--labels") {
+		for _, kv := range strings.Split(value, ",") {
+			key := strings.SplitN(kv, "=", 2)[0]
+			if _, found := a.opts.Labels[key]; found {
+				return nil, fmt.Errorf("Expected label key '%s' to be specified via labels or rawOptions, but not both", key)
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(a.opts.Labels))
+	for key := range a.opts.Labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+a.opts.Labels[key])
+	}
+
+	return append(args, "--labels="+strings.Join(pairs, ",")), nil
+}
+
+// addWaitResourceTimeoutArgs serializes the spec'd per-resource wait
+// timeout into kapp's --wait-resource-timeout flag. Specifying the flag
+// via rawOptions as well is rejected since kapp would silently prefer
+// one of the values.
+func (a *Kapp) addWaitResourceTimeoutArgs(args []string) ([]string, error) {
+	if a.opts.WaitResourceTimeout == nil {
+		return args, nil
+	}
+
+	if len(a.rawOptFlagValues("--wait-resource-timeout")) > 0 {
+		return nil, fmt.Errorf("Expected wait resource timeout to be specified via waitResourceTimeout or rawOptions, but not both")
+	}
+
+	return append(args, "--wait-resource-timeout="+a.opts.WaitResourceTimeout.Duration.String()), nil
+}
+
+// addPrevAppArgs serializes the spec'd previous app name into kapp's
+// --prev-app flag. Specifying the flag via rawOptions as well is
+// rejected since kapp would silently prefer one of the values.
+func (a *Kapp) addPrevAppArgs(args []string) ([]string, error) {
+	if a.opts.PrevApp == "" {
+		return args, nil
+	}
+	if strings.TrimSpace(a.opts.PrevApp) == "" {
+		return nil, fmt.Errorf("Expected prevApp to be non-empty")
+	}
+
+	if len(a.rawOptFlagValues("--prev-app")) > 0 {
+		return nil, fmt.Errorf("Expected previous app name to be specified via prevApp or rawOptions, but not both")
+	}
+
+	return append(args, "--prev-app="+a.opts.PrevApp), nil
+}
+
+// addIgnoredFlagsArgs serializes the spec'd apply/wait ignored booleans
+// into kapp's --apply-ignored and --wait-ignored flags. Specifying a
+// flag via rawOptions as well is rejected since kapp would silently
+// prefer one of the values.
+func (a *Kapp) addIgnoredFlagsArgs(args []string) ([]string, error) {
+	typedFlags := map[string]bool{
+		"--apply-ignored": a.opts.ApplyIgnored,
+		"--wait-ignored":  a.opts.WaitIgnored,
+	}
+
+	for _, flagName := range []string{"--apply-ignored", "--wait-ignored"} {
+		if typedFlags[flagName] && len(a.rawOptFlagValues(flagName)) > 0 {
+			return nil, fmt.Errorf("Expected '%s' to be specified via typed option or rawOptions, but not both", flagName)
+		}
+	}
+
+	if a.opts.ApplyIgnored {
+		args = append(args, "--apply-ignored=true")
+	}
+	if a.opts.WaitIgnored {
+		args = append(args, "--wait-ignored=true")
+	}
+
+	return args, nil
+}
+
+// addDiffMaskArgs serializes the spec'd diff mask boolean into kapp's
+// --diff-mask flag; when unset kapp's default (masked) applies.
+// Specifying the flag via rawOptions as well is rejected since kapp
+// would silently prefer one of the values.
+func (a *Kapp) addDiffMaskArgs(args []string) ([]string, error) {
+	if a.opts.DiffMask == nil {
+		return args, nil
+	}
+
+	if len(a.rawOptFlagValues("--diff-mask")) > 0 {
+		return nil, fmt.Errorf("Expected '--diff-mask' to be specified via typed option or rawOptions, but not both")
+	}
+
+	return append(args, fmt.Sprintf("--diff-mask=%t", *a.opts.DiffMask)), nil
 }
 
 func (a *Kapp) addDeleteArgs(args []string) ([]string, error) {
 	if a.opts.Delete != nil {
-		return a.addRawOpts(args, a.opts.Delete.RawOptions, kappAllowedDeleteFlagSet)
+		return a.addRawOpts(args, a.opts.Delete.RawOptions, kappAllowedDeleteFlagSet, "delete")
 	}
 	return args, nil
 }
 
 func (a *Kapp) addInspectArgs(args []string) ([]string, error) {
 	if a.opts.Inspect != nil {
-		return a.addRawOpts(args, a.opts.Inspect.RawOptions, kappAllowedInspectFlagSet)
+		return a.addRawOpts(args, a.opts.Inspect.RawOptions, kappAllowedInspectFlagSet, "inspect")
 	}
 	return args, nil
 }
 
-func (a *Kapp) addRawOpts(args []string, opts []string, allowedFlagSet exec.FlagSet) ([]string, error) {
+// rawOptPlaceholderRegexp matches {{key}} placeholders within raw options.
+var rawOptPlaceholderRegexp = regexp.MustCompile(`\{\{\s*([a-zA-Z]+)\s*\}\}`)
+
+// expandRawOptPlaceholders substitutes supported placeholders within a raw
+// option against values known for the app. Supported placeholders are
+// {{name}} (app name) and {{namespace}} (app namespace; {{ns}} is a
+// shorthand). Unknown placeholders are rejected instead of being passed
+// through to kapp as literals. Expansion happens before the
+// flag-restriction check so that disallowed flags are still rejected
+// post-expansion.
+func (a *Kapp) expandRawOptPlaceholders(opt string) (string, error) {
+	values := map[string]string{
+		"name":      a.clusterAccess.Name,
+		"namespace": a.appNamespace,
+		"ns":        a.appNamespace,
+	}
+
+	var unknownKey string
+
+	expanded := rawOptPlaceholderRegexp.ReplaceAllStringFunc(opt, func(match string) string {
+		key := rawOptPlaceholderRegexp.FindStringSubmatch(match)[1]
+		if val, found := values[key]; found {
+			return val
+		}
+		if unknownKey == "" {
+			unknownKey = key
+		}
+		return match
+	})
+
+	if unknownKey != "" {
+		return "", fmt.Errorf("Expected raw option placeholder '{{%s}}' to be one of: name, namespace, ns", unknownKey)
+	}
+
+	return expanded, nil
+}
+
+func (a *Kapp) addRawOpts(args []string, opts []string, allowedFlagSet exec.FlagSet, allowedSetName string) ([]string, error) {
 	for _, opt := range opts {
+		opt, err := a.expandRawOptPlaceholders(opt)
+		if err != nil {
+			return nil, err
+		}
 		flag, err := exec.NewFlagFromString(opt)
 		if err != nil {
 			return nil, err
@@ -245,7 +443,7 @@ func (a *Kapp) addRawOpts(args []string, opts []string, allowedFlagSet exec.Flag
 		if allowedFlagSet.Includes(flag.Name) {
 			args = append(args, opt)
 		} else {
-			return nil, fmt.Errorf("Unexpected flag '%s' specified (either forbidden or unknown)", flag.Name)
+			return nil, DisallowedFlagError{Flag: flag.Name, AllowedSet: allowedSetName}
 		}
 	}
 	return args, nil
@@ -57,6 +57,7 @@ var (
 		"--wait-concurrency",
 		"--wait-ignored",
 		"--wait-timeout",
+		"--wait-resource-timeout",
 		"--exit-early-on-wait-error",
 	}
 )
@@ -79,6 +80,7 @@ var (
 
 		"--labels",
 		"--patch",
+		"--prev-app",
 	})
 
 	kappAllowedInspectFlagSet = exec.NewFlagSet(kappAllowedSharedOpts, []string{
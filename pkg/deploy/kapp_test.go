@@ -0,0 +1,239 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"testing"
+	"time"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/kubeconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_RawOptions_PlaceholderExpansion(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		RawOptions: []string{"--into-ns={{ns}}", "--labels=app={{name}},ns={{namespace}}"},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	args, err := kapp.addDeployArgs([]string{"deploy"})
+	require.NoError(t, err)
+
+	assert.Contains(t, args, "--into-ns=app-ns")
+	assert.Contains(t, args, "--labels=app=simple-app,ns=app-ns")
+}
+
+func Test_RawOptions_UnknownPlaceholderRejected(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		RawOptions: []string{"--labels=tier={{unknown}}"},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	_, err := kapp.addDeployArgs([]string{"deploy"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "placeholder '{{unknown}}' to be one of: name, namespace, ns")
+}
+
+func Test_Labels_SerializedIntoLabelsFlag(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		Labels: map[string]string{"tier": "backend", "app": "simple-app"},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	args, err := kapp.addDeployArgs([]string{"deploy"})
+	require.NoError(t, err)
+
+	assert.Contains(t, args, "--labels=app=simple-app,tier=backend")
+}
+
+func Test_Labels_DuplicateKeyInRawOptionsErrors(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		Labels:     map[string]string{"tier": "backend"},
+		RawOptions: []string{"--labels=tier=frontend,env=dev"},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	_, err := kapp.addDeployArgs([]string{"deploy"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "label key 'tier'")
+}
+
+func Test_Labels_NonConflictingRawOptionLabelsAllowed(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		Labels:     map[string]string{"tier": "backend"},
+		RawOptions: []string{"--labels=env=dev"},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	args, err := kapp.addDeployArgs([]string{"deploy"})
+	require.NoError(t, err)
+
+	assert.Contains(t, args, "--labels=tier=backend")
+	assert.Contains(t, args, "--labels=env=dev")
+}
+
+func Test_RawOptions_DisallowedFlagRejectedPostExpansion(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		RawOptions: []string{"--kubeconfig={{ns}}"},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	_, err := kapp.addDeployArgs([]string{"deploy"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Unexpected flag '--kubeconfig'")
+}
+
+func Test_WaitResourceTimeout_SerializedIntoFlag(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		WaitResourceTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	args, err := kapp.addDeployArgs([]string{"deploy"})
+	require.NoError(t, err)
+
+	assert.Contains(t, args, "--wait-resource-timeout=5m0s")
+}
+
+func Test_WaitResourceTimeout_DuplicateRawOptionErrors(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		WaitResourceTimeout: &metav1.Duration{Duration: 5 * time.Minute},
+		RawOptions:          []string{"--wait-resource-timeout=1m"},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	_, err := kapp.addDeployArgs([]string{"deploy"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "waitResourceTimeout or rawOptions, but not both")
+}
+
+func Test_WaitResourceTimeout_AllowedAsRawOption(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		RawOptions: []string{"--wait-resource-timeout=1m"},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	args, err := kapp.addDeployArgs([]string{"deploy"})
+	require.NoError(t, err)
+
+	assert.Contains(t, args, "--wait-resource-timeout=1m")
+}
+
+func Test_PrevApp_SerializedIntoFlag(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		PrevApp: "old-name.app",
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	args, err := kapp.addDeployArgs([]string{"deploy"})
+	require.NoError(t, err)
+
+	assert.Contains(t, args, "--prev-app=old-name.app")
+}
+
+func Test_PrevApp_BlankNameErrors(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		PrevApp: "  ",
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	_, err := kapp.addDeployArgs([]string{"deploy"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Expected prevApp to be non-empty")
+}
+
+func Test_PrevApp_DuplicateRawOptionErrors(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		PrevApp:    "old-name.app",
+		RawOptions: []string{"--prev-app=other-name.app"},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	_, err := kapp.addDeployArgs([]string{"deploy"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "prevApp or rawOptions, but not both")
+}
+
+func Test_IgnoredFlags_SerializedIntoFlags(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		ApplyIgnored: true,
+		WaitIgnored:  true,
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	args, err := kapp.addDeployArgs([]string{"deploy"})
+	require.NoError(t, err)
+
+	assert.Contains(t, args, "--apply-ignored=true")
+	assert.Contains(t, args, "--wait-ignored=true")
+}
+
+func Test_IgnoredFlags_OmittedWhenUnset(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	args, err := kapp.addDeployArgs([]string{"deploy"})
+	require.NoError(t, err)
+
+	assert.NotContains(t, args, "--apply-ignored=true")
+	assert.NotContains(t, args, "--wait-ignored=true")
+}
+
+func Test_IgnoredFlags_DuplicateRawOptionErrors(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		WaitIgnored: true,
+		RawOptions:  []string{"--wait-ignored=false"},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	_, err := kapp.addDeployArgs([]string{"deploy"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "'--wait-ignored' to be specified via typed option or rawOptions, but not both")
+}
+
+func Test_IgnoredFlags_AllowedAsRawOptions(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		RawOptions: []string{"--apply-ignored=true", "--wait-ignored=true"},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	args, err := kapp.addDeployArgs([]string{"deploy"})
+	require.NoError(t, err)
+
+	assert.Contains(t, args, "--apply-ignored=true")
+	assert.Contains(t, args, "--wait-ignored=true")
+}
+
+func Test_PrevApp_AllowedAsRawOption(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		RawOptions: []string{"--prev-app=old-name.app"},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	args, err := kapp.addDeployArgs([]string{"deploy"})
+	require.NoError(t, err)
+
+	assert.Contains(t, args, "--prev-app=old-name.app")
+}
+
+func Test_DiffMask_SerializedIntoFlag(t *testing.T) {
+	mask := false
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		DiffMask: &mask,
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	args, err := kapp.addDeployArgs([]string{"deploy"})
+	require.NoError(t, err)
+
+	assert.Contains(t, args, "--diff-mask=false")
+}
+
+func Test_DiffMask_OmittedWhenUnset(t *testing.T) {
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	args, err := kapp.addDeployArgs([]string{"deploy"})
+	require.NoError(t, err)
+
+	for _, arg := range args {
+		assert.NotContains(t, arg, "--diff-mask")
+	}
+}
+
+func Test_DiffMask_DuplicateRawOptionErrors(t *testing.T) {
+	mask := true
+	kapp := NewKapp(".app", v1alpha1.AppDeployKapp{
+		DiffMask:   &mask,
+		RawOptions: []string{"--diff-mask=false"},
+	}, kubeconfig.AccessInfo{Name: "simple-app"}, nil, nil, nil, "app-ns")
+
+	_, err := kapp.addDeployArgs([]string{"deploy"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "typed option or rawOptions, but not both")
+}
@@ -0,0 +1,36 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// Example kapp wait progress output:
+//
+//	3:56:18PM: ---- waiting on 2 changes [6/8 done] ----
+var resourceProgressRegexp = regexp.MustCompile(`\[(\d+)/(\d+) done\]`)
+
+// ParseResourceProgress extracts the most recent "[N/M done]" pair from
+// kapp's wait progress output. Returns found=false when output does not
+// contain any recognizable progress markers.
+func ParseResourceProgress(stdout string) (ready int, total int, found bool) {
+	matches := resourceProgressRegexp.FindAllStringSubmatch(stdout, -1)
+	if len(matches) == 0 {
+		return 0, 0, false
+	}
+
+	last := matches[len(matches)-1]
+	ready, err := strconv.Atoi(last[1])
+	if err != nil {
+		return 0, 0, false
+	}
+	total, err = strconv.Atoi(last[2])
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return ready, total, true
+}
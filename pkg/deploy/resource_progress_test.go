@@ -0,0 +1,46 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy_test
+
+import (
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseResourceProgress(t *testing.T) {
+	stdout := `3:56:10PM: ---- applying 8 changes [0/8 done] ----
+3:56:12PM: create deployment/simple-app (apps/v1) namespace: default
+3:56:15PM: ---- waiting on 3 changes [5/8 done] ----
+3:56:16PM: ongoing: reconcile deployment/simple-app (apps/v1) namespace: default`
+
+	ready, total, found := deploy.ParseResourceProgress(stdout)
+
+	assert.True(t, found)
+	assert.Equal(t, 5, ready)
+	assert.Equal(t, 8, total)
+}
+
+func Test_ParseResourceProgress_UsesLatestMarker(t *testing.T) {
+	stdout := `3:56:10PM: ---- waiting on 8 changes [0/8 done] ----
+3:56:15PM: ---- waiting on 2 changes [6/8 done] ----
+3:56:18PM: ---- waiting on 1 changes [7/8 done] ----`
+
+	ready, total, found := deploy.ParseResourceProgress(stdout)
+
+	assert.True(t, found)
+	assert.Equal(t, 7, ready)
+	assert.Equal(t, 8, total)
+}
+
+func Test_ParseResourceProgress_NoMarkers(t *testing.T) {
+	stdout := `Target cluster 'https://127.0.0.1:6443'
+Changes
+Op:      0 create, 0 delete, 0 update, 0 noop`
+
+	_, _, found := deploy.ParseResourceProgress(stdout)
+
+	assert.False(t, found)
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/exec"
+)
+
+// typedFieldOverlaps returns kapp flags specified both via a typed
+// deploy field and rawOptions. Unlike flags rejected outright during
+// arg construction, these overlaps are ambiguous (kapp silently
+// prefers one of the values) and are surfaced as warnings.
+func typedFieldOverlaps(opts v1alpha1.AppDeployKapp) []string {
+	typedFlags := map[string]bool{}
+	if opts.IntoNs != "" {
+		typedFlags["--into-ns"] = true
+	}
+	if len(opts.MapNs) > 0 {
+		typedFlags["--map-ns"] = true
+	}
+
+	var overlaps []string
+	for _, opt := range opts.RawOptions {
+		flag, err := exec.NewFlagFromString(opt)
+		if err != nil {
+			// Raw options are validated separately during arg construction
+			continue
+		}
+		if typedFlags[flag.Name] {
+			typedFlags[flag.Name] = false
+			overlaps = append(overlaps, flag.Name)
+		}
+	}
+
+	return overlaps
+}
@@ -0,0 +1,38 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TypedFieldOverlaps_Detected(t *testing.T) {
+	overlaps := typedFieldOverlaps(v1alpha1.AppDeployKapp{
+		IntoNs:     "ns1",
+		MapNs:      []string{"a=b"},
+		RawOptions: []string{"--into-ns=ns2", "--map-ns=c=d", "--wait-timeout=5m"},
+	})
+	assert.Equal(t, []string{"--into-ns", "--map-ns"}, overlaps)
+}
+
+func Test_TypedFieldOverlaps_NoneWithoutConflict(t *testing.T) {
+	assert.Empty(t, typedFieldOverlaps(v1alpha1.AppDeployKapp{
+		IntoNs:     "ns1",
+		RawOptions: []string{"--wait-timeout=5m"},
+	}))
+	assert.Empty(t, typedFieldOverlaps(v1alpha1.AppDeployKapp{
+		RawOptions: []string{"--into-ns=ns2"},
+	}))
+	assert.Empty(t, typedFieldOverlaps(v1alpha1.AppDeployKapp{IntoNs: "ns1"}))
+}
+
+func Test_TypedFieldOverlaps_MalformedRawOptionIgnored(t *testing.T) {
+	assert.Empty(t, typedFieldOverlaps(v1alpha1.AppDeployKapp{
+		IntoNs:     "ns1",
+		RawOptions: []string{"not-a-flag"},
+	}))
+}
@@ -0,0 +1,37 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy
+
+import (
+	"regexp"
+	"strings"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+)
+
+// Example kapp wait failure output:
+//
+//	kapp: Error: waiting on reconcile deployment/simple-app (apps/v1) namespace: default:
+//	  Finished unsuccessfully (Deployment is not progressing: ProgressDeadlineExceeded)
+var waitFailureRegexp = regexp.MustCompile(
+	`waiting on reconcile (?P<resource>\S+ \([^)]*\)(?: namespace: \S+)?):\s*` +
+		`(?:\n\s*)?(?:Finished unsuccessfully |Errored:? ?)?\(?(?P<reason>[^)\n]+)\)?`)
+
+// ParseWaitFailures extracts structured (resource, reason) entries from
+// kapp's wait failure stderr. Returns nil when output does not contain
+// any recognizable wait failures.
+func ParseWaitFailures(stderr string) []v1alpha1.AppStatusDeployWaitFailure {
+	var failures []v1alpha1.AppStatusDeployWaitFailure
+
+	for _, match := range waitFailureRegexp.FindAllStringSubmatch(stderr, -1) {
+		resource := strings.TrimSuffix(strings.TrimSpace(match[1]), ":")
+		reason := strings.TrimSpace(match[2])
+		failures = append(failures, v1alpha1.AppStatusDeployWaitFailure{
+			Resource: resource,
+			Reason:   reason,
+		})
+	}
+
+	return failures
+}
@@ -0,0 +1,43 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package deploy_test
+
+import (
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/deploy"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseWaitFailures(t *testing.T) {
+	stderr := `kapp: Error: waiting on reconcile deployment/simple-app (apps/v1) namespace: default:
+  Finished unsuccessfully (Deployment is not progressing: ProgressDeadlineExceeded`
+
+	failures := deploy.ParseWaitFailures(stderr)
+
+	assert.Equal(t, []v1alpha1.AppStatusDeployWaitFailure{{
+		Resource: "deployment/simple-app (apps/v1) namespace: default",
+		Reason:   "Deployment is not progressing: ProgressDeadlineExceeded",
+	}}, failures)
+}
+
+func Test_ParseWaitFailures_MultipleResources(t *testing.T) {
+	stderr := `kapp: Error: waiting on reconcile deployment/simple-app (apps/v1) namespace: default:
+  Finished unsuccessfully (Deployment is not progressing)
+kapp: Error: waiting on reconcile pod/simple-pod (v1) namespace: default:
+  Errored: Pod is in CrashLoopBackOff`
+
+	failures := deploy.ParseWaitFailures(stderr)
+
+	assert.Len(t, failures, 2)
+	assert.Equal(t, "deployment/simple-app (apps/v1) namespace: default", failures[0].Resource)
+	assert.Equal(t, "Deployment is not progressing", failures[0].Reason)
+	assert.Equal(t, "pod/simple-pod (v1) namespace: default", failures[1].Resource)
+	assert.Equal(t, "Pod is in CrashLoopBackOff", failures[1].Reason)
+}
+
+func Test_ParseWaitFailures_NoFailures(t *testing.T) {
+	assert.Nil(t, deploy.ParseWaitFailures("kapp: Error: Applying create configmap/cm: connection refused"))
+}
@@ -0,0 +1,121 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetch
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/k14s/semver/v4"
+	kyaml "sigs.k8s.io/yaml"
+)
+
+// HelmIndex is a parsed Helm repository index (index.yaml).
+type HelmIndex struct {
+	Entries map[string][]HelmIndexEntry `json:"entries"`
+}
+
+// HelmIndexEntry is a single chart version advertised by a Helm repository.
+type HelmIndexEntry struct {
+	Version string   `json:"version"`
+	Digest  string   `json:"digest"`
+	URLs    []string `json:"urls"`
+}
+
+// NewHelmIndexFromBytes parses YAML-encoded Helm repository index contents.
+func NewHelmIndexFromBytes(bs []byte) (HelmIndex, error) {
+	var index HelmIndex
+	err := kyaml.Unmarshal(bs, &index)
+	if err != nil {
+		return HelmIndex{}, fmt.Errorf("Parsing Helm repository index: %s", err)
+	}
+	return index, nil
+}
+
+// FetchHelmIndex downloads and parses index.yaml from given Helm repository URL.
+func FetchHelmIndex(repoURL string, proxy ProxyConfig) (HelmIndex, error) {
+	indexURL := strings.TrimSuffix(repoURL, "/") + "/index.yaml"
+
+	resp, err := proxy.Client().Get(indexURL)
+	if err != nil {
+		return HelmIndex{}, fmt.Errorf("Fetching Helm repository index: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return HelmIndex{}, fmt.Errorf("Fetching Helm repository index: unexpected status code %d", resp.StatusCode)
+	}
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return HelmIndex{}, fmt.Errorf("Reading Helm repository index: %s", err)
+	}
+
+	return NewHelmIndexFromBytes(bs)
+}
+
+// ResolveChartVersion returns the highest chart version matching given
+// semver constraint (e.g. ">=1.0.0 <2.0.0"). Empty constraint matches
+// all versions. Returned entry carries the digest the chart is pinned to.
+func (i HelmIndex) ResolveChartVersion(chartName, constraint string) (HelmIndexEntry, error) {
+	entries, found := i.Entries[chartName]
+	if !found {
+		return HelmIndexEntry{}, fmt.Errorf("Expected to find chart '%s' in Helm repository index", chartName)
+	}
+
+	matches := func(semver.Version) bool { return true }
+	if constraint != "" {
+		rangeFunc, err := semver.ParseRange(constraint)
+		if err != nil {
+			return HelmIndexEntry{}, fmt.Errorf("Parsing version constraint '%s': %s", constraint, err)
+		}
+		matches = rangeFunc
+	}
+
+	var highest *HelmIndexEntry
+	var highestVersion semver.Version
+
+	for i, entry := range entries {
+		version, err := semver.Parse(entry.Version)
+		if err != nil {
+			continue // skip non-semver versions
+		}
+		if !matches(version) {
+			continue
+		}
+		if highest == nil || version.GT(highestVersion) {
+			highest = &entries[i]
+			highestVersion = version
+		}
+	}
+
+	if highest == nil {
+		return HelmIndexEntry{}, fmt.Errorf("Expected to find chart '%s' version matching constraint '%s'", chartName, constraint)
+	}
+
+	return *highest, nil
+}
+
+// ResolveChartURL resolves a chart archive URL advertised by a Helm
+// repository index against the repository URL. Indexes commonly list
+// URLs relative to the repository root; absolute URLs pass through.
+func ResolveChartURL(repoURL, chartURL string) (string, error) {
+	ref, err := url.Parse(chartURL)
+	if err != nil {
+		return "", fmt.Errorf("Parsing chart URL '%s': %s", chartURL, err)
+	}
+	if ref.IsAbs() {
+		return chartURL, nil
+	}
+
+	base, err := url.Parse(strings.TrimSuffix(repoURL, "/") + "/")
+	if err != nil {
+		return "", fmt.Errorf("Parsing Helm repository URL '%s': %s", repoURL, err)
+	}
+
+	return base.ResolveReference(ref).String(), nil
+}
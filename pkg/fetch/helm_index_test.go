@@ -0,0 +1,83 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetch_test
+
+import (
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/fetch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fakeHelmIndex = `
+apiVersion: v1
+entries:
+  simple-chart:
+  - version: 1.0.0
+    digest: sha256-aaa
+    urls:
+    - https://charts.example.com/simple-chart-1.0.0.tgz
+  - version: 1.2.0
+    digest: sha256-bbb
+    urls:
+    - https://charts.example.com/simple-chart-1.2.0.tgz
+  - version: 2.0.0
+    digest: sha256-ccc
+    urls:
+    - https://charts.example.com/simple-chart-2.0.0.tgz
+  - version: not-a-semver
+    digest: sha256-ddd
+`
+
+func Test_HelmIndex_ResolveChartVersion(t *testing.T) {
+	index, err := fetch.NewHelmIndexFromBytes([]byte(fakeHelmIndex))
+	require.NoError(t, err)
+
+	t.Run("resolves highest version matching constraint", func(t *testing.T) {
+		entry, err := index.ResolveChartVersion("simple-chart", ">=1.0.0 <2.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.0", entry.Version)
+		assert.Equal(t, "sha256-bbb", entry.Digest)
+	})
+
+	t.Run("resolves highest version when constraint is empty", func(t *testing.T) {
+		entry, err := index.ResolveChartVersion("simple-chart", "")
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0", entry.Version)
+		assert.Equal(t, "sha256-ccc", entry.Digest)
+	})
+
+	t.Run("errors when no version matches constraint", func(t *testing.T) {
+		_, err := index.ResolveChartVersion("simple-chart", ">=3.0.0")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "matching constraint")
+	})
+
+	t.Run("errors when chart is not in index", func(t *testing.T) {
+		_, err := index.ResolveChartVersion("missing-chart", "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Expected to find chart")
+	})
+}
+
+func Test_HelmIndex_ResolveChartURL(t *testing.T) {
+	t.Run("passes through absolute URLs", func(t *testing.T) {
+		url, err := fetch.ResolveChartURL("https://charts.example.com", "https://mirror.example.net/simple-chart-1.2.0.tgz")
+		require.NoError(t, err)
+		assert.Equal(t, "https://mirror.example.net/simple-chart-1.2.0.tgz", url)
+	})
+
+	t.Run("resolves relative URLs against the repository URL", func(t *testing.T) {
+		url, err := fetch.ResolveChartURL("https://charts.example.com/stable", "simple-chart-1.2.0.tgz")
+		require.NoError(t, err)
+		assert.Equal(t, "https://charts.example.com/stable/simple-chart-1.2.0.tgz", url)
+	})
+
+	t.Run("resolves relative URLs when repository URL has trailing slash", func(t *testing.T) {
+		url, err := fetch.ResolveChartURL("https://charts.example.com/stable/", "charts/simple-chart-1.2.0.tgz")
+		require.NoError(t, err)
+		assert.Equal(t, "https://charts.example.com/stable/charts/simple-chart-1.2.0.tgz", url)
+	})
+}
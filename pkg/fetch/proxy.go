@@ -0,0 +1,96 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetch
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+)
+
+// ProxyConfig selects which proxy URL (if any) in-process HTTP
+// fetches go through. Standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY
+// environment is honored; per-App spec values override it.
+type ProxyConfig struct {
+	HTTPProxy  string
+	HTTPSProxy string
+	NoProxy    string
+}
+
+// NewProxyConfig builds proxy configuration from the environment,
+// applying any per-App overrides from given spec proxy config.
+func NewProxyConfig(proxy *v1alpha1.AppProxy) ProxyConfig {
+	cfg := ProxyConfig{
+		HTTPProxy:  envFirst("HTTP_PROXY", "http_proxy"),
+		HTTPSProxy: envFirst("HTTPS_PROXY", "https_proxy"),
+		NoProxy:    envFirst("NO_PROXY", "no_proxy"),
+	}
+
+	if proxy != nil {
+		if proxy.HTTPProxy != "" {
+			cfg.HTTPProxy = proxy.HTTPProxy
+		}
+		if proxy.HTTPSProxy != "" {
+			cfg.HTTPSProxy = proxy.HTTPSProxy
+		}
+		if proxy.NoProxy != "" {
+			cfg.NoProxy = proxy.NoProxy
+		}
+	}
+
+	return cfg
+}
+
+// Client returns an HTTP client routing requests through the
+// configured proxy (if any).
+func (c ProxyConfig) Client() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return c.ProxyForURL(req.URL)
+	}
+	return &http.Client{Transport: transport}
+}
+
+// ProxyForURL returns the proxy URL to use for given request URL,
+// or nil when the request goes direct (no proxy configured or the
+// host is excluded via NO_PROXY).
+func (c ProxyConfig) ProxyForURL(u *url.URL) (*url.URL, error) {
+	rawProxy := c.HTTPProxy
+	if u.Scheme == "https" {
+		rawProxy = c.HTTPSProxy
+	}
+	if rawProxy == "" || c.hostExcluded(u.Hostname()) {
+		return nil, nil
+	}
+	return url.Parse(rawProxy)
+}
+
+func (c ProxyConfig) hostExcluded(host string) bool {
+	for _, entry := range strings.Split(c.NoProxy, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if entry == "*" {
+			return true
+		}
+		entry = strings.TrimPrefix(entry, ".")
+		if host == entry || strings.HasSuffix(host, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
+
+func envFirst(keys ...string) string {
+	for _, key := range keys {
+		if val := os.Getenv(key); val != "" {
+			return val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,101 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package fetch
+
+import (
+	"net/url"
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ProxyForURL_SchemeSelection(t *testing.T) {
+	cfg := ProxyConfig{
+		HTTPProxy:  "http://http-proxy.corp:3128",
+		HTTPSProxy: "http://https-proxy.corp:3128",
+	}
+
+	httpURL, _ := url.Parse("http://charts.example.com/index.yaml")
+	proxyURL, err := cfg.ProxyForURL(httpURL)
+	require.NoError(t, err)
+	require.NotNil(t, proxyURL)
+	assert.Equal(t, "http://http-proxy.corp:3128", proxyURL.String())
+
+	httpsURL, _ := url.Parse("https://charts.example.com/index.yaml")
+	proxyURL, err = cfg.ProxyForURL(httpsURL)
+	require.NoError(t, err)
+	require.NotNil(t, proxyURL)
+	assert.Equal(t, "http://https-proxy.corp:3128", proxyURL.String())
+}
+
+func Test_ProxyForURL_NoProxyConfigured(t *testing.T) {
+	cfg := ProxyConfig{}
+
+	u, _ := url.Parse("https://charts.example.com/index.yaml")
+	proxyURL, err := cfg.ProxyForURL(u)
+	require.NoError(t, err)
+	assert.Nil(t, proxyURL)
+}
+
+func Test_ProxyForURL_NoProxyExclusions(t *testing.T) {
+	cfg := ProxyConfig{
+		HTTPSProxy: "http://proxy.corp:3128",
+		NoProxy:    "internal.example.com, .cluster.local, *",
+	}
+
+	exactMatch, _ := url.Parse("https://internal.example.com/index.yaml")
+	proxyURL, err := cfg.ProxyForURL(exactMatch)
+	require.NoError(t, err)
+	assert.Nil(t, proxyURL)
+
+	domainSuffix, _ := url.Parse("https://charts.svc.cluster.local/index.yaml")
+	proxyURL, err = cfg.ProxyForURL(domainSuffix)
+	require.NoError(t, err)
+	assert.Nil(t, proxyURL)
+
+	// wildcard entry excludes everything
+	other, _ := url.Parse("https://charts.example.com/index.yaml")
+	proxyURL, err = cfg.ProxyForURL(other)
+	require.NoError(t, err)
+	assert.Nil(t, proxyURL)
+}
+
+func Test_ProxyForURL_NoProxySuffixDoesNotMatchPartialLabels(t *testing.T) {
+	cfg := ProxyConfig{
+		HTTPSProxy: "http://proxy.corp:3128",
+		NoProxy:    "example.com",
+	}
+
+	// "notexample.com" is not within the "example.com" domain
+	u, _ := url.Parse("https://notexample.com/index.yaml")
+	proxyURL, err := cfg.ProxyForURL(u)
+	require.NoError(t, err)
+	require.NotNil(t, proxyURL)
+	assert.Equal(t, "http://proxy.corp:3128", proxyURL.String())
+
+	sub, _ := url.Parse("https://charts.example.com/index.yaml")
+	proxyURL, err = cfg.ProxyForURL(sub)
+	require.NoError(t, err)
+	assert.Nil(t, proxyURL)
+}
+
+func Test_NewProxyConfig_AppOverridesEnv(t *testing.T) {
+	t.Setenv("HTTP_PROXY", "http://env-proxy.corp:3128")
+	t.Setenv("HTTPS_PROXY", "http://env-proxy.corp:3128")
+	t.Setenv("NO_PROXY", "env.example.com")
+
+	cfg := NewProxyConfig(nil)
+	assert.Equal(t, "http://env-proxy.corp:3128", cfg.HTTPProxy)
+	assert.Equal(t, "http://env-proxy.corp:3128", cfg.HTTPSProxy)
+	assert.Equal(t, "env.example.com", cfg.NoProxy)
+
+	cfg = NewProxyConfig(&v1alpha1.AppProxy{
+		HTTPSProxy: "http://app-proxy.corp:3128",
+	})
+	assert.Equal(t, "http://env-proxy.corp:3128", cfg.HTTPProxy)
+	assert.Equal(t, "http://app-proxy.corp:3128", cfg.HTTPSProxy)
+	assert.Equal(t, "env.example.com", cfg.NoProxy)
+}
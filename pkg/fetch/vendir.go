@@ -17,6 +17,7 @@ import (
 	"carvel.dev/kapp-controller/pkg/exec"
 	// we run vendir by shelling out to it, but we create the vendir configs with help from a vendored copy of vendir.
 	vendirconf "carvel.dev/vendir/pkg/vendir/config"
+	"github.com/k14s/semver/v4"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	kyaml "sigs.k8s.io/yaml"
@@ -39,6 +40,7 @@ type Vendir struct {
 	config     vendirconf.Config
 	opts       VendirOpts
 	cmdRunner  exec.CmdRunner
+	proxy      ProxyConfig
 }
 
 // VendirOpts allows to customize vendir configuration given to vendir.
@@ -67,9 +69,16 @@ func NewVendir(nsName string, coreClient kubernetes.Interface,
 			Kind:       "Config",                  // TODO: use constant from vendir package
 		},
 		cmdRunner: cmdRunner,
+		proxy:     NewProxyConfig(nil),
 	}
 }
 
+// SetAppProxy applies per-App proxy overrides to the proxy configuration
+// used for in-process HTTP requests (e.g. Helm repository index fetches).
+func (v *Vendir) SetAppProxy(proxy *v1alpha1.AppProxy) {
+	v.proxy = NewProxyConfig(proxy)
+}
+
 // AddDir adds a directory to vendir's config for each fetcher that the app spec declares.
 // vendir fetches resources into your filesystem, so the destination directory is a core part of vendir config.
 func (v *Vendir) AddDir(fetch v1alpha1.AppFetch, dirPath string) error {
@@ -87,7 +96,11 @@ func (v *Vendir) AddDir(fetch v1alpha1.AppFetch, dirPath string) error {
 	case fetch.Git != nil:
 		v.config.Directories = append(v.config.Directories, v.dir(v.gitConf(*fetch.Git), dirPath))
 	case fetch.HelmChart != nil:
-		v.config.Directories = append(v.config.Directories, v.dir(v.helmChartConf(*fetch.HelmChart), dirPath))
+		conf, err := v.helmChartConf(*fetch.HelmChart)
+		if err != nil {
+			return err
+		}
+		v.config.Directories = append(v.config.Directories, v.dir(conf, dirPath))
 	case fetch.ImgpkgBundle != nil:
 		v.config.Directories = append(v.config.Directories, v.dir(v.imgpkgBundleConf(*fetch.ImgpkgBundle), dirPath))
 	default:
@@ -175,15 +188,64 @@ func (v *Vendir) gitConf(git v1alpha1.AppFetchGit) vendirconf.DirectoryContents
 	}
 }
 
-func (v *Vendir) helmChartConf(chart v1alpha1.AppFetchHelmChart) vendirconf.DirectoryContents {
+func (v *Vendir) helmChartConf(chart v1alpha1.AppFetchHelmChart) (vendirconf.DirectoryContents, error) {
+	version := chart.Version
+
+	// Versions that are not plain semver are treated as constraints
+	// and get resolved (and pinned) against the repository index.
+	if isHelmVersionConstraint(version) && chart.Repository != nil {
+		index, err := FetchHelmIndex(chart.Repository.URL, v.proxy)
+		if err != nil {
+			return vendirconf.DirectoryContents{}, err
+		}
+
+		entry, err := index.ResolveChartVersion(chart.Name, version)
+		if err != nil {
+			return vendirconf.DirectoryContents{}, err
+		}
+
+		// When the index advertises the chart archive URL and digest,
+		// fetch the archive directly with checksum verification so the
+		// resolved version is pinned to its contents, not just its
+		// version string (a republished chart would fail the checksum)
+		if entry.Digest != "" && len(entry.URLs) > 0 {
+			chartURL, err := ResolveChartURL(chart.Repository.URL, entry.URLs[0])
+			if err != nil {
+				return vendirconf.DirectoryContents{}, err
+			}
+
+			return vendirconf.DirectoryContents{
+				Path: vendirEntireDirPath,
+				HTTP: &vendirconf.DirectoryContentsHTTP{
+					URL:       chartURL,
+					SHA256:    entry.Digest,
+					SecretRef: v.localRefConf(chart.Repository.SecretRef),
+				},
+			}, nil
+		}
+
+		version = entry.Version
+	}
+
 	return vendirconf.DirectoryContents{
 		Path: vendirEntireDirPath,
 		HelmChart: &vendirconf.DirectoryContentsHelmChart{
 			Name:       chart.Name,
-			Version:    chart.Version,
+			Version:    version,
 			Repository: v.helmRepoConf(chart.Repository),
 		},
+	}, nil
+}
+
+func isHelmVersionConstraint(version string) bool {
+	if version == "" {
+		return false
+	}
+	if _, err := semver.Parse(version); err == nil {
+		return false
 	}
+	_, err := semver.ParseRange(version)
+	return err == nil
 }
 
 func (v *Vendir) inlineSourceConf(src v1alpha1.AppFetchInlineSource) vendirconf.DirectoryContentsInlineSource {
@@ -4,6 +4,9 @@
 package fetch_test
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
@@ -11,6 +14,7 @@ import (
 	"carvel.dev/kapp-controller/pkg/exec"
 	"carvel.dev/kapp-controller/pkg/fetch"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8sfake "k8s.io/client-go/kubernetes/fake"
@@ -149,3 +153,47 @@ func TestExtractHost(t *testing.T) {
 		})
 	}
 }
+
+func Test_AddDir_helmChartConstraintPinsResolvedDigest(t *testing.T) {
+	indexYaml := `
+apiVersion: v1
+entries:
+  simple-chart:
+  - version: 1.0.0
+    digest: aaa111
+    urls:
+    - simple-chart-1.0.0.tgz
+  - version: 1.2.0
+    digest: bbb222
+    urls:
+    - simple-chart-1.2.0.tgz
+`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/index.yaml", r.URL.Path)
+		fmt.Fprint(w, indexYaml)
+	}))
+	defer server.Close()
+
+	vendir := fetch.NewVendir("default", k8sfake.NewSimpleClientset(),
+		fetch.VendirOpts{}, exec.NewPlainCmdRunner())
+
+	err := vendir.AddDir(v1alpha1.AppFetch{
+		HelmChart: &v1alpha1.AppFetchHelmChart{
+			Name:       "simple-chart",
+			Version:    ">=1.0.0 <2.0.0",
+			Repository: &v1alpha1.AppFetchHelmChartRepo{URL: server.URL},
+		},
+	}, "dirpath/0")
+	require.NoError(t, err)
+
+	vConf := vendir.Config()
+	require.Len(t, vConf.Directories, 1)
+
+	// Resolved constraint fetches the chart archive directly so contents
+	// are verified against the digest advertised by the index
+	contents := vConf.Directories[0].Contents[0]
+	require.NotNil(t, contents.HTTP)
+	assert.Equal(t, server.URL+"/simple-chart-1.2.0.tgz", contents.HTTP.URL)
+	assert.Equal(t, "bbb222", contents.HTTP.SHA256)
+	assert.Nil(t, contents.HelmChart)
+}
@@ -7,14 +7,16 @@ package metrics
 type Metrics struct {
 	*ReconcileCountMetrics
 	*ReconcileTimeMetrics
+	*ReconcileInFlightMetrics
 	IsFirstReconcile bool
 }
 
 // NewMetrics is a factory function that returns a new instance of Metrics.
 func NewMetrics() *Metrics {
 	return &Metrics{
-		ReconcileCountMetrics: NewCountMetrics(),
-		ReconcileTimeMetrics:  NewReconcileTimeMetrics(),
-		IsFirstReconcile:      false,
+		ReconcileCountMetrics:    NewCountMetrics(),
+		ReconcileTimeMetrics:     NewReconcileTimeMetrics(),
+		ReconcileInFlightMetrics: NewReconcileInFlightMetrics(),
+		IsFirstReconcile:         false,
 	}
 }
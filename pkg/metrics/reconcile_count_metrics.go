@@ -18,6 +18,8 @@ type ReconcileCountMetrics struct {
 	reconcileFailureTotal       *prometheus.CounterVec
 	reconcileDeleteAttemptTotal *prometheus.CounterVec
 	reconcileDeleteFailedTotal  *prometheus.CounterVec
+	reconcileSourceSuccessTotal *prometheus.CounterVec
+	reconcileSourceFailureTotal *prometheus.CounterVec
 }
 
 var (
@@ -31,6 +33,7 @@ func NewCountMetrics() *ReconcileCountMetrics {
 		kappNameLabel      = "name"
 		kappNamespaceLabel = "namespace"
 		resourceTypeLabel  = "controller"
+		fetchSourceLabel   = "fetch_source"
 	)
 	return &ReconcileCountMetrics{
 		reconcileAttemptTotal: prometheus.NewCounterVec(
@@ -73,6 +76,22 @@ func NewCountMetrics() *ReconcileCountMetrics {
 			},
 			[]string{resourceTypeLabel, kappNameLabel, kappNamespaceLabel},
 		),
+		reconcileSourceSuccessTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricNamespace,
+				Name:      "app_reconcile_success_by_fetch_source_total",
+				Help:      "Total number of succeeded reconciles by fetch source type",
+			},
+			[]string{resourceTypeLabel, fetchSourceLabel},
+		),
+		reconcileSourceFailureTotal: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: metricNamespace,
+				Name:      "app_reconcile_failure_by_fetch_source_total",
+				Help:      "Total number of failed reconciles by fetch source type",
+			},
+			[]string{resourceTypeLabel, fetchSourceLabel},
+		),
 	}
 }
 
@@ -85,6 +104,8 @@ func (am *ReconcileCountMetrics) RegisterAllMetrics() {
 			am.reconcileFailureTotal,
 			am.reconcileDeleteAttemptTotal,
 			am.reconcileDeleteFailedTotal,
+			am.reconcileSourceSuccessTotal,
+			am.reconcileSourceFailureTotal,
 		)
 	})
 }
@@ -134,6 +155,34 @@ func (am *ReconcileCountMetrics) RegisterReconcileDeleteFailed(resourceType, app
 	am.reconcileDeleteFailedTotal.WithLabelValues(resourceType, appName, namespace).Inc()
 }
 
+// RegisterReconcileSuccessForSource increments the per-fetch-source success counter
+func (am *ReconcileCountMetrics) RegisterReconcileSuccessForSource(resourceType, sourceType string) {
+	am.reconcileSourceSuccessTotal.WithLabelValues(resourceType, sourceType).Inc()
+}
+
+// RegisterReconcileFailureForSource increments the per-fetch-source failure counter
+func (am *ReconcileCountMetrics) RegisterReconcileFailureForSource(resourceType, sourceType string) {
+	am.reconcileSourceFailureTotal.WithLabelValues(resourceType, sourceType).Inc()
+}
+
+// GetReconcileSuccessForSourceValue returns the per-fetch-source success count
+func (am *ReconcileCountMetrics) GetReconcileSuccessForSourceValue(resourceType, sourceType string) int64 {
+	var m = &dto.Metric{}
+	if err := am.reconcileSourceSuccessTotal.WithLabelValues(resourceType, sourceType).Write(m); err != nil {
+		return 0
+	}
+	return int64(m.Counter.GetValue())
+}
+
+// GetReconcileFailureForSourceValue returns the per-fetch-source failure count
+func (am *ReconcileCountMetrics) GetReconcileFailureForSourceValue(resourceType, sourceType string) int64 {
+	var m = &dto.Metric{}
+	if err := am.reconcileSourceFailureTotal.WithLabelValues(resourceType, sourceType).Write(m); err != nil {
+		return 0
+	}
+	return int64(m.Counter.GetValue())
+}
+
 // GetReconcileAttemptCounterValue return reconcile count
 func (am *ReconcileCountMetrics) GetReconcileAttemptCounterValue(resourceType, appName, namespace string) int64 {
 	var m = &dto.Metric{}
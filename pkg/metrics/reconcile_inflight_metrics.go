@@ -0,0 +1,62 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// ReconcileInFlightMetrics holds the gauge of currently running reconciles
+type ReconcileInFlightMetrics struct {
+	reconcileInFlight prometheus.Gauge
+}
+
+var (
+	inFlightMetricsOnce sync.Once
+)
+
+// NewReconcileInFlightMetrics creates ReconcileInFlightMetrics object
+func NewReconcileInFlightMetrics() *ReconcileInFlightMetrics {
+	return &ReconcileInFlightMetrics{
+		reconcileInFlight: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: "kappctrl",
+				Name:      "app_reconcile_in_flight",
+				Help:      "Number of reconciles currently in progress",
+			},
+		),
+	}
+}
+
+// RegisterAllMetrics registers all prometheus metrics.
+func (im *ReconcileInFlightMetrics) RegisterAllMetrics() {
+	inFlightMetricsOnce.Do(func() {
+		metrics.Registry.MustRegister(
+			im.reconcileInFlight,
+		)
+	})
+}
+
+// RegisterInFlightStart increments reconcileInFlight
+func (im *ReconcileInFlightMetrics) RegisterInFlightStart() {
+	im.reconcileInFlight.Inc()
+}
+
+// RegisterInFlightEnd decrements reconcileInFlight
+func (im *ReconcileInFlightMetrics) RegisterInFlightEnd() {
+	im.reconcileInFlight.Dec()
+}
+
+// GetInFlightValue returns the current gauge value
+func (im *ReconcileInFlightMetrics) GetInFlightValue() int64 {
+	var m = &dto.Metric{}
+	if err := im.reconcileInFlight.Write(m); err != nil {
+		return 0
+	}
+	return int64(m.Gauge.GetValue())
+}
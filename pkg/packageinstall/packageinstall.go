@@ -42,6 +42,13 @@ const (
 	// PackageInstall to indicate that lower version of the package
 	// can be selected vs whats currently installed.
 	DowngradableAnnKey = "packaging.carvel.dev/downgradable"
+
+	// ResolvedVersionAnnKey records on the App the package version
+	// that most recently reconciled successfully.
+	ResolvedVersionAnnKey = "packaging.carvel.dev/resolved-version"
+	// ResolvedFetchDigestAnnKey records on the App the digest the
+	// fetch was pinned to during that reconcile (if any).
+	ResolvedFetchDigestAnnKey = "packaging.carvel.dev/resolved-fetch-digest"
 )
 
 // nolint: revive
@@ -190,6 +197,10 @@ func (pi *PackageInstallCR) reconcile(modelStatus *reconciler.Status) (reconcile
 			modelStatus.SetReconciling(pi.model.ObjectMeta)
 		case appStatus.IsReconcileSucceeded():
 			modelStatus.SetReconcileCompleted(nil)
+			err := pi.recordResolvedVersion(existingApp)
+			if err != nil {
+				return reconcile.Result{Requeue: true}, err
+			}
 		case appStatus.IsReconcileFailed():
 			modelStatus.SetUsefulErrorMessage(existingApp.Status.UsefulErrorMessage)
 			modelStatus.SetReconcileCompleted(fmt.Errorf("Error (see .status.usefulErrorMessage for details)"))
@@ -235,6 +246,47 @@ func (pi *PackageInstallCR) reconcileAppWithPackage(existingApp *kcv1alpha1.App,
 	return reconcile.Result{}, nil
 }
 
+// recordResolvedVersion annotates the App with the package version
+// (and pinned fetch digest, if any) that reconciled successfully so
+// that kubectl get shows it without inspecting status. The version is
+// taken from the App's own package-version annotation since the App's
+// current spec is what reconciled.
+func (pi *PackageInstallCR) recordResolvedVersion(app *kcv1alpha1.App) error {
+	resolvedAnns := map[string]string{
+		ResolvedVersionAnnKey: app.Annotations["packaging.carvel.dev/package-version"],
+	}
+	if app.Status.Fetch != nil && app.Status.Fetch.PinnedDigest != "" {
+		resolvedAnns[ResolvedFetchDigestAnnKey] = app.Status.Fetch.PinnedDigest
+	}
+
+	changed := false
+	for key, value := range resolvedAnns {
+		if app.Annotations[key] != value {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	annotatedApp := app.DeepCopy()
+	if annotatedApp.Annotations == nil {
+		annotatedApp.Annotations = map[string]string{}
+	}
+	for key, value := range resolvedAnns {
+		annotatedApp.Annotations[key] = value
+	}
+
+	updatedApp, err := pi.kcclient.KappctrlV1alpha1().Apps(app.Namespace).Update(
+		context.Background(), annotatedApp, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("Recording resolved version: %s", err)
+	}
+
+	*app = *updatedApp
+	return nil
+}
+
 func pkgHasK8sConstraint(pkg *datapkgingv1alpha1.Package) bool {
 	return pkg.Spec.KubernetesVersionSelection != nil && pkg.Spec.KubernetesVersionSelection.Constraints != ""
 }
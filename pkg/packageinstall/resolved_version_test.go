@@ -0,0 +1,69 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package packageinstall
+
+import (
+	"context"
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	fakekappctrl "carvel.dev/kapp-controller/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func Test_RecordResolvedVersion_SetsAnnotations(t *testing.T) {
+	app := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "instl-pkg",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"packaging.carvel.dev/package-ref-name": "pkg.test.carvel.dev",
+				"packaging.carvel.dev/package-version":  "1.0.0",
+			},
+		},
+		Status: v1alpha1.AppStatus{
+			Fetch: &v1alpha1.AppStatusFetch{PinnedDigest: "sha256:abc123"},
+		},
+	}
+
+	fakekctrl := fakekappctrl.NewSimpleClientset(app)
+	pi := PackageInstallCR{kcclient: fakekctrl, log: logf.Log.WithName("kc")}
+
+	err := pi.recordResolvedVersion(app)
+	require.NoError(t, err)
+
+	updatedApp, err := fakekctrl.KappctrlV1alpha1().Apps("default").Get(context.Background(), "instl-pkg", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.0", updatedApp.Annotations[ResolvedVersionAnnKey])
+	assert.Equal(t, "sha256:abc123", updatedApp.Annotations[ResolvedFetchDigestAnnKey])
+
+	// In-memory copy reflects the write for the rest of the reconcile
+	assert.Equal(t, "1.0.0", app.Annotations[ResolvedVersionAnnKey])
+}
+
+func Test_RecordResolvedVersion_NoUpdateWhenUnchanged(t *testing.T) {
+	app := &v1alpha1.App{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "instl-pkg",
+			Namespace: "default",
+			Annotations: map[string]string{
+				"packaging.carvel.dev/package-version": "1.0.0",
+				ResolvedVersionAnnKey:                  "1.0.0",
+			},
+		},
+	}
+
+	fakekctrl := fakekappctrl.NewSimpleClientset(app)
+	pi := PackageInstallCR{kcclient: fakekctrl, log: logf.Log.WithName("kc")}
+
+	err := pi.recordResolvedVersion(app)
+	require.NoError(t, err)
+
+	for _, action := range fakekctrl.Actions() {
+		assert.NotEqual(t, "update", action.GetVerb(), "Expected no update for unchanged annotations")
+	}
+}
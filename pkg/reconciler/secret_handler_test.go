@@ -0,0 +1,112 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package reconciler_test
+
+import (
+	"context"
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/reconciler"
+	"carvel.dev/kapp-controller/pkg/reftracker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func testSecret(name, ns string) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}}
+}
+
+func Test_SecretHandler_UpdateEnqueuesTrackedApps(t *testing.T) {
+	refTracker := reftracker.NewAppRefTracker()
+	updateStatus := reftracker.NewAppUpdateStatus()
+
+	appKey := reftracker.NewAppKey("tracked-app", "default")
+	secretKey := reftracker.NewSecretKey("tracked-secret", "default")
+	refTracker.ReconcileRefs(map[reftracker.RefKey]struct{}{secretKey: {}}, appKey)
+
+	handler := reconciler.NewSecretHandler(logf.Log.WithName("test"), refTracker, updateStatus)
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	handler.Update(context.Background(), event.UpdateEvent{
+		ObjectOld: testSecret("tracked-secret", "default"),
+		ObjectNew: testSecret("tracked-secret", "default"),
+	}, queue)
+
+	require.Equal(t, 1, queue.Len())
+
+	item, _ := queue.Get()
+	request, ok := item.(reconcile.Request)
+	require.True(t, ok)
+	assert.Equal(t, "tracked-app", request.Name)
+	assert.Equal(t, "default", request.Namespace)
+
+	assert.True(t, updateStatus.IsUpdateNeeded(appKey))
+}
+
+func Test_SecretHandler_UpdateIgnoresUntrackedSecrets(t *testing.T) {
+	refTracker := reftracker.NewAppRefTracker()
+	updateStatus := reftracker.NewAppUpdateStatus()
+
+	handler := reconciler.NewSecretHandler(logf.Log.WithName("test"), refTracker, updateStatus)
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	handler.Update(context.Background(), event.UpdateEvent{
+		ObjectOld: testSecret("untracked-secret", "default"),
+		ObjectNew: testSecret("untracked-secret", "default"),
+	}, queue)
+
+	assert.Equal(t, 0, queue.Len())
+}
+
+func Test_SecretHandler_UpdateEnqueuesTrackedPackageRepositories(t *testing.T) {
+	refTracker := reftracker.NewAppRefTracker()
+	updateStatus := reftracker.NewAppUpdateStatus()
+
+	pkgrKey := reftracker.NewPackageRepositoryKey("tracked-repo", "default")
+	secretKey := reftracker.NewSecretKey("repo-secret", "default")
+	refTracker.ReconcileRefs(map[reftracker.RefKey]struct{}{secretKey: {}}, pkgrKey)
+
+	handler := reconciler.NewSecretHandler(logf.Log.WithName("test"), refTracker, updateStatus)
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	handler.Update(context.Background(), event.UpdateEvent{
+		ObjectOld: testSecret("repo-secret", "default"),
+		ObjectNew: testSecret("repo-secret", "default"),
+	}, queue)
+
+	require.Equal(t, 1, queue.Len())
+
+	item, _ := queue.Get()
+	request, ok := item.(reconcile.Request)
+	require.True(t, ok)
+	assert.Equal(t, "tracked-repo", request.Name)
+}
+
+func Test_SecretHandler_DeleteRemovesRefTracking(t *testing.T) {
+	refTracker := reftracker.NewAppRefTracker()
+	updateStatus := reftracker.NewAppUpdateStatus()
+
+	appKey := reftracker.NewAppKey("tracked-app", "default")
+	secretKey := reftracker.NewSecretKey("tracked-secret", "default")
+	refTracker.ReconcileRefs(map[reftracker.RefKey]struct{}{secretKey: {}}, appKey)
+
+	handler := reconciler.NewSecretHandler(logf.Log.WithName("test"), refTracker, updateStatus)
+	queue := workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+	handler.Delete(context.Background(), event.DeleteEvent{
+		Object: testSecret("tracked-secret", "default"),
+	}, queue)
+
+	// Deletion enqueues tracked apps once, then stops tracking the secret
+	require.Equal(t, 1, queue.Len())
+
+	_, err := refTracker.AppsForRef(secretKey)
+	assert.Error(t, err)
+}
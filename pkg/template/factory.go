@@ -50,3 +50,9 @@ func (f Factory) NewSops(
 func (f Factory) NewCue(opts v1alpha1.AppTemplateCue, appContext AppContext, additionalValues AdditionalDownwardAPIValues) Template {
 	return newCue(opts, appContext, f.coreClient, f.cmdRunner, additionalValues)
 }
+
+// NewValues returns data values backed by the given sources, e.g. to
+// render what a template step consumed via Values.Resolved.
+func (f Factory) NewValues(valuesFrom []v1alpha1.AppTemplateValuesSource, appContext AppContext, additionalValues AdditionalDownwardAPIValues) Values {
+	return Values{valuesFrom, additionalValues, appContext, f.coreClient}
+}
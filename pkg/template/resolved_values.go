@@ -0,0 +1,179 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"carvel.dev/kapp-controller/pkg/memdir"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// redactedValue replaces data values that must not be surfaced in
+// status output.
+const redactedValue = "<redacted>"
+
+// sensitiveValueKeyRegexp matches data value keys whose contents are
+// likely sensitive even when they do not come from a Secret.
+var sensitiveValueKeyRegexp = regexp.MustCompile(`(?i)(password|passwd|secret|token|credential|api[-_]?key|private)`)
+
+// Resolved renders the data values documents handed to the templating
+// tool as one multi-document YAML string so they can be surfaced for
+// reproducibility. All values sourced from Secrets and values whose
+// keys look sensitive are redacted.
+func (t Values) Resolved(dirPath string) (string, error) {
+	var docs []string
+
+	for _, source := range t.ValuesFrom {
+		switch {
+		case source.SecretRef != nil:
+			secret, err := t.coreClient.CoreV1().Secrets(t.appContext.Namespace).Get(
+				context.Background(), source.SecretRef.Name, metav1.GetOptions{})
+			if err != nil {
+				return "", err
+			}
+
+			var names []string
+			for name := range secret.Data {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				docs = append(docs, redactDoc(secret.Data[name], true))
+			}
+
+		case source.ConfigMapRef != nil:
+			configMap, err := t.coreClient.CoreV1().ConfigMaps(t.appContext.Namespace).Get(
+				context.Background(), source.ConfigMapRef.Name, metav1.GetOptions{})
+			if err != nil {
+				return "", err
+			}
+
+			var names []string
+			for name := range configMap.Data {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+
+			for _, name := range names {
+				docs = append(docs, redactDoc([]byte(configMap.Data[name]), false))
+			}
+
+		case len(source.Path) > 0:
+			if source.Path == stdinPath {
+				continue
+			}
+			checkedPath, err := memdir.ScopedPath(dirPath, source.Path)
+			if err != nil {
+				continue
+			}
+			contents, err := os.ReadFile(checkedPath)
+			if err != nil {
+				return "", fmt.Errorf("Reading data values file '%s': %s", source.Path, err)
+			}
+			docs = append(docs, redactDoc(contents, false))
+
+		case source.DownwardAPI != nil:
+			downwardAPIValues := DownwardAPIValues{
+				items:                       source.DownwardAPI.Items,
+				metadata:                    t.appContext.Metadata,
+				additionalDownwardAPIValues: t.AdditionalValues,
+			}
+			dataValues, err := downwardAPIValues.AsYAMLs()
+			if err != nil {
+				return "", err
+			}
+			for _, content := range dataValues {
+				docs = append(docs, redactDoc(content, false))
+			}
+		}
+	}
+
+	if len(docs) == 0 {
+		return "", nil
+	}
+
+	return strings.Join(docs, "---\n"), nil
+}
+
+// redactDoc redacts a single data values YAML document: all values
+// when it came from a Secret, otherwise only values under keys that
+// look sensitive. Documents that do not parse as a map are redacted
+// wholesale when secret and passed through unchanged otherwise.
+func redactDoc(contents []byte, fromSecret bool) string {
+	var doc map[string]interface{}
+
+	err := yaml.Unmarshal(contents, &doc)
+	if err != nil || doc == nil {
+		if fromSecret {
+			return redactedValue + "\n"
+		}
+		return strings.TrimSuffix(string(contents), "\n") + "\n"
+	}
+
+	redactValues(doc, fromSecret)
+
+	bs, err := yaml.Marshal(doc)
+	if err != nil {
+		return redactedValue + "\n"
+	}
+	return string(bs)
+}
+
+func redactValues(doc map[string]interface{}, redactAll bool) {
+	for key, val := range doc {
+		redactVal := redactAll || sensitiveValueKeyRegexp.MatchString(key)
+		switch typedVal := val.(type) {
+		case map[string]interface{}:
+			redactValues(typedVal, redactVal)
+		case []interface{}:
+			redactListValues(typedVal, redactVal)
+		default:
+			if redactVal {
+				doc[key] = redactedValue
+			}
+		}
+	}
+}
+
+// redactListValues walks list items so that sensitive keys nested in
+// lists (e.g. users: [{name: ..., password: ...}]) are redacted too;
+// scalar items are redacted only when the list itself is sensitive.
+func redactListValues(list []interface{}, redactAll bool) {
+	for i, item := range list {
+		switch typedItem := item.(type) {
+		case map[string]interface{}:
+			redactValues(typedItem, redactAll)
+		case []interface{}:
+			redactListValues(typedItem, redactAll)
+		default:
+			if redactAll {
+				list[i] = redactedValue
+			}
+		}
+	}
+}
+
+// ValuesSources extracts the data values sources a template step
+// consumes (empty for steps that take no values).
+func ValuesSources(tpl v1alpha1.AppTemplate) []v1alpha1.AppTemplateValuesSource {
+	switch {
+	case tpl.Ytt != nil:
+		return tpl.Ytt.ValuesFrom
+	case tpl.HelmTemplate != nil:
+		return tpl.HelmTemplate.ValuesFrom
+	case tpl.Cue != nil:
+		return tpl.Cue.ValuesFrom
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"os"
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolvedValuesRedactsSecretSources(t *testing.T) {
+	coreClient := k8sfake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vals-secret", Namespace: "some-namespace"},
+		Data:       map[string][]byte{"vals.yml": []byte("db_user: admin\ndb_pass: hunter2\n")},
+	})
+
+	subject := Values{
+		ValuesFrom: []v1alpha1.AppTemplateValuesSource{
+			{SecretRef: &v1alpha1.AppTemplateValuesSourceRef{Name: "vals-secret"}},
+		},
+		appContext: AppContext{Namespace: "some-namespace"},
+		coreClient: coreClient,
+	}
+
+	resolved, err := subject.Resolved(os.TempDir())
+	require.NoError(t, err)
+
+	assert.Contains(t, resolved, "db_user: <redacted>")
+	assert.Contains(t, resolved, "db_pass: <redacted>")
+	assert.NotContains(t, resolved, "admin")
+	assert.NotContains(t, resolved, "hunter2")
+}
+
+func TestResolvedValuesRedactsSensitiveKeys(t *testing.T) {
+	coreClient := k8sfake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "vals-cm", Namespace: "some-namespace"},
+		Data: map[string]string{
+			"vals.yml": "replicas: 3\napi_token: abcd1234\nauth:\n  password: hunter2\n",
+		},
+	})
+
+	subject := Values{
+		ValuesFrom: []v1alpha1.AppTemplateValuesSource{
+			{ConfigMapRef: &v1alpha1.AppTemplateValuesSourceRef{Name: "vals-cm"}},
+		},
+		appContext: AppContext{Namespace: "some-namespace"},
+		coreClient: coreClient,
+	}
+
+	resolved, err := subject.Resolved(os.TempDir())
+	require.NoError(t, err)
+
+	assert.Contains(t, resolved, "replicas: 3")
+	assert.Contains(t, resolved, "api_token: <redacted>")
+	assert.Contains(t, resolved, "password: <redacted>")
+	assert.NotContains(t, resolved, "abcd1234")
+	assert.NotContains(t, resolved, "hunter2")
+}
+
+func TestResolvedValuesRedactsSensitiveKeysNestedInLists(t *testing.T) {
+	coreClient := k8sfake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "vals-cm", Namespace: "some-namespace"},
+		Data: map[string]string{
+			"vals.yml": "users:\n- name: alice\n  password: hunter2\ntokens:\n- abcd1234\n",
+		},
+	})
+
+	subject := Values{
+		ValuesFrom: []v1alpha1.AppTemplateValuesSource{
+			{ConfigMapRef: &v1alpha1.AppTemplateValuesSourceRef{Name: "vals-cm"}},
+		},
+		appContext: AppContext{Namespace: "some-namespace"},
+		coreClient: coreClient,
+	}
+
+	resolved, err := subject.Resolved(os.TempDir())
+	require.NoError(t, err)
+
+	assert.Contains(t, resolved, "name: alice")
+	assert.Contains(t, resolved, "password: <redacted>")
+	// every item of a list under a sensitive key is redacted
+	assert.Contains(t, resolved, "- <redacted>")
+	assert.NotContains(t, resolved, "hunter2")
+	assert.NotContains(t, resolved, "abcd1234")
+}
+
+func TestResolvedValuesRendersDownwardAPISources(t *testing.T) {
+	subject := Values{
+		ValuesFrom: []v1alpha1.AppTemplateValuesSource{
+			{DownwardAPI: &v1alpha1.AppTemplateValuesDownwardAPI{
+				Items: []v1alpha1.AppTemplateValuesDownwardAPIItem{
+					{Name: "app_name", FieldPath: "metadata.name"},
+				},
+			}},
+		},
+		appContext: AppContext{Metadata: PartialObjectMetadata{
+			ObjectMeta: ObjectMeta{Name: "some-name", Namespace: "some-namespace"},
+		}},
+	}
+
+	resolved, err := subject.Resolved(os.TempDir())
+	require.NoError(t, err)
+	assert.Equal(t, "app_name: some-name\n", resolved)
+}
+
+func TestResolvedValuesEmptyWithoutSources(t *testing.T) {
+	resolved, err := Values{}.Resolved(os.TempDir())
+	require.NoError(t, err)
+	assert.Empty(t, resolved)
+}
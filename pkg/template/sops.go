@@ -149,7 +149,11 @@ func (t *Sops) decryptSopsFile(path, newPath string, args, env []string) error {
 
 	err := t.cmdRunner.Run(cmd)
 	if err != nil {
-		return fmt.Errorf("Running sops: %s, %v", stderrBs.String(), err)
+		return SopsDecryptionError{
+			Path:   path,
+			Reason: classifySopsError(stderrBs.String()),
+			Output: strings.TrimSpace(fmt.Sprintf("%s, %v", stderrBs.String(), err)),
+		}
 	}
 
 	err = os.Remove(path)
@@ -0,0 +1,84 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Reasons a SOPS decryption can fail, derived from well-known patterns
+// in sops output.
+const (
+	// SopsErrReasonMissingKey means no usable private key was available
+	// to decrypt the file.
+	SopsErrReasonMissingKey = "missing decryption key"
+	// SopsErrReasonWrongRecipient means keys were available but the file
+	// was encrypted for different recipients.
+	SopsErrReasonWrongRecipient = "encrypted for a different recipient"
+	// SopsErrReasonNoSopsMetadata means the file is not a sops-encrypted
+	// file (or its metadata was stripped).
+	SopsErrReasonNoSopsMetadata = "file has no sops metadata"
+	// SopsErrReasonUnknown covers output that matches no known pattern.
+	SopsErrReasonUnknown = "decryption failed"
+)
+
+// SopsDecryptionError is a structured representation of a failed SOPS
+// decryption: which file failed, a classified reason, and the raw sops
+// output for debugging.
+type SopsDecryptionError struct {
+	Path   string
+	Reason string
+	Output string
+}
+
+// Error implements error. The enclosing decrypt call wraps this with
+// the file path, so it is not repeated here.
+func (e SopsDecryptionError) Error() string {
+	return fmt.Sprintf("Running sops: %s (%s)", e.Output, e.Reason)
+}
+
+// Patterns are matched case-insensitively against sops output. Wrong
+// recipient markers are checked first since the generic data key
+// failure usually accompanies them.
+var (
+	sopsWrongRecipientMarkers = []string{
+		"no identity matched any of the recipients",
+		"could not decrypt data key with any of the master keys",
+		"no pgp key could decrypt",
+	}
+	sopsMissingKeyMarkers = []string{
+		"no secret key",
+		"no identity found",
+		"could not load any of the keys",
+		"failed to get the data key",
+	}
+	sopsNoMetadataMarkers = []string{
+		"sops metadata not found",
+	}
+)
+
+// classifySopsError maps common sops error output to one of the
+// SopsErrReason* constants.
+func classifySopsError(output string) string {
+	lower := strings.ToLower(output)
+
+	for _, marker := range sopsNoMetadataMarkers {
+		if strings.Contains(lower, marker) {
+			return SopsErrReasonNoSopsMetadata
+		}
+	}
+	for _, marker := range sopsWrongRecipientMarkers {
+		if strings.Contains(lower, marker) {
+			return SopsErrReasonWrongRecipient
+		}
+	}
+	for _, marker := range sopsMissingKeyMarkers {
+		if strings.Contains(lower, marker) {
+			return SopsErrReasonMissingKey
+		}
+	}
+
+	return SopsErrReasonUnknown
+}
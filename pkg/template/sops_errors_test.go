@@ -0,0 +1,77 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package template
+
+import (
+	"errors"
+	"fmt"
+	goexec "os/exec"
+	"testing"
+
+	"carvel.dev/kapp-controller/pkg/apis/kappctrl/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifySopsError(t *testing.T) {
+	testCases := []struct {
+		output string
+		reason string
+	}{
+		{"age: no identity matched any of the recipients", SopsErrReasonWrongRecipient},
+		{"Could not decrypt data key with any of the master keys", SopsErrReasonWrongRecipient},
+		{"gpg: decryption failed: No secret key", SopsErrReasonMissingKey},
+		{"Failed to get the data key required to decrypt the SOPS file", SopsErrReasonMissingKey},
+		{"sops metadata not found", SopsErrReasonNoSopsMetadata},
+		{"something else went wrong", SopsErrReasonUnknown},
+		{"", SopsErrReasonUnknown},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.reason, classifySopsError(tc.output), "output: %s", tc.output)
+	}
+}
+
+// sopsFailingCmdRunner writes the given stderr and fails every command
+type sopsFailingCmdRunner struct {
+	stderr string
+}
+
+func (r sopsFailingCmdRunner) Run(cmd *goexec.Cmd) error {
+	fmt.Fprint(cmd.Stderr, r.stderr)
+	return fmt.Errorf("exit status 128")
+}
+
+func (r sopsFailingCmdRunner) RunWithCancel(cmd *goexec.Cmd, _ chan struct{}) error {
+	return r.Run(cmd)
+}
+
+func TestSopsDecryptFailureReturnsTypedError(t *testing.T) {
+	runner := sopsFailingCmdRunner{stderr: "age: no identity matched any of the recipients"}
+	sops := NewSops(v1alpha1.AppTemplateSops{}, AppContext{}, nil, runner)
+
+	err := sops.decryptSopsFile("values.sops.yml", "values.yml", nil, nil)
+	require.Error(t, err)
+
+	var sopsErr SopsDecryptionError
+	require.True(t, errors.As(err, &sopsErr))
+	assert.Equal(t, "values.sops.yml", sopsErr.Path)
+	assert.Equal(t, SopsErrReasonWrongRecipient, sopsErr.Reason)
+	assert.Contains(t, sopsErr.Output, "no identity matched")
+
+	assert.Contains(t, err.Error(), "Running sops:")
+	assert.Contains(t, err.Error(), SopsErrReasonWrongRecipient)
+}
+
+func TestSopsMissingKeyFailureReturnsTypedError(t *testing.T) {
+	runner := sopsFailingCmdRunner{stderr: "gpg: decryption failed: No secret key"}
+	sops := NewSops(v1alpha1.AppTemplateSops{}, AppContext{}, nil, runner)
+
+	err := sops.decryptSopsFile("values.sops.yml", "values.yml", nil, nil)
+	require.Error(t, err)
+
+	var sopsErr SopsDecryptionError
+	require.True(t, errors.As(err, &sopsErr))
+	assert.Equal(t, SopsErrReasonMissingKey, sopsErr.Reason)
+}
@@ -0,0 +1,61 @@
+// Copyright 2024 The Carvel Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tracing emits OpenTelemetry spans for app reconcile stages.
+// Tracing is opt-in: unless Setup is called the global tracer provider
+// stays a no-op, so span creation costs nothing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "carvel.dev/kapp-controller"
+
+// Setup configures the global tracer provider to export spans to an
+// OTLP gRPC collector at the given address. It returns a shutdown
+// function flushing remaining spans.
+func Setup(ctx context.Context, collectorAddr string) (func(context.Context) error, error) {
+	exporter, err := otlptracegrpc.New(ctx,
+		otlptracegrpc.WithEndpoint(collectorAddr), otlptracegrpc.WithInsecure())
+	if err != nil {
+		return nil, fmt.Errorf("Building OTLP trace exporter: %s", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// StartReconcileSpan starts the span covering a full object reconcile,
+// carrying the object's kind, name and namespace as attributes.
+func StartReconcileSpan(ctx context.Context, kind string, name string, namespace string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, "reconcile", trace.WithAttributes(
+		attribute.String("carvel.kind", kind),
+		attribute.String("k8s.name", name),
+		attribute.String("k8s.namespace", namespace),
+	))
+}
+
+// StartStageSpan starts a span covering a single reconcile stage
+// (fetch, template, deploy) as a child of the reconcile span in ctx.
+func StartStageSpan(ctx context.Context, stage string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, stage, trace.WithAttributes(
+		attribute.String("carvel.stage", stage),
+	))
+}
+
+// RecordError records a non-nil error on the span.
+func RecordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+}